@@ -34,19 +34,49 @@ import (
 	"github.com/yoockh/go-game-rental-api/app/echo-server/router"
 	_ "github.com/yoockh/go-game-rental-api/docs"
 	"github.com/yoockh/go-game-rental-api/internal/handler"
+	"github.com/yoockh/go-game-rental-api/internal/model"
 	"github.com/yoockh/go-game-rental-api/internal/repository"
 	"github.com/yoockh/go-game-rental-api/internal/repository/email"
+	"github.com/yoockh/go-game-rental-api/internal/repository/storage"
 	"github.com/yoockh/go-game-rental-api/internal/repository/transaction"
 	"github.com/yoockh/go-game-rental-api/internal/service"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// Shown as thumbnail_url for games without images unless GAME_PLACEHOLDER_IMAGE_URL is set
+const defaultGamePlaceholderImage = "https://placehold.co/600x400?text=No+Image"
+
+// requestLogFormat is Echo's default access log with ${path} instead of ${uri}, so query
+// strings such as /auth/verify-email?token= never reach the logs
+var requestLogFormat = strings.Replace(middleware.DefaultLoggerConfig.Format, `"uri":"${uri}"`, `"path":"${path}"`, 1)
+
+// newGormLogger writes through logrus and never interpolates query values, which would
+// otherwise put password and token hashes in the logs. Every query is logged only at debug.
+func newGormLogger(level logrus.Level) logger.Interface {
+	gormLevel := logger.Warn
+	if level >= logrus.DebugLevel {
+		gormLevel = logger.Info
+	}
+	return logger.New(logrus.StandardLogger(), logger.Config{
+		SlowThreshold:             200 * time.Millisecond,
+		LogLevel:                  gormLevel,
+		IgnoreRecordNotFoundError: true,
+		ParameterizedQueries:      true,
+	})
+}
+
 func main() {
 	// Setup logrus
 	logrus.SetFormatter(&logrus.JSONFormatter{})
-	logrus.SetLevel(logrus.InfoLevel)
+	logLevel, err := logrus.ParseLevel(utils.GetEnvString("LOG_LEVEL", "info"))
+	if err != nil {
+		logLevel = logrus.InfoLevel
+		logrus.Warnf("Invalid LOG_LEVEL %q, using info", os.Getenv("LOG_LEVEL"))
+	}
+	logrus.SetLevel(logLevel)
 
 	cfg := myConfig.LoadEnv()
 	JwtSecret := os.Getenv("JWT_SECRET")
@@ -77,7 +107,7 @@ func main() {
 	}), &gorm.Config{
 		PrepareStmt:            false, // globally disable prepared statements
 		SkipDefaultTransaction: true,
-		Logger:                 logger.Default.LogMode(logger.Info),
+		Logger:                 newGormLogger(logLevel),
 	})
 
 	if err != nil {
@@ -119,17 +149,28 @@ func main() {
 	bookingRepo := repository.NewBookingRepository(db)
 	paymentRepo := repository.NewPaymentRepository(db)
 	reviewRepo := repository.NewReviewRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	emailVerificationRepo := repository.NewEmailVerificationRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	gameReportRepo := repository.NewGameReportRepository(db)
+	bookingAuditRepo := repository.NewBookingAuditRepository(db)
 
 	// Initialize 3rd party repositories with fallback to mock
 	var emailRepo email.EmailRepository
 	var transactionRepo transaction.TransactionRepository
+	var stripeRepo transaction.TransactionRepository
+	var storageRepo storage.StorageRepository
 
-	if repo, err := email.NewSendGridRepository(); err != nil {
+	if !utils.GetEnvBool("EMAILS_ENABLED", true) {
+		logrus.Warn("EMAILS_ENABLED=false, outgoing emails will only be logged")
+		emailRepo = &email.DisabledEmailRepository{}
+	} else if repo, err := email.NewSendGridRepository(); err != nil {
 		logrus.Warn("SendGrid failed, using mock:", err)
 		emailRepo = &email.MockEmailRepository{}
 	} else {
 		emailRepo = repo
 	}
+	emailRepo = email.NewLoggingEmailRepository(emailRepo)
 
 	if repo, err := transaction.NewMidtransRepository(); err != nil {
 		logrus.Warn("Midtrans failed, using mock:", err)
@@ -138,31 +179,72 @@ func main() {
 		transactionRepo = repo
 	}
 
+	if repo, err := transaction.NewStripeRepository(); err != nil {
+		logrus.Warn("Stripe disabled: ", err)
+	} else {
+		stripeRepo = repo
+	}
+
+	if repo, err := storage.NewSupabaseRepository(); err != nil {
+		logrus.Warn("Supabase storage failed, using mock:", err)
+		storageRepo = &storage.MockStorageRepository{}
+	} else {
+		storageRepo = repo
+	}
+
 	// Initialize services
-	userService := service.NewUserService(userRepo)
+	userService := service.NewUserService(userRepo, emailVerificationRepo, refreshTokenRepo, utils.GetEnvBool("REQUIRE_EMAIL_VERIFICATION", false),
+		utils.GetEnvBool("REGISTRATION_ENABLED", true))
 	categoryService := service.NewCategoryService(categoryRepo)
-	gameService := service.NewGameService(gameRepo)
-	bookingService := service.NewBookingService(bookingRepo, gameRepo, userRepo, emailRepo)
-	paymentService := service.NewPaymentService(paymentRepo, bookingRepo, userRepo, gameRepo, bookingService, transactionRepo, emailRepo)
-	reviewService := service.NewReviewService(reviewRepo, bookingRepo)
+	gameService := service.NewGameService(gameRepo, storageRepo)
+	notificationService := service.NewNotificationService(notificationRepo)
+	bookingService := service.NewBookingService(bookingRepo, gameRepo, userRepo, emailRepo, notificationService, bookingAuditRepo,
+		utils.GetEnvInt("BOOKING_MAX_ADVANCE_DAYS", 90))
+	depositMode := model.DepositMode(strings.ToLower(utils.GetEnvString("DEPOSIT_MODE", string(model.DepositCharge))))
+	if !depositMode.IsValid() {
+		logrus.Warnf("Invalid DEPOSIT_MODE %q, using charge", depositMode)
+		depositMode = model.DepositCharge
+	}
+	paymentService := service.NewPaymentService(paymentRepo, bookingRepo, userRepo, gameRepo, bookingService, transactionRepo, stripeRepo, emailRepo, notificationService, bookingAuditRepo,
+		time.Duration(utils.GetEnvInt("PAYMENT_EXPIRY_MINUTES", 0))*time.Minute, depositMode)
+	reviewService := service.NewReviewService(reviewRepo, bookingRepo, gameRepo)
+	dashboardService := service.NewDashboardService(userRepo, gameRepo, bookingRepo, paymentRepo)
+	gameReportService := service.NewGameReportService(gameReportRepo, gameRepo, utils.GetEnvInt("GAME_REPORT_FLAG_THRESHOLD", 3))
 
 	// Initialize handlers
-	authHandler := handler.NewAuthHandler(userService, JwtSecret, emailRepo)
-	userHandler := handler.NewUserHandler(userService)
+	passwordResetURL := utils.GetEnvString("PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+	authHandler := handler.NewAuthHandler(userService, JwtSecret, emailRepo, passwordResetURL,
+		utils.GetEnvString("EMAIL_VERIFICATION_URL", "http://localhost:8080/auth/verify-email"))
+	userHandler := handler.NewUserHandler(userService, bookingService, emailRepo, passwordResetURL)
 	categoryHandler := handler.NewCategoryHandler(categoryService)
-	gameHandler := handler.NewGameHandler(gameService)
+	gameHandler := handler.NewGameHandler(gameService, reviewService, utils.GetEnvString("GAME_PLACEHOLDER_IMAGE_URL", defaultGamePlaceholderImage))
 	bookingHandler := handler.NewBookingHandler(bookingService)
 	paymentHandler := handler.NewPaymentHandler(paymentService)
 	reviewHandler := handler.NewReviewHandler(reviewService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	dashboardHandler := handler.NewDashboardHandler(dashboardService)
+	gameReportHandler := handler.NewGameReportHandler(gameReportService)
 
 	// Setup Echo
 	e := echo.New()
-	e.Use(middleware.Logger())
+	// Bound every request so a stuck handler can't hold the single DB connection forever.
+	// Registered first, as Echo's timeout middleware requires. The CSV export streams
+	// and is left out, since the timeout buffers the whole response.
+	e.Use(utils.RequestTimeout(time.Duration(utils.GetEnvInt("REQUEST_TIMEOUT_SECONDS", 30))*time.Second, "/admin/payments/export"))
+	e.Use(middleware.RequestID())
+	e.Use(utils.RequestIDContext())
+	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{Format: requestLogFormat}))
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	// Public catalog rate limit, separate from authenticated traffic
+	publicLimiter := utils.PublicRateLimiter(
+		utils.GetEnvFloat("PUBLIC_RATE_LIMIT_RPS", 5),
+		utils.GetEnvInt("PUBLIC_RATE_LIMIT_BURST", 20),
+	)
+
 	// Register routes
 	router.RegisterRoutes(
 		e,
@@ -173,7 +255,11 @@ func main() {
 		bookingHandler,
 		paymentHandler,
 		reviewHandler,
+		notificationHandler,
+		dashboardHandler,
+		gameReportHandler,
 		JwtSecret,
+		publicLimiter,
 	)
 
 	port := cfg.Port
@@ -15,18 +15,33 @@ func RegisterRoutes(
 	bookingH *handler.BookingHandler,
 	paymentH *handler.PaymentHandler,
 	reviewH *handler.ReviewHandler,
+	notificationH *handler.NotificationHandler,
+	dashboardH *handler.DashboardHandler,
+	gameReportH *handler.GameReportHandler,
 	jwtSecret string,
+	publicLimiter echo.MiddlewareFunc,
 ) {
 	// Public endpoints
 	e.POST("/auth/register", authH.Register)
 	e.POST("/auth/login", authH.Login)
-	e.GET("/games", gameH.GetAllGames)
-	e.GET("/games/:id", gameH.GetGameDetail)
-	e.GET("/games/search", gameH.SearchGames)
-	e.GET("/categories", categoryH.GetAllCategories)
-	e.GET("/categories/:id", categoryH.GetCategoryDetail)
-	e.GET("/games/:game_id/reviews", reviewH.GetGameReviews)
+	e.POST("/auth/refresh", authH.RefreshToken)
+	e.POST("/auth/logout", authH.Logout)
+	e.POST("/auth/forgot-password", authH.RequestPasswordReset)
+	e.POST("/auth/reset-password", authH.ResetPassword)
+	e.GET("/auth/verify-email", authH.VerifyEmail)
+
+	// Public catalog, throttled per IP against scraping
+	catalog := e.Group("", publicLimiter)
+	catalog.GET("/games", gameH.GetAllGames)
+	catalog.GET("/games/:id", gameH.GetGameDetail)
+	catalog.GET("/games/search", gameH.SearchGames)
+	catalog.GET("/games/slug/:slug", gameH.GetGameBySlug)
+	catalog.GET("/categories", categoryH.GetAllCategories)
+	catalog.GET("/categories/:id", categoryH.GetCategoryDetail)
+	catalog.GET("/games/:game_id/reviews", reviewH.GetGameReviews)
+	catalog.POST("/games/:id/quote", bookingH.QuoteBooking)
 	e.POST("/webhooks/payments", paymentH.PaymentWebhook)
+	e.POST("/webhooks/stripe", paymentH.StripeWebhook)
 
 	// Protected routes
 	jwtConfig := myMiddleware.JWTConfig{
@@ -39,39 +54,80 @@ func RegisterRoutes(
 
 	protected.GET("/users/me", userH.GetMyProfile)
 	protected.PUT("/users/me", userH.UpdateMyProfile)
+	protected.PUT("/users/me/password", userH.ChangeMyPassword)
+	protected.GET("/users/me/permissions", userH.GetMyPermissions)
+	protected.GET("/users/me/reviews", reviewH.GetMyReviews)
+	protected.GET("/users/me/notifications", notificationH.GetMyNotifications)
+	protected.POST("/users/me/notifications/:id/read", notificationH.MarkNotificationRead)
 
 	protected.POST("/bookings", bookingH.CreateBooking)
 	protected.GET("/bookings/my", bookingH.GetMyBookings)
 	protected.GET("/bookings/:booking_id", bookingH.GetBookingDetail)
+	protected.GET("/bookings/:booking_id/deposit", bookingH.GetBookingDeposit)
 	protected.PATCH("/bookings/:booking_id/cancel", bookingH.CancelBooking)
+	protected.PATCH("/bookings/:booking_id/extend", bookingH.ExtendBooking)
 
 	protected.POST("/bookings/:booking_id/payments", paymentH.CreatePayment)
 	protected.GET("/bookings/:booking_id/payments", paymentH.GetPaymentByBooking)
+	protected.GET("/payments/:id/booking", paymentH.GetBookingByPayment)
 
 	protected.POST("/bookings/:booking_id/reviews", reviewH.CreateReview)
+	protected.GET("/bookings/:booking_id/can-review", reviewH.CanReview)
+	protected.PUT("/reviews/:id", reviewH.UpdateReview)
+	protected.DELETE("/reviews/:id", reviewH.DeleteReview)
+	protected.POST("/reviews/:id/reply", reviewH.ReplyToReview)
+
+	protected.POST("/games/:id/report", gameReportH.ReportGame)
 
 	// Admin routes
 	admin := protected.Group("/admin")
 	admin.Use(myMiddleware.RequireRoles("admin", "super_admin")) // BALIK PAKAI INI
 
+	admin.GET("/counts", dashboardH.GetCounts)
+	admin.GET("/stats", dashboardH.GetStats)
+
+	admin.GET("/games", gameH.GetAllGamesAdmin)
 	admin.POST("/games", gameH.CreateGame)
+	admin.POST("/games/bulk-status", gameH.BulkSetGameStatus)
 	admin.PUT("/games/:id", gameH.UpdateGame)
 	admin.DELETE("/games/:id", gameH.DeleteGame)
+	admin.POST("/games/:id/images", gameH.UploadGameImage)
+	admin.DELETE("/games/:id/images", gameH.DeleteGameImage)
+	admin.GET("/game-reports", gameReportH.GetReportSummaries)
+	admin.GET("/game-reports/:id", gameReportH.GetGameReports)
 
+	admin.GET("/categories", categoryH.GetAllCategoriesAdmin)
+	admin.GET("/categories/:id", categoryH.GetCategoryDetailAdmin)
 	admin.POST("/categories", categoryH.CreateCategory)
 	admin.PUT("/categories/:id", categoryH.UpdateCategory)
 	admin.DELETE("/categories/:id", categoryH.DeleteCategory)
 
 	admin.GET("/bookings", bookingH.GetAllBookings)
+	admin.GET("/bookings/search", bookingH.SearchBookings)
+	admin.POST("/bookings/process-overdue", bookingH.ProcessOverdueBookings)
 	admin.PATCH("/bookings/:id/status", bookingH.UpdateBookingStatus)
+	admin.POST("/bookings/:id/return", bookingH.ConfirmReturn)
+	admin.DELETE("/bookings/:id", bookingH.DeleteBooking)
+	admin.POST("/bookings/:id/restore", bookingH.RestoreBooking)
+	admin.GET("/bookings/:id/audit", bookingH.GetBookingAudit)
+	admin.POST("/bookings/:id/resend-confirmation", bookingH.ResendConfirmation)
 
 	admin.GET("/payments", paymentH.GetAllPayments)
 	admin.GET("/payments/:id", paymentH.GetPaymentDetail)
 	admin.GET("/payments/status", paymentH.GetPaymentsByStatus)
+	admin.GET("/payments/revenue", paymentH.GetRevenueReport)
+	admin.GET("/payments/export", paymentH.ExportPayments)
+	admin.GET("/payments/search", paymentH.SearchPaymentByProviderID)
+	admin.POST("/payments/:id/mark-paid", paymentH.MarkPaymentPaid)
+	admin.POST("/payments/:id/mark-failed", paymentH.MarkPaymentFailed)
+	admin.POST("/payments/:id/refund", paymentH.RefundPayment)
+	admin.POST("/payments/:id/capture", paymentH.CaptureDepositHold)
+	admin.POST("/payments/:id/void", paymentH.VoidDepositHold)
 
 	admin.GET("/users", userH.GetAllUsers)
+	admin.POST("/users", userH.CreateUser)
 	admin.GET("/users/:id", userH.GetUserDetail)
 	admin.PATCH("/users/:id/role", userH.UpdateUserRole)
-	admin.PATCH("/users/:id/status", userH.ToggleUserStatus)
+	admin.PATCH("/users/:id/status", userH.SetUserStatus)
 	admin.DELETE("/users/:id", userH.DeleteUser)
 }
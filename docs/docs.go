@@ -83,14 +83,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/bookings/{id}/status": {
-            "patch": {
+        "/admin/bookings/process-overdue": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update booking status (Admin only)",
+                "description": "Mark active bookings past their end date as overdue and email each customer. Safe to run repeatedly, e.g. from a daily scheduler (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -100,40 +100,10 @@ const docTemplate = `{
                 "tags": [
                     "Admin - Bookings"
                 ],
-                "summary": "Update booking status",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Booking ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "New status",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "status": {
-                                    "type": "string"
-                                }
-                            }
-                        }
-                    }
-                ],
+                "summary": "Process overdue bookings",
                 "responses": {
                     "200": {
-                        "description": "Booking status updated successfully",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid booking ID",
+                        "description": "Overdue bookings processed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -156,14 +126,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/categories": {
-            "post": {
+        "/admin/bookings/search": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new game category (Admin only)",
+                "description": "Search bookings by notes, customer email or name, and game name (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -171,30 +141,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Categories"
+                    "Admin - Bookings"
                 ],
-                "summary": "Create category",
+                "summary": "Search bookings",
                 "parameters": [
                     {
-                        "description": "Category details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateCategoryRequest"
-                        }
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Category created successfully",
+                    "200": {
+                        "description": "Bookings search results",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Search query required",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -217,14 +199,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/categories/{id}": {
-            "put": {
+        "/admin/bookings/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update category information (Admin only)",
+                "description": "Soft-delete a booking so it disappears from lists; live bookings are cancelled and their stock released. Restorable for 30 days (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -232,65 +214,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Categories"
+                    "Admin - Bookings"
                 ],
-                "summary": "Update category",
+                "summary": "Delete booking",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated category details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateCategoryRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category updated successfully",
+                        "description": "Booking deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid booking ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Booking is active",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/bookings/{id}/audit": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a category (Admin only)",
+                "description": "List admin changes to a booking, newest first, with the old and new value of each changed field and who made it. Automatic overdue transitions have no actor (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -298,28 +280,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Categories"
+                    "Admin - Bookings"
                 ],
-                "summary": "Delete category",
+                "summary": "Get booking audit log",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category deleted successfully",
+                        "description": "Booking audit log retrieved successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid category ID",
+                        "description": "Invalid booking ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -342,14 +338,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/games": {
+        "/admin/bookings/{id}/resend-confirmation": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new game listing (Admin only)",
+                "description": "Re-send the booking confirmation (pending) or payment confirmation (paid) email to the customer. Limited to once per 10 minutes per booking (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -357,44 +353,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Games"
+                    "Admin - Bookings"
                 ],
-                "summary": "Create new game",
+                "summary": "Resend booking confirmation",
                 "parameters": [
                     {
-                        "description": "Game details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateGameRequest"
-                        }
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Game created successfully",
+                    "200": {
+                        "description": "Confirmation email resent",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid booking ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Booking is cancelled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Resent too recently",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "502": {
+                        "description": "Email provider failed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -403,14 +418,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/games/{id}": {
-            "put": {
+        "/admin/bookings/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update game information (Admin only)",
+                "description": "Restore a soft-deleted booking deleted within the last 30 days (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -418,65 +433,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Games"
+                    "Admin - Bookings"
                 ],
-                "summary": "Update game",
+                "summary": "Restore booking",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Game ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated game details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateGameRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Game updated successfully",
+                        "description": "Booking restored successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid booking ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Deleted booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Restore window has passed",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/bookings/{id}/return": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a game (Admin only)",
+                "description": "Complete an active or overdue booking and put the copy back in stock. Returns after the end date are charged the game's late_fee_per_day for each late day as a pending late_fee payment (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -484,13 +499,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Games"
+                    "Admin - Bookings"
                 ],
-                "summary": "Delete game",
+                "summary": "Confirm game return",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Game ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -498,28 +513,34 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Game deleted successfully",
+                        "description": "Return confirmed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Booking"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Invalid game ID",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Booking not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "409": {
+                        "description": "Booking is not out",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -528,14 +549,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/payments": {
-            "get": {
+        "/admin/bookings/{id}/status": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of all payments (Admin only)",
+                "description": "Update booking status (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -543,28 +564,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Payments"
+                    "Admin - Bookings"
                 ],
-                "summary": "Get all payments",
+                "summary": "Update booking status",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "New status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "status": {
+                                    "type": "string"
+                                }
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Payments retrieved successfully",
+                        "description": "Booking status updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -587,14 +622,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/payments/status": {
+        "/admin/categories": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of payments filtered by status (Admin only)",
+                "description": "Get all categories, including inactive ones, with the number of games in each (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -602,44 +637,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Payments"
-                ],
-                "summary": "Get payments by status",
-                "parameters": [
-                    {
-                        "enum": [
-                            "pending",
-                            "completed",
-                            "failed",
-                            "refunded"
-                        ],
-                        "type": "string",
-                        "description": "Payment status",
-                        "name": "status",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Admin - Categories"
                 ],
+                "summary": "Get all categories with game counts",
                 "responses": {
                     "200": {
-                        "description": "Payments retrieved successfully",
+                        "description": "Categories retrieved successfully",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AdminCategoryDTO"
+                            }
                         }
                     },
                     "401": {
@@ -657,16 +665,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/payments/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed payment information (Admin only)",
+                "description": "Create a new game category (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -674,86 +680,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Payments"
+                    "Admin - Categories"
                 ],
-                "summary": "Get payment detail",
+                "summary": "Create category",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Payment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Category details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Payment retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/model.Payment"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid payment ID",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Payment not found",
+                    "201": {
+                        "description": "Category created successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/admin/users": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get list of all users (Admin only)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Admin - Users"
-                ],
-                "summary": "Get all users",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
                     },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Users retrieved successfully",
+                    "400": {
+                        "description": "Invalid input",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -776,14 +726,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}": {
+        "/admin/categories/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific user (Admin only)",
+                "description": "Get a category with the number of games it holds, useful before deleting it (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -791,13 +741,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
+                    "Admin - Categories"
                 ],
-                "summary": "Get user detail",
+                "summary": "Get category detail with game count",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -805,13 +755,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User retrieved successfully",
+                        "description": "Category retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/dto.AdminCategoryDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid category ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -832,7 +782,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Category not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -840,13 +790,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft delete a user (Admin only)",
+                "description": "Update category information (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -854,28 +804,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
+                    "Admin - Categories"
                 ],
-                "summary": "Delete user",
+                "summary": "Update category",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated category details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Category updated successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -896,16 +855,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/users/{id}/role": {
-            "patch": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user role (Admin only)",
+                "description": "Delete a category (Admin only). Pass target_category_id to move its games there first; otherwise deleting a category with games fails.",
                 "consumes": [
                     "application/json"
                 ],
@@ -913,37 +870,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
+                    "Admin - Categories"
                 ],
-                "summary": "Update user role",
+                "summary": "Delete category",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Role update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserRoleRequest"
-                        }
+                        "type": "integer",
+                        "description": "Active category to reassign the games to before deleting",
+                        "name": "target_category_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User role updated successfully",
+                        "description": "Category deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid category ID or target",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -962,18 +916,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "409": {
+                        "description": "Category still has games",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/admin/users/{id}/status": {
-            "patch": {
+        "/admin/counts": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Ban or unban a user (Admin only)",
+                "description": "Get entity totals for the admin home screen in one call: users, active_games, bookings, payments, pending_payments (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -981,31 +942,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Users"
-                ],
-                "summary": "Toggle user status",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Admin - Dashboard"
                 ],
+                "summary": "Get dashboard counts",
                 "responses": {
                     "200": {
-                        "description": "User status updated successfully",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid user ID",
+                        "description": "Counts retrieved successfully",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "integer",
+                                "format": "int64"
+                            }
                         }
                     },
                     "401": {
@@ -1025,9 +973,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate user and return JWT token",
+        "/admin/game-reports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get games with customer reports and their counts, most reported first. flagged is true once a game reaches GAME_REPORT_FLAG_THRESHOLD (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1035,78 +988,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin - Games"
                 ],
-                "summary": "Login user",
+                "summary": "Get reported games",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
-                        }
+                        "type": "boolean",
+                        "description": "Only games at or above the flag threshold",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input",
+                        "description": "Game reports retrieved successfully",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.GameReportSummary"
+                            }
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user account",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Register user",
-                "parameters": [
-                    {
-                        "description": "Registration details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RegisterRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "User registered successfully",
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Invalid input or validation error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1115,14 +1040,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings": {
-            "post": {
+        "/admin/game-reports/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new game rental booking",
+                "description": "Get the individual reports filed against one game, newest first (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1130,30 +1055,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Bookings"
+                    "Admin - Games"
                 ],
-                "summary": "Create booking",
+                "summary": "Get reports for a game",
                 "parameters": [
                     {
-                        "description": "Booking details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateBookingRequest"
-                        }
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Booking created successfully",
+                    "200": {
+                        "description": "Game reports retrieved successfully",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.GameReport"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid game ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1165,18 +1104,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/bookings/my": {
+        "/admin/games": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of current user's bookings",
+                "description": "Get every game including inactive ones, optionally only those created by one admin (Super admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1184,10 +1130,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Bookings"
+                    "Admin - Games"
                 ],
-                "summary": "Get my bookings",
+                "summary": "Get all games (super admin)",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only games created by this admin",
+                        "name": "admin_id",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -1205,7 +1157,14 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Bookings retrieved successfully",
+                        "description": "Games retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid admin_id",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1217,18 +1176,23 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            }
-        },
-        "/bookings/{booking_id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about a specific booking",
+                "description": "Create a new game listing (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1236,21 +1200,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Bookings"
+                    "Admin - Games"
                 ],
-                "summary": "Get booking detail",
+                "summary": "Create new game",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Booking ID",
-                        "name": "booking_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Game details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateGameRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Booking retrieved successfully",
+                    "201": {
+                        "description": "Game created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1259,14 +1246,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{booking_id}/cancel": {
-            "patch": {
+        "/admin/games/bulk-status": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cancel a pending booking",
+                "description": "Show or hide many games in the catalog at once (Admin only). Existing bookings are not affected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1274,21 +1261,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Bookings"
+                    "Admin - Games"
                 ],
-                "summary": "Cancel booking",
+                "summary": "Bulk activate/deactivate games",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Booking ID",
-                        "name": "booking_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Game IDs and target status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkGameStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Booking cancelled successfully",
+                        "description": "Per-game results",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BulkGameStatusResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1297,14 +1309,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{booking_id}/payments": {
-            "get": {
+        "/admin/games/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get payment information for a specific booking",
+                "description": "Update game information (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1312,27 +1324,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payments"
+                    "Admin - Games"
                 ],
-                "summary": "Get payment by booking",
+                "summary": "Update game",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Booking ID",
-                        "name": "booking_id",
+                        "description": "Game ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated game details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateGameRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Payment retrieved successfully",
+                        "description": "Game updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Payment"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid booking ID",
+                        "description": "Invalid input",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1345,8 +1367,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Payment not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1354,13 +1376,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create payment for a booking",
+                "description": "Delete a game (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1368,36 +1390,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payments"
+                    "Admin - Games"
                 ],
-                "summary": "Create payment",
+                "summary": "Delete game",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Booking ID",
-                        "name": "booking_id",
+                        "description": "Game ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Payment details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreatePaymentRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Payment created successfully",
+                    "200": {
+                        "description": "Game deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Payment"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid game ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1409,56 +1423,60 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/bookings/{booking_id}/reviews": {
+        "/admin/games/{id}/images": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a review for a completed booking",
+                "description": "Upload a jpeg, png or webp image (max 10MB) and append its URL to the game's images (Admin only, own games unless super_admin)",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Admin - Games"
                 ],
-                "summary": "Create review",
+                "summary": "Upload game image",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Booking ID",
-                        "name": "booking_id",
+                        "description": "Game ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Review details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateReviewRequest"
-                        }
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Review created successfully",
+                        "description": "Image uploaded successfully",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/dto.GameResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid game ID, missing file or image limit reached",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1470,37 +1488,44 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/categories": {
-            "get": {
-                "description": "Get list of active game categories",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Categories"
-                ],
-                "summary": "Get active categories",
-                "responses": {
-                    "200": {
-                        "description": "Categories retrieved successfully",
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Image too large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported image type",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/categories/{id}": {
-            "get": {
-                "description": "Get detailed information about a specific category",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an image URL from the game and delete the stored file (Admin only, own games unless super_admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1508,34 +1533,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin - Games"
                 ],
-                "summary": "Get category detail",
+                "summary": "Delete game image",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Game ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image URL to remove",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category retrieved successfully",
+                        "description": "Image deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Category"
+                            "$ref": "#/definitions/dto.GameResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid category ID",
+                        "description": "Invalid game ID or missing url",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Category not found",
+                        "description": "Game or image not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1544,9 +1590,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/games": {
+        "/admin/payments": {
             "get": {
-                "description": "Get list of all active games",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of all payments (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1554,9 +1605,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Games"
+                    "Admin - Payments"
                 ],
-                "summary": "Get all games",
+                "summary": "Get all payments",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1575,7 +1626,21 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Games retrieved successfully",
+                        "description": "Payments retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1584,52 +1649,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/games/search": {
+        "/admin/payments/export": {
             "get": {
-                "description": "Search games by name, description, or platform",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Download payments oldest first as CSV (id, booking_id, provider, amount, status, paid_at, method), streamed in batches. from and to filter on created_at and are inclusive (Admin only)",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "Games"
+                    "Admin - Payments"
                 ],
-                "summary": "Search games",
+                "summary": "Export payments as CSV",
                 "parameters": [
                     {
+                        "enum": [
+                            "pending",
+                            "authorized",
+                            "paid",
+                            "failed",
+                            "refunded",
+                            "expired",
+                            "voided"
+                        ],
                         "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD)",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Games search results",
+                        "description": "CSV file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Search query required",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1638,9 +1724,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/games/{game_id}/reviews": {
+        "/admin/payments/revenue": {
             "get": {
-                "description": "Get list of reviews for a specific game",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sum paid payments by paid_at per day, week (Monday start) or month, in UTC. from and to are inclusive and at most one year apart; periods without payments are returned with zero revenue (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1648,49 +1739,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Admin - Payments"
                 ],
-                "summary": "Get game reviews",
+                "summary": "Get revenue report",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Game ID",
-                        "name": "game_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Bucket size",
+                        "name": "group_by",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Reviews retrieved successfully",
+                        "description": "Revenue report retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.RevenueBucket"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range or grouping",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Invalid game ID",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1699,9 +1804,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/games/{id}": {
+        "/admin/payments/search": {
             "get": {
-                "description": "Get detailed information about a specific game",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Find a payment with its booking, user and game from a gateway transaction ID (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1709,34 +1819,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Games"
+                    "Admin - Payments"
                 ],
-                "summary": "Get game detail",
+                "summary": "Search payment by provider ID",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Game ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Provider payment ID (Midtrans transaction ID or Stripe PaymentIntent ID)",
+                        "name": "provider_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Game retrieved successfully",
+                        "description": "Payment retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.Game"
+                            "$ref": "#/definitions/model.Payment"
                         }
                     },
                     "400": {
-                        "description": "Invalid game ID",
+                        "description": "Missing provider_id",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "Game not found",
+                        "description": "Payment not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1745,14 +1862,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/admin/payments/status": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get current user's profile information",
+                "description": "Get list of payments filtered by status (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1760,14 +1877,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin - Payments"
+                ],
+                "summary": "Get payments by status",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "paid",
+                            "failed",
+                            "refunded",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Payment status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get current user profile",
                 "responses": {
                     "200": {
-                        "description": "Profile retrieved successfully",
+                        "description": "Payments retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "401": {
@@ -1776,16 +1924,25 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/payments/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update current user's profile information",
+                "description": "Get detailed payment information (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1793,25 +1950,90 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin - Payments"
                 ],
-                "summary": "Update current user profile",
+                "summary": "Get payment detail",
                 "parameters": [
                     {
-                        "description": "Profile update details",
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/payments/{id}/capture": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Capture an authorized payment once the game is returned (or overdue): the rental amount, plus the security deposit when keep_deposit is true. The rest of the hold is released (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Payments"
+                ],
+                "summary": "Capture a deposit hold",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Whether to keep the deposit, and why",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateProfileRequest"
+                            "$ref": "#/definitions/dto.CaptureDepositHoldRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated successfully",
+                        "description": "Deposit hold captured",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.Payment"
                         }
                     },
                     "400": {
@@ -1821,8 +2043,22 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Payment not authorized or booking not returned",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1831,9 +2067,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/webhooks/payments": {
+        "/admin/payments/{id}/mark-failed": {
             "post": {
-                "description": "Receive payment status updates from payment provider",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Void a stuck pending payment, cancelling its booking and releasing stock (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1841,30 +2082,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Webhooks"
+                    "Admin - Payments"
                 ],
-                "summary": "Payment webhook",
+                "summary": "Mark payment as failed",
                 "parameters": [
                     {
-                        "description": "Webhook payload",
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Failure reason",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.PaymentWebhookRequest"
+                            "$ref": "#/definitions/dto.MarkPaymentFailedRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Webhook processed successfully",
+                        "description": "Payment marked as failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or payment not pending",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Invalid webhook payload",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1872,118 +2133,3859 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CreateBookingRequest": {
-            "type": "object",
-            "required": [
-                "end_date",
-                "game_id",
-                "start_date"
-            ],
-            "properties": {
-                "end_date": {
-                    "description": "String format YYYY-MM-DD",
-                    "type": "string"
-                },
-                "game_id": {
-                    "type": "integer"
-                },
-                "notes": {
-                    "type": "string"
-                },
-                "start_date": {
-                    "description": "String format YYYY-MM-DD",
-                    "type": "string"
-                }
-            }
         },
-        "dto.CreateCategoryRequest": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "description": {
+        "/admin/payments/{id}/mark-paid": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Manually confirm a payment settled out-of-band, e.g. a verified bank transfer (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Payments"
+                ],
+                "summary": "Mark payment as paid",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment method and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MarkPaymentPaidRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment marked as paid",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or payment already paid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/payments/{id}/refund": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refund all or part of a paid payment through its gateway (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Payments"
+                ],
+                "summary": "Refund payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Refund amount and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefundPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment refunded",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or amount",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Payment not paid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/payments/{id}/void": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Release an authorized payment in full for a booking that was cancelled (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Payments"
+                ],
+                "summary": "Void a deposit hold",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Void reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoidDepositHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deposit hold voided",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Payment not authorized or booking not cancelled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get total users, active games, bookings per status and revenue from paid payments (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Dashboard"
+                ],
+                "summary": "Get admin statistics",
+                "responses": {
+                    "200": {
+                        "description": "Stats retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdminStatsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of all users (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "customer",
+                            "admin",
+                            "super_admin"
+                        ],
+                        "type": "string",
+                        "description": "Filter by role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_desc",
+                            "created_asc",
+                            "last_login_desc",
+                            "last_login_asc",
+                            "name_asc",
+                            "name_desc"
+                        ],
+                        "type": "string",
+                        "default": "created_desc",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an account with an explicit role and active state and email the user a link to set their password (valid 72 hours). Works when self-registration is disabled. Admins can create customers; only a super admin can create admin or super_admin accounts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "description": "Account details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Email already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific user (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Get user detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a user (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/role": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user role (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Update user role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User role updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Activate or deactivate a user (Admin only). Idempotent: the requested state is written as-is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Users"
+                ],
+                "summary": "Set user status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Desired active state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User status updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Email a single-use password reset link. Always succeeds so registered emails can't be discovered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Request password reset",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reset link sent if the account exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user and return JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revoke a refresh token. Access tokens already issued stay valid until they expire",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Refresh token to revoke",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logged out",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or already revoked refresh token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token from login for a new access token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token refreshed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired or revoked refresh token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register user",
+                "parameters": [
+                    {
+                        "description": "Registration details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User registered successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Self-registration is disabled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Set a new password using the token from a reset email. Each token works once, the new password needs 8+ characters with a letter and a digit, and every existing session is signed out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password reset successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid, expired or already used token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "New password too weak",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email": {
+            "get": {
+                "description": "Activate an account with the token from its verification email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email verified successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid, expired or already used token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new game rental booking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Create booking",
+                "parameters": [
+                    {
+                        "description": "Booking details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Booking created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of current user's bookings, optionally filtered by status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Get my bookings",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "confirmed",
+                            "active",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Booking status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bookings retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{booking_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about a specific booking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Get booking detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Booking retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookingDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{booking_id}/can-review": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check upfront whether the current user may review this booking, with the reason when not",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Check if a booking can be reviewed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review eligibility",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CanReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Booking not owned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{booking_id}/cancel": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a pending booking, optionally saying why",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Cancel booking",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CancelBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Booking cancelled successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{booking_id}/deposit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Whether the security deposit is held, refunded or forfeited, and the amount. none means the booking has no deposit or hasn't been paid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Get booking deposit status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deposit status retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DepositSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not your booking",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{booking_id}/extend": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move an active booking's end date later. The added days are priced at the booking's daily price and added to extension_amount_due for a follow-up payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Extend booking",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New end date",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExtendBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Booking extended successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.Booking"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, or end date not later or beyond BOOKING_MAX_ADVANCE_DAYS",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Booking not owned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Booking not active or the extra days are already booked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{booking_id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get payment information for a specific booking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get payment by booking",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid booking ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create payment for a booking. Midtrans card payments, including deposit holds, need the card_token from Midtrans.js",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Create payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Payment created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{booking_id}/reviews": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a review for a completed booking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Create review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Review created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Get list of active game categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get active categories",
+                "responses": {
+                    "200": {
+                        "description": "Categories retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "description": "Get detailed information about a specific category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get category detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/games": {
+            "get": {
+                "description": "Get list of all active games",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Games"
+                ],
+                "summary": "Get all games",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. id,name,rental_price_per_day",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only games in this category",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only games for this platform (case-insensitive)",
+                        "name": "platform",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum rental price per day",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum rental price per day",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "excellent",
+                            "good",
+                            "fair"
+                        ],
+                        "type": "string",
+                        "description": "Only games in this condition",
+                        "name": "condition",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Games retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/games/search": {
+            "get": {
+                "description": "Search games by name, description, or platform",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Games"
+                ],
+                "summary": "Search games",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Games search results",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Search query required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/games/slug/{slug}": {
+            "get": {
+                "description": "Same as GET /games/{id}, addressed by the game's URL slug. Slugs a game had before being renamed answer with a 301 to the current one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Games"
+                ],
+                "summary": "Get game detail by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Intended start date (YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Intended end date (YYYY-MM-DD)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. id,name,rental_price_per_day",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Game retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GameDetailResponse"
+                        }
+                    },
+                    "301": {
+                        "description": "Old slug; Location holds the current one"
+                    },
+                    "400": {
+                        "description": "Invalid dates",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{game_id}/reviews": {
+            "get": {
+                "description": "Get list of reviews for a specific game",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get game reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "game_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}": {
+            "get": {
+                "description": "Get detailed information about a specific game, including average_rating (one decimal) and review_count. Pass start and end to also check availability for those dates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Games"
+                ],
+                "summary": "Get game detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Intended start date (YYYY-MM-DD)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Intended end date (YYYY-MM-DD)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. id,name,rental_price_per_day",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Game retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GameDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID or dates",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/quote": {
+            "post": {
+                "description": "Get the cost breakdown for renting a game over a date range without an account. Nothing is created or reserved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Games"
+                ],
+                "summary": "Get a price quote",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Intended rental dates",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookingQuoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quote calculated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookingQuoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/report": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report wrong or offensive listing content. Each user can report a game once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Games"
+                ],
+                "summary": "Report a game listing",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Why the listing is a problem",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportGameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Report submitted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Already reported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{id}/booking": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the booking a payment belongs to, with the game, payment and deposit status, e.g. when starting from a receipt",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get booking by payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Booking retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BookingDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not your payment",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Payment or booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the rating and comment of your own review, up to 30 days after posting it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Update review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New rating and comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Review not owned or too old to edit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete your own review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Delete review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid review ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Review not owned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/reply": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Post or replace the public reply on a review of one of your games (Admin only; super_admin on any game)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Reply to review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reply text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReplyReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reply saved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not the game's owner",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get current user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user profile",
+                "parameters": [
+                    {
+                        "enum": [
+                            "stats"
+                        ],
+                        "type": "string",
+                        "description": "Pass 'stats' to attach booking counts",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update current user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update current user profile",
+                "parameters": [
+                    {
+                        "description": "Profile update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current user's in-app notifications, newest first, with the unread count in meta",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get my notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications retrieved successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark one of the current user's notifications as read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark notification as read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification marked as read",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid notification ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the password after confirming the current one and sign out every session. The new password needs 8+ characters with a letter and a digit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change current user password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or current password incorrect",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "New password too weak",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current user's role and the capabilities it grants. The role is read from the database, so a role change shows up before the token is refreshed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user permissions",
+                "responses": {
+                    "200": {
+                        "description": "Permissions retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserPermissionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get reviews written by the current user, newest first, each with its game",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get my reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews retrieved successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.UserReviewResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/payments": {
+            "post": {
+                "description": "Receive payment status updates from Midtrans; the notification must carry a valid signature_key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Midtrans payment webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook processed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook payload",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/stripe": {
+            "post": {
+                "description": "Receive payment_intent.* events from Stripe, signed with STRIPE_WEBHOOK_SECRET",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Stripe payment webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stripe's signature of the raw body",
+                        "name": "Stripe-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook processed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook payload",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AdminCategoryDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "game_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AdminStatsResponse": {
+            "type": "object",
+            "properties": {
+                "active_games": {
+                    "type": "integer"
+                },
+                "bookings_by_status": {
+                    "description": "Every known status is present, with 0 when there are no bookings in it",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "total_revenue": {
+                    "description": "Sum of paid payments; refunded ones are excluded",
+                    "type": "number"
+                },
+                "total_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BookingDetailResponse": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "start_date"
+            ],
+            "properties": {
+                "cancellation_reason": {
+                    "description": "Set when the customer cancels; reason is optional",
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_price": {
+                    "type": "number"
+                },
+                "deleted_at": {
+                    "description": "Admin soft delete; GORM hides these rows from every normal query",
+                    "type": "string"
+                },
+                "deposit": {
+                    "$ref": "#/definitions/dto.DepositSummary"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "extension_amount_due": {
+                    "description": "Added to TotalAmount by extensions and not yet covered by a payment",
+                    "type": "number"
+                },
+                "game": {
+                    "$ref": "#/definitions/model.Game"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "late_fee": {
+                    "type": "number"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "payment": {
+                    "$ref": "#/definitions/model.Payment"
+                },
+                "rental_days": {
+                    "type": "integer"
+                },
+                "returned_at": {
+                    "description": "Set by ConfirmReturn; LateFee is charged through a separate late_fee payment",
+                    "type": "string"
+                },
+                "review": {
+                    "$ref": "#/definitions/model.Review"
+                },
+                "security_deposit": {
+                    "type": "number"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.BookingStatus"
+                },
+                "terms_accepted_at": {
+                    "description": "When the customer accepted the game's rental terms; nil if the game had none",
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "total_rental_price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "Relationships",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BookingQuoteRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "description": "String format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "String format YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BookingQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "daily_price": {
+                    "type": "number"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "rental_days": {
+                    "type": "integer"
+                },
+                "rental_terms": {
+                    "type": "string"
+                },
+                "security_deposit": {
+                    "type": "number"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "total_rental_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.BulkGameStatusRequest": {
+            "type": "object",
+            "required": [
+                "game_ids",
+                "is_active"
+            ],
+            "properties": {
+                "game_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.BulkGameStatusResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.CanReviewResponse": {
+            "type": "object",
+            "properties": {
+                "can_review": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CancelBookingRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "dto.CaptureDepositHoldRequest": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "keep_deposit": {
+                    "description": "Also capture the security deposit, e.g. when the game came back damaged or not at all",
+                    "type": "boolean"
+                },
+                "note": {
+                    "type": "string",
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateBookingRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "game_id",
+                "start_date"
+            ],
+            "properties": {
+                "accepted_terms": {
+                    "description": "Required when the game has rental_terms",
+                    "type": "boolean"
+                },
+                "end_date": {
+                    "description": "String format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "String format YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "dto.CreateGameRequest": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "condition",
+                "name",
+                "rental_price_per_day",
+                "security_deposit",
+                "stock"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "excellent",
+                        "good",
+                        "fair"
+                    ]
+                },
+                "deposit_mode": {
+                    "description": "charge or hold; empty uses the DEPOSIT_MODE setting",
+                    "type": "string",
+                    "enum": [
+                        "charge",
+                        "hold"
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "late_fee_per_day": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "rental_price_per_day": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "rental_terms": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "security_deposit": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.CreatePaymentRequest": {
+            "type": "object",
+            "required": [
+                "provider"
+            ],
+            "properties": {
+                "card_token": {
+                    "description": "CardToken is the Midtrans.js card token; required for Midtrans card payments, including deposit holds",
+                    "type": "string"
+                },
+                "payment_type": {
+                    "type": "string"
+                },
+                "provider": {
+                    "enum": [
+                        "stripe",
+                        "midtrans"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PaymentProvider"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.CreateReviewRequest": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "full_name",
+                "is_active",
+                "role"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string",
+                    "minLength": 2
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string",
+                    "minLength": 10
+                },
+                "role": {
+                    "enum": [
+                        "customer",
+                        "admin",
+                        "super_admin"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.UserRole"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.DepositSummary": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "The deposit held or forfeited, or the part given back when refunded",
+                    "type": "number"
+                },
+                "mode": {
+                    "$ref": "#/definitions/model.DepositMode"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.DepositStatus"
+                }
+            }
+        },
+        "dto.ExtendBookingRequest": {
+            "type": "object",
+            "required": [
+                "end_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "description": "String format YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GameAvailability": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GameDetailResponse": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "admin_id": {
+                    "type": "integer"
+                },
+                "availability": {
+                    "$ref": "#/definitions/dto.GameAvailability"
+                },
+                "available_stock": {
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "type": "number"
+                },
+                "category": {
+                    "$ref": "#/definitions/model.Category"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "condition": {
+                    "$ref": "#/definitions/model.GameCondition"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deposit_mode": {
+                    "description": "Empty uses the DEPOSIT_MODE setting",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.DepositMode"
+                        }
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "late_fee_per_day": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "rental_price_per_day": {
+                    "type": "number"
+                },
+                "rental_terms": {
+                    "description": "Shown before booking; customers must accept it when set",
+                    "type": "string"
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "security_deposit": {
+                    "type": "number"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GameResponse": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "admin_id": {
+                    "type": "integer"
+                },
+                "available_stock": {
+                    "type": "integer"
+                },
+                "category": {
+                    "$ref": "#/definitions/model.Category"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "condition": {
+                    "$ref": "#/definitions/model.GameCondition"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deposit_mode": {
+                    "description": "Empty uses the DEPOSIT_MODE setting",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.DepositMode"
+                        }
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "late_fee_per_day": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "rental_price_per_day": {
+                    "type": "number"
+                },
+                "rental_terms": {
+                    "description": "Shown before booking; customers must accept it when set",
+                    "type": "string"
+                },
+                "security_deposit": {
+                    "type": "number"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                }
+            }
+        },
+        "dto.MarkPaymentFailedRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.MarkPaymentPaidRequest": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "minLength": 3
+                },
+                "payment_method": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PaymentWebhookRequest": {
+            "type": "object",
+            "required": [
+                "provider_payment_id",
+                "status"
+            ],
+            "properties": {
+                "failure_reason": {
                     "type": "string"
                 },
-                "name": {
+                "payment_method": {
+                    "type": "string"
+                },
+                "provider_payment_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RefreshTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RefundPaymentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "reason"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string",
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "full_name",
+                "password"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
                     "type": "string",
-                    "maxLength": 100,
                     "minLength": 2
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "phone": {
+                    "type": "string",
+                    "minLength": 10
                 }
             }
         },
-        "dto.CreateGameRequest": {
+        "dto.ReplyReviewRequest": {
             "type": "object",
             "required": [
-                "category_id",
-                "condition",
-                "name",
-                "rental_price_per_day",
-                "security_deposit",
-                "stock"
+                "reply"
+            ],
+            "properties": {
+                "reply": {
+                    "type": "string",
+                    "maxLength": 2000
+                }
+            }
+        },
+        "dto.ReportGameRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
             ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "dto.UpdateGameRequest": {
+            "type": "object",
             "properties": {
                 "category_id": {
                     "type": "integer"
                 },
                 "condition": {
+                    "type": "string"
+                },
+                "deposit_mode": {
+                    "description": "Omit to keep the current mode; an empty string goes back to the DEPOSIT_MODE setting",
                     "type": "string",
                     "enum": [
-                        "excellent",
-                        "good",
-                        "fair"
+                        "charge",
+                        "hold"
                     ]
                 },
                 "description": {
                     "type": "string"
                 },
+                "images": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "late_fee_per_day": {
+                    "description": "Omit to keep the current fee; 0 stops charging late returns",
+                    "type": "number",
+                    "minimum": 0
+                },
                 "name": {
-                    "type": "string",
-                    "minLength": 3
+                    "type": "string"
                 },
                 "platform": {
                     "type": "string"
                 },
                 "rental_price_per_day": {
-                    "type": "number",
-                    "minimum": 0
+                    "type": "number"
+                },
+                "rental_terms": {
+                    "description": "Omit to keep the current terms; an empty string removes them",
+                    "type": "string",
+                    "maxLength": 2000
                 },
                 "security_deposit": {
-                    "type": "number",
-                    "minimum": 0
+                    "type": "number"
                 },
                 "stock": {
-                    "type": "integer",
-                    "minimum": 0
+                    "type": "integer"
                 }
             }
         },
-        "dto.CreatePaymentRequest": {
+        "dto.UpdateProfileRequest": {
             "type": "object",
             "required": [
-                "provider"
+                "full_name"
             ],
             "properties": {
-                "payment_type": {
+                "address": {
                     "type": "string"
                 },
-                "provider": {
-                    "enum": [
-                        "stripe",
-                        "midtrans"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.PaymentProvider"
-                        }
-                    ]
+                "full_name": {
+                    "type": "string",
+                    "minLength": 2
+                },
+                "phone": {
+                    "type": "string",
+                    "minLength": 10
                 }
             }
         },
-        "dto.CreateReviewRequest": {
+        "dto.UpdateReviewRequest": {
             "type": "object",
             "required": [
                 "rating"
@@ -1999,165 +6001,160 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.UpdateUserRoleRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "role"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
+                "role": {
+                    "enum": [
+                        "customer",
+                        "partner",
+                        "admin"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.UserRole"
+                        }
+                    ]
                 }
             }
         },
-        "dto.LoginResponse": {
+        "dto.UpdateUserStatusRequest": {
             "type": "object",
+            "required": [
+                "is_active"
+            ],
             "properties": {
-                "access_token": {
-                    "type": "string"
-                },
-                "expires_at": {
-                    "type": "string"
-                },
-                "user": {
-                    "$ref": "#/definitions/model.User"
+                "is_active": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.PaymentWebhookRequest": {
+        "dto.UserBookingStats": {
             "type": "object",
-            "required": [
-                "provider_payment_id",
-                "status"
-            ],
             "properties": {
-                "failure_reason": {
-                    "type": "string"
+                "active_bookings": {
+                    "type": "integer"
                 },
-                "payment_method": {
-                    "type": "string"
+                "completed_bookings": {
+                    "type": "integer"
                 },
-                "provider_payment_id": {
-                    "type": "string"
+                "total_bookings": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.UserPermissionsResponse": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "$ref": "#/definitions/model.Permissions"
                 },
-                "status": {
-                    "type": "string"
+                "role": {
+                    "$ref": "#/definitions/model.UserRole"
                 }
             }
         },
-        "dto.RegisterRequest": {
+        "dto.UserProfileResponse": {
             "type": "object",
             "required": [
                 "email",
-                "full_name",
-                "password"
+                "full_name"
             ],
             "properties": {
                 "address": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
                 "full_name": {
-                    "type": "string",
-                    "minLength": 2
+                    "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "no gorm default: it would drop an explicit false on insert",
+                    "type": "boolean"
+                },
+                "last_login_at": {
+                    "description": "Set on each successful login; nil for accounts that never logged in",
+                    "type": "string"
                 },
                 "phone": {
-                    "type": "string",
-                    "minLength": 10
-                }
-            }
-        },
-        "dto.UpdateCategoryRequest": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "description": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "role": {
+                    "$ref": "#/definitions/model.UserRole"
+                },
+                "stats": {
+                    "$ref": "#/definitions/dto.UserBookingStats"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.UpdateGameRequest": {
+        "dto.UserReviewResponse": {
             "type": "object",
             "properties": {
-                "category_id": {
+                "booking": {
+                    "$ref": "#/definitions/model.Booking"
+                },
+                "booking_id": {
                     "type": "integer"
                 },
-                "condition": {
+                "comment": {
                     "type": "string"
                 },
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "game": {
+                    "$ref": "#/definitions/model.Game"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "replied_at": {
                     "type": "string"
                 },
-                "platform": {
+                "reply": {
+                    "description": "Public answer from the admin who listed the game; replying again overwrites it",
                     "type": "string"
                 },
-                "rental_price_per_day": {
-                    "type": "number"
+                "updated_at": {
+                    "type": "string"
                 },
-                "security_deposit": {
-                    "type": "number"
+                "user": {
+                    "$ref": "#/definitions/model.User"
                 },
-                "stock": {
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.UpdateProfileRequest": {
+        "dto.VoidDepositHoldRequest": {
             "type": "object",
             "required": [
-                "full_name"
+                "reason"
             ],
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "full_name": {
-                    "type": "string",
-                    "minLength": 2
-                },
-                "phone": {
+                "reason": {
                     "type": "string",
-                    "minLength": 10
-                }
-            }
-        },
-        "dto.UpdateUserRoleRequest": {
-            "type": "object",
-            "required": [
-                "role"
-            ],
-            "properties": {
-                "role": {
-                    "enum": [
-                        "customer",
-                        "partner",
-                        "admin"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.UserRole"
-                        }
-                    ]
+                    "minLength": 3
                 }
             }
         },
@@ -2168,15 +6165,30 @@ const docTemplate = `{
                 "start_date"
             ],
             "properties": {
+                "cancellation_reason": {
+                    "description": "Set when the customer cancels; reason is optional",
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "daily_price": {
                     "type": "number"
                 },
+                "deleted_at": {
+                    "description": "Admin soft delete; GORM hides these rows from every normal query",
+                    "type": "string"
+                },
                 "end_date": {
                     "type": "string"
                 },
+                "extension_amount_due": {
+                    "description": "Added to TotalAmount by extensions and not yet covered by a payment",
+                    "type": "number"
+                },
                 "game": {
                     "$ref": "#/definitions/model.Game"
                 },
@@ -2186,6 +6198,9 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "late_fee": {
+                    "type": "number"
+                },
                 "notes": {
                     "type": "string"
                 },
@@ -2195,6 +6210,10 @@ const docTemplate = `{
                 "rental_days": {
                     "type": "integer"
                 },
+                "returned_at": {
+                    "description": "Set by ConfirmReturn; LateFee is charged through a separate late_fee payment",
+                    "type": "string"
+                },
                 "review": {
                     "$ref": "#/definitions/model.Review"
                 },
@@ -2207,6 +6226,10 @@ const docTemplate = `{
                 "status": {
                     "$ref": "#/definitions/model.BookingStatus"
                 },
+                "terms_accepted_at": {
+                    "description": "When the customer accepted the game's rental terms; nil if the game had none",
+                    "type": "string"
+                },
                 "total_amount": {
                     "type": "number"
                 },
@@ -2235,6 +6258,7 @@ const docTemplate = `{
                 "pending",
                 "confirmed",
                 "active",
+                "overdue",
                 "completed",
                 "cancelled"
             ],
@@ -2242,6 +6266,7 @@ const docTemplate = `{
                 "BookingPending",
                 "BookingConfirmed",
                 "BookingActive",
+                "BookingOverdue",
                 "BookingCompleted",
                 "BookingCancelled"
             ]
@@ -2266,9 +6291,47 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
+        "model.DepositMode": {
+            "type": "string",
+            "enum": [
+                "charge",
+                "hold"
+            ],
+            "x-enum-varnames": [
+                "DepositCharge",
+                "DepositHold"
+            ]
+        },
+        "model.DepositStatus": {
+            "type": "string",
+            "enum": [
+                "none",
+                "held",
+                "refunded",
+                "forfeited"
+            ],
+            "x-enum-comments": {
+                "DepositNone": "no deposit, or not paid yet"
+            },
+            "x-enum-descriptions": [
+                "no deposit, or not paid yet",
+                "",
+                "",
+                ""
+            ],
+            "x-enum-varnames": [
+                "DepositNone",
+                "DepositHeld",
+                "DepositRefunded",
+                "DepositForfeited"
+            ]
+        },
         "model.Game": {
             "type": "object",
             "properties": {
@@ -2293,15 +6356,32 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deposit_mode": {
+                    "description": "Empty uses the DEPOSIT_MODE setting",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.DepositMode"
+                        }
+                    ]
+                },
                 "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "is_active": {
                     "type": "boolean"
                 },
+                "late_fee_per_day": {
+                    "type": "number"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -2311,9 +6391,16 @@ const docTemplate = `{
                 "rental_price_per_day": {
                     "type": "number"
                 },
+                "rental_terms": {
+                    "description": "Shown before booking; customers must accept it when set",
+                    "type": "string"
+                },
                 "security_deposit": {
                     "type": "number"
                 },
+                "slug": {
+                    "type": "string"
+                },
                 "stock": {
                     "type": "integer"
                 },
@@ -2335,9 +6422,38 @@ const docTemplate = `{
                 "ConditionFair"
             ]
         },
+        "model.GameReport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.Payment": {
             "type": "object",
             "properties": {
+                "admin_note": {
+                    "type": "string"
+                },
                 "amount": {
                     "type": "number"
                 },
@@ -2352,9 +6468,24 @@ const docTemplate = `{
                 "booking_id": {
                     "type": "integer"
                 },
+                "client_secret": {
+                    "description": "Stripe only, returned once on create and never stored",
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "deposit_mode": {
+                    "$ref": "#/definitions/model.DepositMode"
+                },
+                "deposit_released": {
+                    "description": "hold captured without the deposit",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "description": "nil when the provider's default expiry applies",
+                    "type": "string"
+                },
                 "failed_at": {
                     "type": "string"
                 },
@@ -2370,14 +6501,32 @@ const docTemplate = `{
                 "payment_method": {
                     "type": "string"
                 },
+                "processed_by": {
+                    "type": "integer"
+                },
                 "provider": {
                     "$ref": "#/definitions/model.PaymentProvider"
                 },
                 "provider_payment_id": {
                     "type": "string"
                 },
+                "purpose": {
+                    "$ref": "#/definitions/model.PaymentPurpose"
+                },
+                "refund_amount": {
+                    "type": "number"
+                },
+                "refund_reason": {
+                    "type": "string"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
                 "status": {
                     "$ref": "#/definitions/model.PaymentStatus"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
@@ -2392,21 +6541,85 @@ const docTemplate = `{
                 "ProviderMidtrans"
             ]
         },
+        "model.PaymentPurpose": {
+            "type": "string",
+            "enum": [
+                "rental",
+                "late_fee"
+            ],
+            "x-enum-varnames": [
+                "PaymentPurposeRental",
+                "PaymentPurposeLateFee"
+            ]
+        },
         "model.PaymentStatus": {
             "type": "string",
             "enum": [
                 "pending",
+                "authorized",
                 "paid",
                 "failed",
-                "refunded"
+                "refunded",
+                "expired",
+                "voided"
+            ],
+            "x-enum-comments": {
+                "PaymentAuthorized": "deposit hold: funds reserved, not yet captured",
+                "PaymentVoided": "deposit hold released without capturing anything"
+            },
+            "x-enum-descriptions": [
+                "",
+                "deposit hold: funds reserved, not yet captured",
+                "",
+                "",
+                "",
+                "",
+                "deposit hold released without capturing anything"
             ],
             "x-enum-varnames": [
                 "PaymentPending",
+                "PaymentAuthorized",
                 "PaymentPaid",
                 "PaymentFailed",
-                "PaymentRefunded"
+                "PaymentRefunded",
+                "PaymentExpired",
+                "PaymentVoided"
             ]
         },
+        "model.Permissions": {
+            "type": "object",
+            "properties": {
+                "can_assign_super_admin": {
+                    "type": "boolean"
+                },
+                "can_manage_any_game": {
+                    "description": "edit or delete games listed by other admins",
+                    "type": "boolean"
+                },
+                "can_manage_bookings": {
+                    "type": "boolean"
+                },
+                "can_manage_categories": {
+                    "type": "boolean"
+                },
+                "can_manage_games": {
+                    "type": "boolean"
+                },
+                "can_manage_payments": {
+                    "type": "boolean"
+                },
+                "can_manage_users": {
+                    "type": "boolean"
+                },
+                "can_view_all_games": {
+                    "description": "admin listing including inactive games",
+                    "type": "boolean"
+                },
+                "can_view_dashboard": {
+                    "type": "boolean"
+                }
+            }
+        },
         "model.Review": {
             "type": "object",
             "properties": {
@@ -2431,6 +6644,13 @@ const docTemplate = `{
                 "rating": {
                     "type": "integer"
                 },
+                "replied_at": {
+                    "type": "string"
+                },
+                "reply": {
+                    "description": "Public answer from the admin who listed the game; replying again overwrites it",
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -2465,8 +6685,13 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "is_active": {
+                    "description": "no gorm default: it would drop an explicit false on insert",
                     "type": "boolean"
                 },
+                "last_login_at": {
+                    "description": "Set on each successful login; nil for accounts that never logged in",
+                    "type": "string"
+                },
                 "phone": {
                     "type": "string"
                 },
@@ -2490,6 +6715,40 @@ const docTemplate = `{
                 "RoleAdmin",
                 "RoleSuperAdmin"
             ]
+        },
+        "repository.GameReportSummary": {
+            "type": "object",
+            "properties": {
+                "flagged": {
+                    "type": "boolean"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "game_name": {
+                    "type": "string"
+                },
+                "latest_report_at": {
+                    "type": "string"
+                },
+                "report_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.RevenueBucket": {
+            "type": "object",
+            "properties": {
+                "payments": {
+                    "type": "integer"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                }
+            }
         }
     },
     "securityDefinitions": {
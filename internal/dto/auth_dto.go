@@ -20,9 +20,29 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	AccessToken string      `json:"access_token"`
-	User        *model.User `json:"user"`
-	ExpiresAt   time.Time   `json:"expires_at"`
+	AccessToken           string      `json:"access_token"`
+	RefreshToken          string      `json:"refresh_token"`
+	User                  *model.User `json:"user"`
+	ExpiresAt             time.Time   `json:"expires_at"`
+	RefreshTokenExpiresAt time.Time   `json:"refresh_token_expires_at"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RefreshTokenResponse struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
 
 type ResendVerificationRequest struct {
@@ -1,8 +1,57 @@
 package dto
 
+import "github.com/yoockh/go-game-rental-api/internal/model"
+
 type CreateBookingRequest struct {
 	GameID    uint   `json:"game_id" validate:"required"`
 	StartDate string `json:"start_date" validate:"required"` // String format YYYY-MM-DD
 	EndDate   string `json:"end_date" validate:"required"`   // String format YYYY-MM-DD
 	Notes     string `json:"notes,omitempty"`
+	// Required when the game has rental_terms
+	AcceptedTerms bool `json:"accepted_terms,omitempty"`
+}
+
+type CancelBookingRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+type ExtendBookingRequest struct {
+	EndDate string `json:"end_date" validate:"required"` // String format YYYY-MM-DD
+}
+
+type BookingQuoteRequest struct {
+	StartDate string `json:"start_date" validate:"required"` // String format YYYY-MM-DD
+	EndDate   string `json:"end_date" validate:"required"`   // String format YYYY-MM-DD
+}
+
+// BookingCost is the price breakdown shared by bookings and guest quotes
+type BookingCost struct {
+	RentalDays       int     `json:"rental_days"`
+	DailyPrice       float64 `json:"daily_price"`
+	TotalRentalPrice float64 `json:"total_rental_price"`
+	SecurityDeposit  float64 `json:"security_deposit"`
+	TotalAmount      float64 `json:"total_amount"`
+}
+
+// DepositSummary is the customer-facing state of a booking's security deposit
+type DepositSummary struct {
+	Status model.DepositStatus `json:"status"`
+	Mode   model.DepositMode   `json:"mode,omitempty"`
+	// The deposit held or forfeited, or the part given back when refunded
+	Amount float64 `json:"amount"`
+}
+
+// BookingDetailResponse is a booking with its deposit summary
+type BookingDetailResponse struct {
+	*model.Booking
+	Deposit DepositSummary `json:"deposit"`
+}
+
+type BookingQuoteResponse struct {
+	GameID    uint   `json:"game_id"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	BookingCost
+	Available   bool    `json:"available"`
+	RentalTerms *string `json:"rental_terms,omitempty"`
 }
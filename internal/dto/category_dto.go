@@ -9,6 +9,12 @@ type CategoryDTO struct {
 	IsActive    bool    `json:"is_active"`
 }
 
+// AdminCategoryDTO adds the game count admins need before deleting a category
+type AdminCategoryDTO struct {
+	CategoryDTO
+	GameCount int64 `json:"game_count"`
+}
+
 type CreateCategoryRequest struct {
 	Name        string `json:"name" validate:"required,min=2,max=100"`
 	Description string `json:"description,omitempty"`
@@ -39,3 +45,14 @@ func ToCategoryDTOList(categories []*model.Category) []*CategoryDTO {
 	}
 	return result
 }
+
+func ToAdminCategoryDTO(category *model.Category, gameCount int64) *AdminCategoryDTO {
+	if category == nil {
+		return nil
+	}
+
+	return &AdminCategoryDTO{
+		CategoryDTO: *ToCategoryDTO(category),
+		GameCount:   gameCount,
+	}
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/yoockh/go-game-rental-api/internal/model"
+
+// AdminStatsResponse summarizes the platform for the admin dashboard
+type AdminStatsResponse struct {
+	TotalUsers  int64 `json:"total_users"`
+	ActiveGames int64 `json:"active_games"`
+	// Every known status is present, with 0 when there are no bookings in it
+	BookingsByStatus map[model.BookingStatus]int64 `json:"bookings_by_status"`
+	// Sum of paid payments; refunded ones are excluded
+	TotalRevenue float64 `json:"total_revenue"`
+}
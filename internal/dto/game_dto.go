@@ -1,23 +1,93 @@
 package dto
 
+import "github.com/yoockh/go-game-rental-api/internal/model"
+
 type CreateGameRequest struct {
-	CategoryID        uint    `json:"category_id" validate:"required"`
-	Name              string  `json:"name" validate:"required,min=3"`
-	Description       string  `json:"description,omitempty"`
-	Platform          string  `json:"platform,omitempty"`
-	Stock             int     `json:"stock" validate:"required,min=0"`
-	RentalPricePerDay float64 `json:"rental_price_per_day" validate:"required,min=0"`
-	SecurityDeposit   float64 `json:"security_deposit" validate:"required,min=0"`
-	Condition         string  `json:"condition" validate:"required,oneof=excellent good fair"`
+	CategoryID        uint     `json:"category_id" validate:"required"`
+	Name              string   `json:"name" validate:"required,min=3"`
+	Description       string   `json:"description,omitempty"`
+	Platform          string   `json:"platform,omitempty"`
+	Stock             int      `json:"stock" validate:"required,min=0"`
+	RentalPricePerDay float64  `json:"rental_price_per_day" validate:"required,min=0"`
+	SecurityDeposit   float64  `json:"security_deposit" validate:"required,min=0"`
+	LateFeePerDay     float64  `json:"late_fee_per_day,omitempty" validate:"min=0"`
+	Condition         string   `json:"condition" validate:"required,oneof=excellent good fair"`
+	Images            []string `json:"images,omitempty" validate:"omitempty,max=10,dive,url"`
+	RentalTerms       string   `json:"rental_terms,omitempty" validate:"max=2000"`
+	// charge or hold; empty uses the DEPOSIT_MODE setting
+	DepositMode string `json:"deposit_mode,omitempty" validate:"omitempty,oneof=charge hold"`
 }
 
 type UpdateGameRequest struct {
-	CategoryID        uint    `json:"category_id,omitempty"`
-	Name              string  `json:"name,omitempty"`
-	Description       string  `json:"description,omitempty"`
-	Platform          string  `json:"platform,omitempty"`
-	Stock             int     `json:"stock,omitempty"`
-	RentalPricePerDay float64 `json:"rental_price_per_day,omitempty"`
-	SecurityDeposit   float64 `json:"security_deposit,omitempty"`
-	Condition         string  `json:"condition,omitempty"`
+	CategoryID        uint     `json:"category_id,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	Description       string   `json:"description,omitempty"`
+	Platform          string   `json:"platform,omitempty"`
+	Stock             int      `json:"stock,omitempty"`
+	RentalPricePerDay float64  `json:"rental_price_per_day,omitempty"`
+	SecurityDeposit   float64  `json:"security_deposit,omitempty"`
+	Condition         string   `json:"condition,omitempty"`
+	Images            []string `json:"images,omitempty" validate:"omitempty,max=10,dive,url"`
+	// Omit to keep the current fee; 0 stops charging late returns
+	LateFeePerDay *float64 `json:"late_fee_per_day,omitempty" validate:"omitempty,min=0"`
+	// Omit to keep the current terms; an empty string removes them
+	RentalTerms *string `json:"rental_terms,omitempty" validate:"omitempty,max=2000"`
+	// Omit to keep the current mode; an empty string goes back to the DEPOSIT_MODE setting
+	DepositMode *string `json:"deposit_mode,omitempty" validate:"omitempty,oneof=charge hold"`
+}
+
+type BulkGameStatusRequest struct {
+	GameIDs  []uint `json:"game_ids" validate:"required,min=1,max=100,dive,gt=0"`
+	IsActive *bool  `json:"is_active" validate:"required"`
+}
+
+type BulkGameStatusResult struct {
+	GameID  uint   `json:"game_id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type GameAvailability struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Available bool   `json:"available"`
+}
+
+// GameResponse is the game payload with a resolved thumbnail, so clients never need their own placeholder
+type GameResponse struct {
+	*model.Game
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+// GameDetailResponse is the game payload with rating stats, plus availability when dates were requested
+type GameDetailResponse struct {
+	*GameResponse
+	AverageRating float64           `json:"average_rating"`
+	ReviewCount   int64             `json:"review_count"`
+	Availability  *GameAvailability `json:"availability,omitempty"`
+}
+
+// ToGameResponse uses the first image as thumbnail and falls back to placeholderURL
+func ToGameResponse(game *model.Game, placeholderURL string) *GameResponse {
+	if game == nil {
+		return nil
+	}
+
+	thumbnail := placeholderURL
+	if len(game.Images) > 0 {
+		thumbnail = game.Images[0]
+	}
+	return &GameResponse{Game: game, ThumbnailURL: thumbnail}
+}
+
+func ToGameResponseList(games []*model.Game, placeholderURL string) []*GameResponse {
+	result := make([]*GameResponse, len(games))
+	for i, game := range games {
+		result[i] = ToGameResponse(game, placeholderURL)
+	}
+	return result
+}
+
+type ReportGameRequest struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
 }
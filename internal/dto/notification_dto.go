@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/yoockh/go-game-rental-api/internal/utils"
+
+// NotificationListMeta is the usual pagination meta plus the unread badge count
+type NotificationListMeta struct {
+	utils.PaginationMeta
+	UnreadCount int64 `json:"unread_count"`
+}
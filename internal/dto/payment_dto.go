@@ -5,6 +5,8 @@ import "github.com/yoockh/go-game-rental-api/internal/model"
 type CreatePaymentRequest struct {
 	Provider    model.PaymentProvider `json:"provider" validate:"required,oneof=stripe midtrans"`
 	PaymentType string                `json:"payment_type,omitempty"`
+	// CardToken is the Midtrans.js card token; required for Midtrans card payments, including deposit holds
+	CardToken string `json:"card_token,omitempty"`
 }
 
 type PaymentWebhookRequest struct {
@@ -13,3 +15,27 @@ type PaymentWebhookRequest struct {
 	PaymentMethod     *string `json:"payment_method,omitempty"`
 	FailureReason     *string `json:"failure_reason,omitempty"`
 }
+
+type MarkPaymentPaidRequest struct {
+	PaymentMethod string `json:"payment_method,omitempty"`
+	Note          string `json:"note" validate:"required,min=3"`
+}
+
+type RefundPaymentRequest struct {
+	Amount float64 `json:"amount" validate:"required,gt=0"`
+	Reason string  `json:"reason" validate:"required,min=3"`
+}
+
+type CaptureDepositHoldRequest struct {
+	// Also capture the security deposit, e.g. when the game came back damaged or not at all
+	KeepDeposit bool   `json:"keep_deposit"`
+	Note        string `json:"note" validate:"required,min=3"`
+}
+
+type VoidDepositHoldRequest struct {
+	Reason string `json:"reason" validate:"required,min=3"`
+}
+
+type MarkPaymentFailedRequest struct {
+	Reason string `json:"reason" validate:"required,min=3"`
+}
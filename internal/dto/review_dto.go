@@ -1,8 +1,39 @@
 package dto
 
+import "github.com/yoockh/go-game-rental-api/internal/model"
+
 type CreateReviewRequest struct {
 	Rating  int    `json:"rating" validate:"required,min=1,max=5"`
 	Comment string `json:"comment,omitempty"`
 }
 
+// UpdateReviewRequest replaces both rating and comment; an empty comment clears it
+type UpdateReviewRequest struct {
+	Rating  int    `json:"rating" validate:"required,min=1,max=5"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type ReplyReviewRequest struct {
+	Reply string `json:"reply" validate:"required,max=2000"`
+}
 
+// CanReviewResponse tells the review form upfront whether submitting will succeed
+type CanReviewResponse struct {
+	CanReview bool   `json:"can_review"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// UserReviewResponse is a review the caller wrote, with the game it is about.
+// model.Review hides Game from JSON, so it is surfaced here.
+type UserReviewResponse struct {
+	*model.Review
+	Game *model.Game `json:"game,omitempty"`
+}
+
+func ToUserReviewResponseList(reviews []*model.Review) []*UserReviewResponse {
+	result := make([]*UserReviewResponse, len(reviews))
+	for i, review := range reviews {
+		result[i] = &UserReviewResponse{Review: review, Game: review.Game}
+	}
+	return result
+}
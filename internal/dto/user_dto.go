@@ -8,6 +8,44 @@ type UpdateProfileRequest struct {
 	Address  string `json:"address,omitempty"`
 }
 
+// ChangePasswordRequest leaves strength rules to the service so a weak password gets a 422
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
 type UpdateUserRoleRequest struct {
 	Role model.UserRole `json:"role" validate:"required,oneof=customer partner admin"`
 }
+
+type UpdateUserStatusRequest struct {
+	IsActive *bool `json:"is_active" validate:"required"`
+}
+
+// CreateUserRequest is an admin-created account; the password is generated and emailed
+type CreateUserRequest struct {
+	Email    string         `json:"email" validate:"required,email"`
+	FullName string         `json:"full_name" validate:"required,min=2"`
+	Phone    string         `json:"phone,omitempty" validate:"omitempty,min=10"`
+	Address  string         `json:"address,omitempty"`
+	Role     model.UserRole `json:"role" validate:"required,oneof=customer admin super_admin"`
+	IsActive *bool          `json:"is_active" validate:"required"`
+}
+
+type UserBookingStats struct {
+	TotalBookings     int64 `json:"total_bookings"`
+	ActiveBookings    int64 `json:"active_bookings"`
+	CompletedBookings int64 `json:"completed_bookings"`
+}
+
+// UserProfileResponse is the profile plus booking stats when include=stats is requested
+type UserProfileResponse struct {
+	*model.User
+	Stats *UserBookingStats `json:"stats,omitempty"`
+}
+
+// UserPermissionsResponse is the caller's role and what it allows, for clients deciding which UI to show
+type UserPermissionsResponse struct {
+	Role        model.UserRole    `json:"role"`
+	Permissions model.Permissions `json:"permissions"`
+}
@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -18,14 +21,20 @@ type AuthHandler struct {
 	jwtSecret   string
 	validate    *validator.Validate
 	emailRepo   email.EmailRepository
+	resetURL    string
+	verifyURL   string
 }
 
-func NewAuthHandler(userService service.UserService, jwtSecret string, emailRepo email.EmailRepository) *AuthHandler {
+// NewAuthHandler takes resetURL, the frontend page that receives ?token= from reset emails,
+// and verifyURL, the GET /auth/verify-email address linked from verification emails
+func NewAuthHandler(userService service.UserService, jwtSecret string, emailRepo email.EmailRepository, resetURL, verifyURL string) *AuthHandler {
 	return &AuthHandler{
 		userService: userService,
 		jwtSecret:   jwtSecret,
 		validate:    utils.GetValidator(),
 		emailRepo:   emailRepo,
+		resetURL:    resetURL,
+		verifyURL:   verifyURL,
 	}
 }
 
@@ -38,6 +47,7 @@ func NewAuthHandler(userService service.UserService, jwtSecret string, emailRepo
 // @Param request body dto.RegisterRequest true "Registration details"
 // @Success 201 {object} map[string]interface{} "User registered successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid input or validation error"
+// @Failure 403 {object} map[string]interface{} "Self-registration is disabled"
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c echo.Context) error {
 	var req dto.RegisterRequest
@@ -51,7 +61,22 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	user, err := h.userService.Register(&req)
 	if err != nil {
 		logrus.WithError(err).Error("Registration failed")
-		return myResponse.BadRequest(c, err.Error())
+		return utils.MapServiceError(c, err)
+	}
+
+	// Inactive on signup means REQUIRE_EMAIL_VERIFICATION is on; send a verify link instead of the welcome
+	if !user.IsActive {
+		token, err := h.userService.IssueEmailVerification(user.ID)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to issue email verification token")
+			return utils.MapServiceError(c, err)
+		}
+		go h.sendVerificationEmail(context.WithoutCancel(c.Request().Context()), user.Email, user.FullName, token)
+
+		return myResponse.Created(c, "User registered successfully. Please check your email to verify your account.", map[string]interface{}{
+			"user_id": user.ID,
+			"email":   user.Email,
+		})
 	}
 
 	// Send welcome email (no verification needed)
@@ -98,9 +123,186 @@ func (h *AuthHandler) Login(c echo.Context) error {
 
 	response, err := h.userService.Login(&req, h.jwtSecret)
 	if err != nil {
-		logrus.WithError(err).WithField("email", req.Email).Error("Login failed")
+		logrus.WithError(err).WithField("email", utils.RedactEmail(req.Email)).Error("Login failed")
+		if errors.Is(err, service.ErrEmailNotVerified) {
+			return utils.MapServiceError(c, err)
+		}
 		return myResponse.Unauthorized(c, err.Error())
 	}
 
 	return myResponse.Success(c, "Login successful", response)
 }
+
+// RefreshToken godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token from login for a new access token
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.RefreshTokenResponse "Token refreshed"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Invalid, expired or revoked refresh token"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) RefreshToken(c echo.Context) error {
+	var req dto.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	response, err := h.userService.RefreshAccessToken(req.RefreshToken, h.jwtSecret)
+	if err != nil {
+		logrus.WithError(err).Error("Token refresh failed")
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Token refreshed", response)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revoke a refresh token. Access tokens already issued stay valid until they expire
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token to revoke"
+// @Success 200 {object} map[string]interface{} "Logged out"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Invalid or already revoked refresh token"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c echo.Context) error {
+	var req dto.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	if err := h.userService.Logout(req.RefreshToken); err != nil {
+		logrus.WithError(err).Error("Logout failed")
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Logged out", nil)
+}
+
+// RequestPasswordReset godoc
+// @Summary Request password reset
+// @Description Email a single-use password reset link. Always succeeds so registered emails can't be discovered
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Account email"
+// @Success 200 {object} map[string]interface{} "Reset link sent if the account exists"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Router /auth/forgot-password [post]
+func (h *AuthHandler) RequestPasswordReset(c echo.Context) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	const message = "If an account exists for this email, a password reset link has been sent."
+
+	user, token, err := h.userService.RequestPasswordReset(req.Email)
+	if err != nil {
+		logrus.WithError(err).Error("Password reset request failed")
+		return myResponse.Success(c, message, nil)
+	}
+	if user == nil {
+		return myResponse.Success(c, message, nil)
+	}
+
+	ctx := context.WithoutCancel(c.Request().Context())
+	go func() {
+		link := fmt.Sprintf("%s?token=%s", h.resetURL, url.QueryEscape(token))
+		subject := "Reset your Game Rental Platform password"
+		htmlContent := fmt.Sprintf(`
+			<h1>Hi %s,</h1>
+			<p>We received a request to reset your password.</p>
+			<p><a href="%s">Reset your password</a></p>
+			<p>This link expires in 1 hour. If you didn't request it, you can ignore this email.</p>
+		`, user.FullName, link)
+		plainText := fmt.Sprintf("Reset your password: %s (expires in 1 hour)", link)
+
+		if err := h.emailRepo.SendEmail(ctx, user.Email, subject, plainText, htmlContent); err != nil {
+			logrus.WithError(err).Error("Failed to send password reset email")
+		}
+	}()
+
+	return myResponse.Success(c, message, nil)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password using the token from a reset email. Each token works once, the new password needs 8+ characters with a letter and a digit, and every existing session is signed out
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]interface{} "Password reset successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid, expired or already used token"
+// @Failure 422 {object} map[string]interface{} "New password too weak"
+// @Router /auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c echo.Context) error {
+	var req dto.ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	if err := h.userService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		logrus.WithError(err).Error("Password reset failed")
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Password reset successfully. You can now login.", nil)
+}
+
+// VerifyEmail godoc
+// @Summary Verify email
+// @Description Activate an account with the token from its verification email
+// @Tags Authentication
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} map[string]interface{} "Email verified successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid, expired or already used token"
+// @Router /auth/verify-email [get]
+func (h *AuthHandler) VerifyEmail(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return myResponse.BadRequest(c, "token is required")
+	}
+
+	if err := h.userService.VerifyEmail(token); err != nil {
+		logrus.WithError(err).Error("Email verification failed")
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Email verified successfully. You can now login.", nil)
+}
+
+func (h *AuthHandler) sendVerificationEmail(ctx context.Context, to, fullName, token string) {
+	link := fmt.Sprintf("%s?token=%s", h.verifyURL, url.QueryEscape(token))
+	subject := "Verify your Game Rental Platform email"
+	htmlContent := fmt.Sprintf(`
+		<h1>Welcome %s!</h1>
+		<p>Thank you for registering at Game Rental Platform.</p>
+		<p><a href="%s">Verify your email</a> to activate your account.</p>
+		<p>This link expires in 24 hours.</p>
+	`, fullName, link)
+	plainText := fmt.Sprintf("Welcome %s! Verify your email to activate your account: %s", fullName, link)
+
+	if err := h.emailRepo.SendEmail(ctx, to, subject, plainText, htmlContent); err != nil {
+		logrus.WithError(err).Error("Failed to send verification email")
+	}
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/yoockh/go-game-rental-api/internal/dto"
 	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/service"
 )
 
 // ============= MOCK USER SERVICE =============
@@ -40,6 +43,14 @@ func (m *MockUserService) Register(registerData interface{}) (*model.User, error
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
+func (m *MockUserService) CreateUser(requestorRole model.UserRole, createData interface{}) (*model.User, string, error) {
+	args := m.Called(requestorRole, createData)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*model.User), args.String(1), args.Error(2)
+}
+
 func (m *MockUserService) Login(loginData interface{}, jwtSecret string) (interface{}, error) {
 	args := m.Called(loginData, jwtSecret)
 	if args.Get(0) == nil {
@@ -48,8 +59,8 @@ func (m *MockUserService) Login(loginData interface{}, jwtSecret string) (interf
 	return args.Get(0), args.Error(1)
 }
 
-func (m *MockUserService) GetAllUsers(requestorRole model.UserRole, limit, offset int) ([]*model.User, int64, error) {
-	args := m.Called(requestorRole, limit, offset)
+func (m *MockUserService) GetAllUsers(requestorRole model.UserRole, filter repository.UserFilter, limit, offset int) ([]*model.User, int64, error) {
+	args := m.Called(requestorRole, filter, limit, offset)
 	return args.Get(0).([]*model.User), args.Get(1).(int64), args.Error(2)
 }
 
@@ -66,8 +77,8 @@ func (m *MockUserService) UpdateUserRole(requestorRole model.UserRole, userID ui
 	return args.Error(0)
 }
 
-func (m *MockUserService) ToggleUserStatus(requestorRole model.UserRole, userID uint) error {
-	args := m.Called(requestorRole, userID)
+func (m *MockUserService) SetUserStatus(requestorRole model.UserRole, userID uint, isActive bool) error {
+	args := m.Called(requestorRole, userID, isActive)
 	return args.Error(0)
 }
 
@@ -76,6 +87,47 @@ func (m *MockUserService) DeleteUser(requestorID uint, requestorRole model.UserR
 	return args.Error(0)
 }
 
+func (m *MockUserService) RequestPasswordReset(email string) (*model.User, string, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(*model.User), args.String(1), args.Error(2)
+}
+
+func (m *MockUserService) ResetPassword(token, newPassword string) error {
+	args := m.Called(token, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserService) IssueEmailVerification(userID uint) (string, error) {
+	args := m.Called(userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUserService) VerifyEmail(token string) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockUserService) RefreshAccessToken(refreshToken, jwtSecret string) (*dto.RefreshTokenResponse, error) {
+	args := m.Called(refreshToken, jwtSecret)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RefreshTokenResponse), args.Error(1)
+}
+
+func (m *MockUserService) Logout(refreshToken string) error {
+	args := m.Called(refreshToken)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ChangePassword(userID uint, oldPassword, newPassword string) error {
+	args := m.Called(userID, oldPassword, newPassword)
+	return args.Error(0)
+}
+
 // ============= MOCK EMAIL REPO =============
 type MockEmailRepository struct {
 	mock.Mock
@@ -96,7 +148,7 @@ func TestRegister_Success(t *testing.T) {
 	// Setup
 	mockUserService := new(MockUserService)
 	mockEmailRepo := new(MockEmailRepository)
-	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo)
+	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo, "http://localhost:3000/reset-password", "http://localhost:8080/auth/verify-email")
 	e := echo.New()
 
 	// Mock data
@@ -141,7 +193,7 @@ func TestRegister_ValidationError(t *testing.T) {
 	// Setup
 	mockUserService := new(MockUserService)
 	mockEmailRepo := new(MockEmailRepository)
-	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo)
+	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo, "http://localhost:3000/reset-password", "http://localhost:8080/auth/verify-email")
 	e := echo.New()
 
 	// Invalid email
@@ -169,7 +221,7 @@ func TestRegister_EmailExists(t *testing.T) {
 	// Setup
 	mockUserService := new(MockUserService)
 	mockEmailRepo := new(MockEmailRepository)
-	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo)
+	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo, "http://localhost:3000/reset-password", "http://localhost:8080/auth/verify-email")
 	e := echo.New()
 
 	reqBody := `{
@@ -198,12 +250,41 @@ func TestRegister_EmailExists(t *testing.T) {
 	mockUserService.AssertExpectations(t)
 }
 
+// ============= TEST REGISTER EMAIL EXISTS SERVICE ERROR =============
+func TestRegister_EmailExistsServiceError(t *testing.T) {
+	mockUserService := new(MockUserService)
+	mockEmailRepo := new(MockEmailRepository)
+	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo, "http://localhost:3000/reset-password", "http://localhost:8080/auth/verify-email")
+	e := echo.New()
+
+	reqBody := `{
+        "email": "existing@example.com",
+        "password": "password123",
+        "full_name": "Test User"
+    }`
+
+	// Mock: service returns the typed error, which carries its own status
+	mockUserService.On("Register", mock.Anything).Return(nil, service.ErrEmailAlreadyExists)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, handler.Register(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Contains(t, rec.Body.String(), "email already exists")
+	}
+
+	mockUserService.AssertExpectations(t)
+}
+
 // ============= TEST LOGIN SUCCESS =============
 func TestLogin_Success(t *testing.T) {
 	// Setup
 	mockUserService := new(MockUserService)
 	mockEmailRepo := new(MockEmailRepository)
-	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo)
+	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo, "http://localhost:3000/reset-password", "http://localhost:8080/auth/verify-email")
 	e := echo.New()
 
 	reqBody := `{
@@ -247,7 +328,7 @@ func TestLogin_InvalidCredentials(t *testing.T) {
 	// Setup
 	mockUserService := new(MockUserService)
 	mockEmailRepo := new(MockEmailRepository)
-	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo)
+	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo, "http://localhost:3000/reset-password", "http://localhost:8080/auth/verify-email")
 	e := echo.New()
 
 	reqBody := `{
@@ -278,7 +359,7 @@ func TestLogin_ValidationError(t *testing.T) {
 	// Setup
 	mockUserService := new(MockUserService)
 	mockEmailRepo := new(MockEmailRepository)
-	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo)
+	handler := NewAuthHandler(mockUserService, "test-secret", mockEmailRepo, "http://localhost:3000/reset-password", "http://localhost:8080/auth/verify-email")
 	e := echo.New()
 
 	// Missing password
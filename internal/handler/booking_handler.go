@@ -65,10 +65,14 @@ func (h *BookingHandler) CreateBooking(c echo.Context) error {
 		EndDate:   endDate,
 		Notes:     utils.PtrOrNil(req.Notes),
 	}
+	if req.AcceptedTerms {
+		now := time.Now()
+		bookingData.TermsAcceptedAt = &now
+	}
 
-	err = h.bookingService.Create(userID, bookingData)
+	err = h.bookingService.Create(c.Request().Context(), userID, bookingData)
 	if err != nil {
-		return myResponse.BadRequest(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Created(c, "Booking created successfully", bookingData)
@@ -76,14 +80,16 @@ func (h *BookingHandler) CreateBooking(c echo.Context) error {
 
 // GetMyBookings godoc
 // @Summary Get my bookings
-// @Description Get list of current user's bookings
+// @Description Get list of current user's bookings, optionally filtered by status
 // @Tags Bookings
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param status query string false "Booking status" Enums(pending, confirmed, active, completed, cancelled)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} map[string]interface{} "Bookings retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid status"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Router /bookings/my [get]
 func (h *BookingHandler) GetMyBookings(c echo.Context) error {
@@ -92,9 +98,14 @@ func (h *BookingHandler) GetMyBookings(c echo.Context) error {
 		return myResponse.Unauthorized(c, "Unauthorized")
 	}
 
+	status := model.BookingStatus(c.QueryParam("status"))
+	if status != "" && !status.IsValid() {
+		return myResponse.BadRequest(c, "Invalid status (use pending, confirmed, active, completed or cancelled)")
+	}
+
 	params := utils.ParsePagination(c)
 
-	bookings, total, err := h.bookingService.GetUserBookings(userID, params.Limit, params.Offset)
+	bookings, total, err := h.bookingService.GetUserBookings(userID, status, params.Limit, params.Offset)
 	if err != nil {
 		return myResponse.InternalServerError(c, "Failed to retrieve bookings")
 	}
@@ -111,7 +122,7 @@ func (h *BookingHandler) GetMyBookings(c echo.Context) error {
 // @Produce json
 // @Security BearerAuth
 // @Param booking_id path int true "Booking ID"
-// @Success 200 {object} map[string]interface{} "Booking retrieved successfully"
+// @Success 200 {object} dto.BookingDetailResponse "Booking retrieved successfully"
 // @Router /bookings/{booking_id} [get]
 func (h *BookingHandler) GetBookingDetail(c echo.Context) error {
 	userID := echomw.CurrentUserID(c)
@@ -119,27 +130,69 @@ func (h *BookingHandler) GetBookingDetail(c echo.Context) error {
 
 	booking, err := h.bookingService.GetByID(userID, bookingID)
 	if err != nil {
-		return myResponse.NotFound(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
-	return myResponse.Success(c, "Booking retrieved successfully", booking)
+	return myResponse.Success(c, "Booking retrieved successfully", dto.BookingDetailResponse{
+		Booking: booking,
+		Deposit: service.SummarizeDeposit(booking),
+	})
+}
+
+// GetBookingDeposit godoc
+// @Summary Get booking deposit status
+// @Description Whether the security deposit is held, refunded or forfeited, and the amount. none means the booking has no deposit or hasn't been paid
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param booking_id path int true "Booking ID"
+// @Success 200 {object} dto.DepositSummary "Deposit status retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid booking ID"
+// @Failure 403 {object} map[string]interface{} "Not your booking"
+// @Failure 404 {object} map[string]interface{} "Booking not found"
+// @Router /bookings/{booking_id}/deposit [get]
+func (h *BookingHandler) GetBookingDeposit(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+	bookingID := myRequest.PathParamUint(c, "booking_id")
+	if bookingID == 0 {
+		return myResponse.BadRequest(c, "Invalid booking ID")
+	}
+
+	summary, err := h.bookingService.GetDepositStatus(userID, bookingID)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Deposit status retrieved successfully", summary)
 }
 
 // CancelBooking godoc
 // @Summary Cancel booking
-// @Description Cancel a pending booking
+// @Description Cancel a pending booking, optionally saying why
 // @Tags Bookings
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param booking_id path int true "Booking ID"
+// @Param request body dto.CancelBookingRequest false "Cancellation reason"
 // @Success 200 {object} map[string]interface{} "Booking cancelled successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
 // @Router /bookings/{booking_id}/cancel [patch]
 func (h *BookingHandler) CancelBooking(c echo.Context) error {
 	userID := echomw.CurrentUserID(c)
 	bookingID := myRequest.PathParamUint(c, "booking_id")
 
-	err := h.bookingService.Cancel(userID, bookingID)
+	// Body is optional; older clients send none
+	var req dto.CancelBookingRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	err := h.bookingService.Cancel(userID, bookingID, req.Reason)
 	if err != nil {
 		return utils.MapServiceError(c, err)
 	}
@@ -147,6 +200,97 @@ func (h *BookingHandler) CancelBooking(c echo.Context) error {
 	return myResponse.Success(c, "Booking cancelled successfully", nil)
 }
 
+// ExtendBooking godoc
+// @Summary Extend booking
+// @Description Move an active booking's end date later. The added days are priced at the booking's daily price and added to extension_amount_due for a follow-up payment
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param booking_id path int true "Booking ID"
+// @Param request body dto.ExtendBookingRequest true "New end date"
+// @Success 200 {object} model.Booking "Booking extended successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input, or end date not later or beyond BOOKING_MAX_ADVANCE_DAYS"
+// @Failure 403 {object} map[string]interface{} "Booking not owned"
+// @Failure 404 {object} map[string]interface{} "Booking not found"
+// @Failure 409 {object} map[string]interface{} "Booking not active or the extra days are already booked"
+// @Router /bookings/{booking_id}/extend [patch]
+func (h *BookingHandler) ExtendBooking(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+	bookingID := myRequest.PathParamUint(c, "booking_id")
+	if bookingID == 0 {
+		return myResponse.BadRequest(c, "Invalid booking ID")
+	}
+
+	var req dto.ExtendBookingRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return myResponse.BadRequest(c, "Invalid end_date format (use YYYY-MM-DD)")
+	}
+
+	if err := h.bookingService.ExtendBooking(userID, bookingID, endDate); err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	booking, err := h.bookingService.GetByID(userID, bookingID)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Booking extended successfully", booking)
+}
+
+// QuoteBooking godoc
+// @Summary Get a price quote
+// @Description Get the cost breakdown for renting a game over a date range without an account. Nothing is created or reserved.
+// @Tags Games
+// @Accept json
+// @Produce json
+// @Param id path int true "Game ID"
+// @Param request body dto.BookingQuoteRequest true "Intended rental dates"
+// @Success 200 {object} dto.BookingQuoteResponse "Quote calculated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 429 {object} map[string]interface{} "Too many requests"
+// @Router /games/{id}/quote [post]
+func (h *BookingHandler) QuoteBooking(c echo.Context) error {
+	gameID := myRequest.PathParamUint(c, "id")
+	if gameID == 0 {
+		return myResponse.BadRequest(c, "Invalid game ID")
+	}
+
+	var req dto.BookingQuoteRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return myResponse.BadRequest(c, "Invalid start_date format (use YYYY-MM-DD)")
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return myResponse.BadRequest(c, "Invalid end_date format (use YYYY-MM-DD)")
+	}
+
+	quote, err := h.bookingService.Quote(gameID, startDate, endDate)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Quote calculated successfully", quote)
+}
+
 // Admin endpoints
 // GetAllBookings godoc
 // @Summary Get all bookings
@@ -174,6 +318,69 @@ func (h *BookingHandler) GetAllBookings(c echo.Context) error {
 	return myResponse.Paginated(c, "Bookings retrieved successfully", bookings, meta)
 }
 
+// SearchBookings godoc
+// @Summary Search bookings
+// @Description Search bookings by notes, customer email or name, and game name (Admin only)
+// @Tags Admin - Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search query"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} map[string]interface{} "Bookings search results"
+// @Failure 400 {object} map[string]interface{} "Search query required"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/bookings/search [get]
+func (h *BookingHandler) SearchBookings(c echo.Context) error {
+	query := myRequest.QueryString(c, "q", "")
+	if query == "" {
+		return myResponse.BadRequest(c, "Search query is required")
+	}
+
+	params := utils.ParsePagination(c)
+	role := echomw.CurrentRole(c)
+
+	bookings, total, err := h.bookingService.Search(model.UserRole(role), query, params.Limit, params.Offset)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	meta := utils.CreateMeta(params, total)
+	return myResponse.Paginated(c, "Bookings search results", bookings, meta)
+}
+
+// ConfirmReturn godoc
+// @Summary Confirm game return
+// @Description Complete an active or overdue booking and put the copy back in stock. Returns after the end date are charged the game's late_fee_per_day for each late day as a pending late_fee payment (Admin only)
+// @Tags Admin - Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Booking ID"
+// @Success 200 {object} model.Booking "Return confirmed"
+// @Failure 400 {object} map[string]interface{} "Invalid booking ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Booking not found"
+// @Failure 409 {object} map[string]interface{} "Booking is not out"
+// @Router /admin/bookings/{id}/return [post]
+func (h *BookingHandler) ConfirmReturn(c echo.Context) error {
+	bookingID := myRequest.PathParamUint(c, "id")
+	if bookingID == 0 {
+		return myResponse.BadRequest(c, "Invalid booking ID")
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+	booking, err := h.bookingService.ConfirmReturn(adminID, model.UserRole(role), bookingID, time.Now())
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Return confirmed", booking)
+}
+
 // UpdateBookingStatus godoc
 // @Summary Update booking status
 // @Description Update booking status (Admin only)
@@ -201,11 +408,165 @@ func (h *BookingHandler) UpdateBookingStatus(c echo.Context) error {
 		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
 	}
 
+	adminID := echomw.CurrentUserID(c)
 	role := echomw.CurrentRole(c)
-	err := h.bookingService.UpdateStatus(model.UserRole(role), bookingID, req.Status)
+	err := h.bookingService.UpdateStatus(c.Request().Context(), adminID, model.UserRole(role), bookingID, req.Status)
 	if err != nil {
 		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Success(c, "Booking status updated successfully", nil)
 }
+
+// DeleteBooking godoc
+// @Summary Delete booking
+// @Description Soft-delete a booking so it disappears from lists; live bookings are cancelled and their stock released. Restorable for 30 days (Admin only)
+// @Tags Admin - Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Booking ID"
+// @Success 200 {object} map[string]interface{} "Booking deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid booking ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Booking not found"
+// @Failure 409 {object} map[string]interface{} "Booking is active"
+// @Router /admin/bookings/{id} [delete]
+func (h *BookingHandler) DeleteBooking(c echo.Context) error {
+	bookingID := myRequest.PathParamUint(c, "id")
+	if bookingID == 0 {
+		return myResponse.BadRequest(c, "Invalid booking ID")
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+	if err := h.bookingService.SoftDelete(adminID, model.UserRole(role), bookingID); err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Booking deleted successfully", nil)
+}
+
+// ResendConfirmation godoc
+// @Summary Resend booking confirmation
+// @Description Re-send the booking confirmation (pending) or payment confirmation (paid) email to the customer. Limited to once per 10 minutes per booking (Admin only)
+// @Tags Admin - Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Booking ID"
+// @Success 200 {object} map[string]interface{} "Confirmation email resent"
+// @Failure 400 {object} map[string]interface{} "Invalid booking ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Booking not found"
+// @Failure 409 {object} map[string]interface{} "Booking is cancelled"
+// @Failure 429 {object} map[string]interface{} "Resent too recently"
+// @Failure 502 {object} map[string]interface{} "Email provider failed"
+// @Router /admin/bookings/{id}/resend-confirmation [post]
+func (h *BookingHandler) ResendConfirmation(c echo.Context) error {
+	bookingID := myRequest.PathParamUint(c, "id")
+	if bookingID == 0 {
+		return myResponse.BadRequest(c, "Invalid booking ID")
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+	kind, err := h.bookingService.ResendConfirmation(c.Request().Context(), adminID, model.UserRole(role), bookingID)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Confirmation email resent", map[string]interface{}{
+		"booking_id": bookingID,
+		"email":      kind,
+	})
+}
+
+// ProcessOverdueBookings godoc
+// @Summary Process overdue bookings
+// @Description Mark active bookings past their end date as overdue and email each customer. Safe to run repeatedly, e.g. from a daily scheduler (Admin only)
+// @Tags Admin - Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Overdue bookings processed"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/bookings/process-overdue [post]
+func (h *BookingHandler) ProcessOverdueBookings(c echo.Context) error {
+	role := echomw.CurrentRole(c)
+	if !model.UserRole(role).Permissions().CanManageBookings {
+		return utils.MapServiceError(c, service.ErrInsufficientPermission)
+	}
+
+	processed, err := h.bookingService.ProcessOverdueBookings()
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Overdue bookings processed", map[string]interface{}{
+		"processed": processed,
+	})
+}
+
+// RestoreBooking godoc
+// @Summary Restore booking
+// @Description Restore a soft-deleted booking deleted within the last 30 days (Admin only)
+// @Tags Admin - Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Booking ID"
+// @Success 200 {object} map[string]interface{} "Booking restored successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid booking ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Deleted booking not found"
+// @Failure 409 {object} map[string]interface{} "Restore window has passed"
+// @Router /admin/bookings/{id}/restore [post]
+func (h *BookingHandler) RestoreBooking(c echo.Context) error {
+	bookingID := myRequest.PathParamUint(c, "id")
+	if bookingID == 0 {
+		return myResponse.BadRequest(c, "Invalid booking ID")
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+	if err := h.bookingService.Restore(adminID, model.UserRole(role), bookingID); err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Booking restored successfully", nil)
+}
+
+// GetBookingAudit godoc
+// @Summary Get booking audit log
+// @Description List admin changes to a booking, newest first, with the old and new value of each changed field and who made it. Automatic overdue transitions have no actor (Admin only)
+// @Tags Admin - Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Booking ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} map[string]interface{} "Booking audit log retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid booking ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/bookings/{id}/audit [get]
+func (h *BookingHandler) GetBookingAudit(c echo.Context) error {
+	bookingID := myRequest.PathParamUint(c, "id")
+	if bookingID == 0 {
+		return myResponse.BadRequest(c, "Invalid booking ID")
+	}
+
+	params := utils.ParsePagination(c)
+	role := echomw.CurrentRole(c)
+
+	entries, total, err := h.bookingService.GetAuditLog(model.UserRole(role), bookingID, params.Limit, params.Offset)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	meta := utils.CreateMeta(params, total)
+	return myResponse.Paginated(c, "Booking audit log retrieved successfully", entries, meta)
+}
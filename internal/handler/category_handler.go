@@ -66,6 +66,56 @@ func (h *CategoryHandler) GetCategoryDetail(c echo.Context) error {
 	return myResponse.Success(c, "Category retrieved successfully", category)
 }
 
+// GetAllCategoriesAdmin godoc
+// @Summary Get all categories with game counts
+// @Description Get all categories, including inactive ones, with the number of games in each (Admin only)
+// @Tags Admin - Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.AdminCategoryDTO "Categories retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/categories [get]
+func (h *CategoryHandler) GetAllCategoriesAdmin(c echo.Context) error {
+	role := echomw.CurrentRole(c)
+	categories, err := h.categoryService.GetAllCategoriesWithGameCount(model.UserRole(role))
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Categories retrieved successfully", categories)
+}
+
+// GetCategoryDetailAdmin godoc
+// @Summary Get category detail with game count
+// @Description Get a category with the number of games it holds, useful before deleting it (Admin only)
+// @Tags Admin - Categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} dto.AdminCategoryDTO "Category retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid category ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Category not found"
+// @Router /admin/categories/{id} [get]
+func (h *CategoryHandler) GetCategoryDetailAdmin(c echo.Context) error {
+	categoryID := myRequest.PathParamUint(c, "id")
+	if categoryID == 0 {
+		return myResponse.BadRequest(c, "Invalid category ID")
+	}
+
+	role := echomw.CurrentRole(c)
+	category, err := h.categoryService.GetCategoryWithGameCount(model.UserRole(role), categoryID)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Category retrieved successfully", category)
+}
+
 // CreateCategory godoc
 // @Summary Create category
 // @Description Create a new game category (Admin only)
@@ -99,7 +149,7 @@ func (h *CategoryHandler) CreateCategory(c echo.Context) error {
 
 	err := h.categoryService.CreateCategory(model.UserRole(role), categoryData)
 	if err != nil {
-		return myResponse.BadRequest(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Created(c, "Category created successfully", categoryData)
@@ -143,7 +193,7 @@ func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
 
 	err := h.categoryService.UpdateCategory(model.UserRole(role), categoryID, updateData)
 	if err != nil {
-		return myResponse.BadRequest(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	// Get updated category for response
@@ -157,16 +207,18 @@ func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
 
 // DeleteCategory godoc
 // @Summary Delete category
-// @Description Delete a category (Admin only)
+// @Description Delete a category (Admin only). Pass target_category_id to move its games there first; otherwise deleting a category with games fails.
 // @Tags Admin - Categories
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Category ID"
+// @Param target_category_id query int false "Active category to reassign the games to before deleting"
 // @Success 200 {object} map[string]interface{} "Category deleted successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid category ID"
+// @Failure 400 {object} map[string]interface{} "Invalid category ID or target"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Category still has games"
 // @Router /admin/categories/{id} [delete]
 func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
 	categoryID := myRequest.PathParamUint(c, "id")
@@ -174,10 +226,20 @@ func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
 		return myResponse.BadRequest(c, "Invalid category ID")
 	}
 
+	targetID := myRequest.QueryInt(c, "target_category_id", 0)
+	if targetID < 0 {
+		return myResponse.BadRequest(c, "Invalid target_category_id")
+	}
+
 	role := echomw.CurrentRole(c)
-	err := h.categoryService.DeleteCategory(model.UserRole(role), categoryID)
+	var err error
+	if targetID > 0 {
+		err = h.categoryService.ReassignAndDeleteCategory(model.UserRole(role), categoryID, uint(targetID))
+	} else {
+		err = h.categoryService.DeleteCategory(model.UserRole(role), categoryID)
+	}
 	if err != nil {
-		return myResponse.BadRequest(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Success(c, "Category deleted successfully", nil)
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	echomw "github.com/yoockh/go-api-utils/pkg-echo/middleware"
+	myResponse "github.com/yoockh/go-api-utils/pkg-echo/response"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/service"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+)
+
+type DashboardHandler struct {
+	dashboardService service.DashboardService
+}
+
+func NewDashboardHandler(dashboardService service.DashboardService) *DashboardHandler {
+	return &DashboardHandler{dashboardService: dashboardService}
+}
+
+// GetCounts godoc
+// @Summary Get dashboard counts
+// @Description Get entity totals for the admin home screen in one call: users, active_games, bookings, payments, pending_payments (Admin only)
+// @Tags Admin - Dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]int64 "Counts retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/counts [get]
+func (h *DashboardHandler) GetCounts(c echo.Context) error {
+	role := echomw.CurrentRole(c)
+
+	counts, err := h.dashboardService.GetCounts(model.UserRole(role))
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Counts retrieved successfully", counts)
+}
+
+// GetStats godoc
+// @Summary Get admin statistics
+// @Description Get total users, active games, bookings per status and revenue from paid payments (Admin only)
+// @Tags Admin - Dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.AdminStatsResponse "Stats retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/stats [get]
+func (h *DashboardHandler) GetStats(c echo.Context) error {
+	role := echomw.CurrentRole(c)
+
+	stats, err := h.dashboardService.GetStats(model.UserRole(role))
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Stats retrieved successfully", stats)
+}
@@ -1,28 +1,41 @@
 package handler
 
 import (
-	"log"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 	echomw "github.com/yoockh/go-api-utils/pkg-echo/middleware"
 	myRequest "github.com/yoockh/go-api-utils/pkg-echo/request"
 	myResponse "github.com/yoockh/go-api-utils/pkg-echo/response"
 	"github.com/yoockh/go-game-rental-api/internal/dto"
 	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/repository/storage"
 	"github.com/yoockh/go-game-rental-api/internal/service"
 	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
 
 type GameHandler struct {
-	gameService service.GameService
-	validate    *validator.Validate
+	gameService         service.GameService
+	reviewService       service.ReviewService
+	validate            *validator.Validate
+	placeholderImageURL string
 }
 
-func NewGameHandler(gameService service.GameService) *GameHandler {
+func NewGameHandler(gameService service.GameService, reviewService service.ReviewService, placeholderImageURL string) *GameHandler {
 	return &GameHandler{
-		gameService: gameService,
-		validate:    utils.GetValidator(),
+		gameService:         gameService,
+		reviewService:       reviewService,
+		validate:            utils.GetValidator(),
+		placeholderImageURL: placeholderImageURL,
 	}
 }
 
@@ -34,34 +47,141 @@ func NewGameHandler(gameService service.GameService) *GameHandler {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param fields query string false "Comma separated fields to return, e.g. id,name,rental_price_per_day"
+// @Param category_id query int false "Only games in this category"
+// @Param platform query string false "Only games for this platform (case-insensitive)"
+// @Param min_price query number false "Minimum rental price per day"
+// @Param max_price query number false "Maximum rental price per day"
+// @Param condition query string false "Only games in this condition" Enums(excellent, good, fair)
 // @Success 200 {object} map[string]interface{} "Games retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
 // @Router /games [get]
 func (h *GameHandler) GetAllGames(c echo.Context) error {
 	params := utils.ParsePagination(c)
 
-	log.Printf("DEBUG GetAllGames: limit=%d, offset=%d", params.Limit, params.Offset)
+	filter, msg := parseCatalogFilter(c)
+	if msg != "" {
+		return myResponse.BadRequest(c, msg)
+	}
+
+	games, total, err := h.gameService.GetAll(filter, params.Limit, params.Offset)
+	if err != nil {
+		logrus.WithError(err).Error("GetAllGames failed")
+		return myResponse.InternalServerError(c, "Failed to retrieve games")
+	}
 
-	games, total, err := h.gameService.GetAll(params.Limit, params.Offset)
+	logrus.WithFields(logrus.Fields{
+		"limit":  params.Limit,
+		"offset": params.Offset,
+		"found":  len(games),
+		"total":  total,
+	}).Debug("GetAllGames")
+
+	data, err := utils.SelectFields(dto.ToGameResponseList(games, h.placeholderImageURL), utils.ParseFields(c.QueryParam("fields")))
 	if err != nil {
-		log.Printf("ERROR GetAllGames: %v", err)
 		return myResponse.InternalServerError(c, "Failed to retrieve games")
 	}
 
-	log.Printf("DEBUG GetAllGames: found %d games, total=%d", len(games), total)
+	meta := utils.CreateMeta(params, total)
+	return myResponse.Paginated(c, "Games retrieved successfully", data, meta)
+}
+
+// parseCatalogFilter reads the public catalog filters, returning a message for the first invalid one
+func parseCatalogFilter(c echo.Context) (repository.GameFilter, string) {
+	var filter repository.GameFilter
+
+	if v := c.QueryParam("category_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || id == 0 {
+			return filter, "Invalid category_id"
+		}
+		filter.CategoryID = uint(id)
+	}
+
+	filter.Platform = strings.TrimSpace(c.QueryParam("platform"))
+
+	var ok bool
+	if filter.MinPrice, ok = queryPrice(c, "min_price"); !ok {
+		return filter, "Invalid min_price"
+	}
+	if filter.MaxPrice, ok = queryPrice(c, "max_price"); !ok {
+		return filter, "Invalid max_price"
+	}
+	if filter.MaxPrice > 0 && filter.MinPrice > filter.MaxPrice {
+		return filter, "min_price cannot be greater than max_price"
+	}
+
+	if v := c.QueryParam("condition"); v != "" {
+		switch condition := model.GameCondition(strings.ToLower(v)); condition {
+		case model.ConditionExcellent, model.ConditionGood, model.ConditionFair:
+			filter.Condition = condition
+		default:
+			return filter, "Invalid condition (use excellent, good or fair)"
+		}
+	}
+
+	return filter, ""
+}
+
+// queryPrice parses an optional non-negative price; unset returns 0
+func queryPrice(c echo.Context, key string) (float64, bool) {
+	v := c.QueryParam(key)
+	if v == "" {
+		return 0, true
+	}
+	price, err := strconv.ParseFloat(v, 64)
+	if err != nil || price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return 0, false
+	}
+	return price, true
+}
+
+// GetAllGamesAdmin godoc
+// @Summary Get all games (super admin)
+// @Description Get every game including inactive ones, optionally only those created by one admin (Super admin only)
+// @Tags Admin - Games
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param admin_id query int false "Only games created by this admin"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} map[string]interface{} "Games retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid admin_id"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/games [get]
+func (h *GameHandler) GetAllGamesAdmin(c echo.Context) error {
+	params := utils.ParsePagination(c)
+	role := echomw.CurrentRole(c)
+
+	adminID := myRequest.QueryInt(c, "admin_id", 0)
+	if adminID < 0 {
+		return myResponse.BadRequest(c, "Invalid admin_id")
+	}
+
+	filter := repository.GameFilter{AdminID: uint(adminID)}
+	games, total, err := h.gameService.GetAllForAdmin(model.UserRole(role), filter, params.Limit, params.Offset)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
 
 	meta := utils.CreateMeta(params, total)
-	return myResponse.Paginated(c, "Games retrieved successfully", games, meta)
+	return myResponse.Paginated(c, "Games retrieved successfully", dto.ToGameResponseList(games, h.placeholderImageURL), meta)
 }
 
 // GetGameDetail godoc
 // @Summary Get game detail
-// @Description Get detailed information about a specific game
+// @Description Get detailed information about a specific game, including average_rating (one decimal) and review_count. Pass start and end to also check availability for those dates.
 // @Tags Games
 // @Accept json
 // @Produce json
 // @Param id path int true "Game ID"
-// @Success 200 {object} model.Game "Game retrieved successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid game ID"
+// @Param start query string false "Intended start date (YYYY-MM-DD)"
+// @Param end query string false "Intended end date (YYYY-MM-DD)"
+// @Param fields query string false "Comma separated fields to return, e.g. id,name,rental_price_per_day"
+// @Success 200 {object} dto.GameDetailResponse "Game retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid game ID or dates"
 // @Failure 404 {object} map[string]interface{} "Game not found"
 // @Router /games/{id} [get]
 func (h *GameHandler) GetGameDetail(c echo.Context) error {
@@ -70,12 +190,116 @@ func (h *GameHandler) GetGameDetail(c echo.Context) error {
 		return myResponse.BadRequest(c, "Invalid game ID")
 	}
 
+	if !hasAvailabilityRange(c) {
+		return myResponse.BadRequest(c, "Both start and end are required to check availability")
+	}
+
 	game, err := h.gameService.GetByID(gameID) // Fix: GetByID bukan GetGameDetail
 	if err != nil {
 		return myResponse.NotFound(c, "Game not found")
 	}
 
-	return myResponse.Success(c, "Game retrieved successfully", game)
+	return h.respondGameDetail(c, game)
+}
+
+// GetGameBySlug godoc
+// @Summary Get game detail by slug
+// @Description Same as GET /games/{id}, addressed by the game's URL slug. Slugs a game had before being renamed answer with a 301 to the current one.
+// @Tags Games
+// @Accept json
+// @Produce json
+// @Param slug path string true "Game slug"
+// @Param start query string false "Intended start date (YYYY-MM-DD)"
+// @Param end query string false "Intended end date (YYYY-MM-DD)"
+// @Param fields query string false "Comma separated fields to return, e.g. id,name,rental_price_per_day"
+// @Success 200 {object} dto.GameDetailResponse "Game retrieved successfully"
+// @Success 301 "Old slug; Location holds the current one"
+// @Failure 400 {object} map[string]interface{} "Invalid dates"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Router /games/slug/{slug} [get]
+func (h *GameHandler) GetGameBySlug(c echo.Context) error {
+	slug := strings.ToLower(strings.TrimSpace(c.Param("slug")))
+	if slug == "" {
+		return myResponse.BadRequest(c, "Invalid game slug")
+	}
+
+	if !hasAvailabilityRange(c) {
+		return myResponse.BadRequest(c, "Both start and end are required to check availability")
+	}
+
+	game, err := h.gameService.GetBySlug(slug)
+	if err != nil {
+		return myResponse.NotFound(c, "Game not found")
+	}
+
+	if game.Slug != slug {
+		target := "/games/slug/" + url.PathEscape(game.Slug)
+		if query := c.QueryString(); query != "" {
+			target += "?" + query
+		}
+		return c.Redirect(http.StatusMovedPermanently, target)
+	}
+
+	return h.respondGameDetail(c, game)
+}
+
+// hasAvailabilityRange reports whether start and end are either both set or both absent
+func hasAvailabilityRange(c echo.Context) bool {
+	return (c.QueryParam("start") == "") == (c.QueryParam("end") == "")
+}
+
+// respondGameDetail writes game with its rating stats, plus availability when start and end are set
+func (h *GameHandler) respondGameDetail(c echo.Context, game *model.Game) error {
+	startParam := c.QueryParam("start")
+	endParam := c.QueryParam("end")
+
+	avgRating, reviewCount, err := h.reviewService.GetGameRatingStats(game.ID)
+	if err != nil {
+		return myResponse.InternalServerError(c, "Failed to retrieve rating")
+	}
+
+	response := dto.GameDetailResponse{
+		GameResponse:  dto.ToGameResponse(game, h.placeholderImageURL),
+		AverageRating: avgRating,
+		ReviewCount:   reviewCount,
+	}
+
+	if startParam != "" {
+		startDate, err := time.Parse("2006-01-02", startParam)
+		if err != nil {
+			return myResponse.BadRequest(c, "Invalid start format (use YYYY-MM-DD)")
+		}
+		endDate, err := time.Parse("2006-01-02", endParam)
+		if err != nil {
+			return myResponse.BadRequest(c, "Invalid end format (use YYYY-MM-DD)")
+		}
+		if startDate.After(endDate) {
+			return myResponse.BadRequest(c, "start must not be after end")
+		}
+
+		available, err := h.gameService.CheckAvailabilityForRange(game.ID, startDate, endDate)
+		if err != nil {
+			return myResponse.InternalServerError(c, "Failed to check availability")
+		}
+
+		response.Availability = &dto.GameAvailability{
+			StartDate: startParam,
+			EndDate:   endParam,
+			Available: available && game.IsActive,
+		}
+	}
+
+	// Availability was asked for explicitly through the dates, so keep it under any field selection
+	fields := utils.ParseFields(c.QueryParam("fields"))
+	if fields != nil && response.Availability != nil {
+		fields["availability"] = true
+	}
+	data, err := utils.SelectFields(response, fields)
+	if err != nil {
+		return myResponse.InternalServerError(c, "Failed to retrieve game")
+	}
+
+	return myResponse.Success(c, "Game retrieved successfully", data)
 }
 
 // SearchGames godoc
@@ -98,13 +322,13 @@ func (h *GameHandler) SearchGames(c echo.Context) error {
 
 	params := utils.ParsePagination(c)
 
-	games, err := h.gameService.Search(query, params.Limit, params.Offset)
+	games, total, err := h.gameService.Search(query, params.Limit, params.Offset)
 	if err != nil {
 		return myResponse.InternalServerError(c, "Failed to search games")
 	}
 
-	meta := utils.CreateMeta(params, int64(len(games)))
-	return myResponse.Paginated(c, "Games search results", games, meta)
+	meta := utils.CreateMeta(params, total)
+	return myResponse.Paginated(c, "Games search results", dto.ToGameResponseList(games, h.placeholderImageURL), meta)
 }
 
 // CreateGame godoc
@@ -140,15 +364,52 @@ func (h *GameHandler) CreateGame(c echo.Context) error {
 		Stock:             req.Stock,
 		RentalPricePerDay: req.RentalPricePerDay,
 		SecurityDeposit:   req.SecurityDeposit,
+		LateFeePerDay:     req.LateFeePerDay,
 		Condition:         model.GameCondition(req.Condition),
+		Images:            req.Images,
+		RentalTerms:       utils.PtrOrNil(strings.TrimSpace(req.RentalTerms)),
+		DepositMode:       model.DepositMode(req.DepositMode),
 	}
 
 	err := h.gameService.Create(adminID, model.UserRole(role), gameData)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
-	return myResponse.Created(c, "Game created successfully", gameData)
+	return myResponse.Created(c, "Game created successfully", dto.ToGameResponse(gameData, h.placeholderImageURL))
+}
+
+// BulkSetGameStatus godoc
+// @Summary Bulk activate/deactivate games
+// @Description Show or hide many games in the catalog at once (Admin only). Existing bookings are not affected.
+// @Tags Admin - Games
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.BulkGameStatusRequest true "Game IDs and target status"
+// @Success 200 {array} dto.BulkGameStatusResult "Per-game results"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/games/bulk-status [post]
+func (h *GameHandler) BulkSetGameStatus(c echo.Context) error {
+	var req dto.BulkGameStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+
+	results, err := h.gameService.BulkSetActive(adminID, model.UserRole(role), req.GameIDs, *req.IsActive)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Game statuses updated", results)
 }
 
 // UpdateGame godoc
@@ -208,19 +469,31 @@ func (h *GameHandler) UpdateGame(c echo.Context) error {
 	if req.SecurityDeposit > 0 {
 		game.SecurityDeposit = req.SecurityDeposit
 	}
+	if req.LateFeePerDay != nil {
+		game.LateFeePerDay = *req.LateFeePerDay
+	}
 	if req.Condition != "" {
 		game.Condition = model.GameCondition(req.Condition)
 	}
+	if req.Images != nil {
+		game.Images = req.Images
+	}
+	if req.RentalTerms != nil {
+		game.RentalTerms = utils.PtrOrNil(strings.TrimSpace(*req.RentalTerms))
+	}
+	if req.DepositMode != nil {
+		game.DepositMode = model.DepositMode(*req.DepositMode)
+	}
 
 	adminID := echomw.CurrentUserID(c)
 	role := echomw.CurrentRole(c)
 
 	err = h.gameService.Update(adminID, model.UserRole(role), gameID, game)
 	if err != nil {
-		return myResponse.BadRequest(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
-	return myResponse.Success(c, "Game updated successfully", game)
+	return myResponse.Success(c, "Game updated successfully", dto.ToGameResponse(game, h.placeholderImageURL))
 }
 
 // DeleteGame godoc
@@ -245,8 +518,99 @@ func (h *GameHandler) DeleteGame(c echo.Context) error {
 	role := echomw.CurrentRole(c)
 	err := h.gameService.Delete(model.UserRole(role), gameID)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Success(c, "Game deleted successfully", nil)
 }
+
+// UploadGameImage godoc
+// @Summary Upload game image
+// @Description Upload a jpeg, png or webp image (max 10MB) and append its URL to the game's images (Admin only, own games unless super_admin)
+// @Tags Admin - Games
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Game ID"
+// @Param image formData file true "Image file"
+// @Success 201 {object} dto.GameResponse "Image uploaded successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid game ID, missing file or image limit reached"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 413 {object} map[string]interface{} "Image too large"
+// @Failure 415 {object} map[string]interface{} "Unsupported image type"
+// @Router /admin/games/{id}/images [post]
+func (h *GameHandler) UploadGameImage(c echo.Context) error {
+	gameID := myRequest.PathParamUint(c, "id")
+	if gameID == 0 {
+		return myResponse.BadRequest(c, "Invalid game ID")
+	}
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		return myResponse.BadRequest(c, "Invalid input: image file is required")
+	}
+	if fileHeader.Size > storage.MaxFileSize {
+		return utils.MapServiceError(c, service.ErrGameImageTooLarge)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so the service can still reject a lying Size header
+	data, err := io.ReadAll(io.LimitReader(file, storage.MaxFileSize+1))
+	if err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+
+	game, err := h.gameService.AddImage(c.Request().Context(), adminID, model.UserRole(role), gameID, data)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Created(c, "Image uploaded successfully", dto.ToGameResponse(game, h.placeholderImageURL))
+}
+
+// DeleteGameImage godoc
+// @Summary Delete game image
+// @Description Remove an image URL from the game and delete the stored file (Admin only, own games unless super_admin)
+// @Tags Admin - Games
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Game ID"
+// @Param url query string true "Image URL to remove"
+// @Success 200 {object} dto.GameResponse "Image deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid game ID or missing url"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Game or image not found"
+// @Router /admin/games/{id}/images [delete]
+func (h *GameHandler) DeleteGameImage(c echo.Context) error {
+	gameID := myRequest.PathParamUint(c, "id")
+	if gameID == 0 {
+		return myResponse.BadRequest(c, "Invalid game ID")
+	}
+
+	imageURL := strings.TrimSpace(c.QueryParam("url"))
+	if imageURL == "" {
+		return myResponse.BadRequest(c, "url is required")
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+
+	game, err := h.gameService.RemoveImage(c.Request().Context(), adminID, model.UserRole(role), gameID, imageURL)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Image deleted successfully", dto.ToGameResponse(game, h.placeholderImageURL))
+}
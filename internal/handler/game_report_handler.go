@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	echomw "github.com/yoockh/go-api-utils/pkg-echo/middleware"
+	myRequest "github.com/yoockh/go-api-utils/pkg-echo/request"
+	myResponse "github.com/yoockh/go-api-utils/pkg-echo/response"
+	"github.com/yoockh/go-game-rental-api/internal/dto"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/service"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+)
+
+type GameReportHandler struct {
+	reportService service.GameReportService
+	validate      *validator.Validate
+}
+
+func NewGameReportHandler(reportService service.GameReportService) *GameReportHandler {
+	return &GameReportHandler{
+		reportService: reportService,
+		validate:      utils.GetValidator(),
+	}
+}
+
+// ReportGame godoc
+// @Summary Report a game listing
+// @Description Report wrong or offensive listing content. Each user can report a game once
+// @Tags Games
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Game ID"
+// @Param request body dto.ReportGameRequest true "Why the listing is a problem"
+// @Success 201 {object} map[string]interface{} "Report submitted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Game not found"
+// @Failure 409 {object} map[string]interface{} "Already reported"
+// @Router /games/{id}/report [post]
+func (h *GameReportHandler) ReportGame(c echo.Context) error {
+	gameID := myRequest.PathParamUint(c, "id")
+	if gameID == 0 {
+		return myResponse.BadRequest(c, "Invalid game ID")
+	}
+
+	var req dto.ReportGameRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	userID := echomw.CurrentUserID(c)
+	if err := h.reportService.ReportGame(userID, gameID, req.Reason); err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Created(c, "Report submitted successfully", nil)
+}
+
+// GetReportSummaries godoc
+// @Summary Get reported games
+// @Description Get games with customer reports and their counts, most reported first. flagged is true once a game reaches GAME_REPORT_FLAG_THRESHOLD (Admin only)
+// @Tags Admin - Games
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param flagged query bool false "Only games at or above the flag threshold"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {array} repository.GameReportSummary "Game reports retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/game-reports [get]
+func (h *GameReportHandler) GetReportSummaries(c echo.Context) error {
+	role := echomw.CurrentRole(c)
+	params := utils.ParsePagination(c)
+	flaggedOnly := c.QueryParam("flagged") == "true"
+
+	summaries, total, err := h.reportService.GetSummaries(model.UserRole(role), flaggedOnly, params.Limit, params.Offset)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	meta := utils.CreateMeta(params, total)
+	return myResponse.Paginated(c, "Game reports retrieved successfully", summaries, meta)
+}
+
+// GetGameReports godoc
+// @Summary Get reports for a game
+// @Description Get the individual reports filed against one game, newest first (Admin only)
+// @Tags Admin - Games
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Game ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {array} model.GameReport "Game reports retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid game ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/game-reports/{id} [get]
+func (h *GameReportHandler) GetGameReports(c echo.Context) error {
+	gameID := myRequest.PathParamUint(c, "id")
+	if gameID == 0 {
+		return myResponse.BadRequest(c, "Invalid game ID")
+	}
+
+	role := echomw.CurrentRole(c)
+	params := utils.ParsePagination(c)
+
+	reports, total, err := h.reportService.GetGameReports(model.UserRole(role), gameID, params.Limit, params.Offset)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	meta := utils.CreateMeta(params, total)
+	return myResponse.Paginated(c, "Game reports retrieved successfully", reports, meta)
+}
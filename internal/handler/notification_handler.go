@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	echomw "github.com/yoockh/go-api-utils/pkg-echo/middleware"
+	myRequest "github.com/yoockh/go-api-utils/pkg-echo/request"
+	myResponse "github.com/yoockh/go-api-utils/pkg-echo/response"
+	"github.com/yoockh/go-game-rental-api/internal/dto"
+	"github.com/yoockh/go-game-rental-api/internal/service"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+)
+
+type NotificationHandler struct {
+	notificationService service.NotificationService
+}
+
+func NewNotificationHandler(notificationService service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// GetMyNotifications godoc
+// @Summary Get my notifications
+// @Description Get the current user's in-app notifications, newest first, with the unread count in meta
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} map[string]interface{} "Notifications retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /users/me/notifications [get]
+func (h *NotificationHandler) GetMyNotifications(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+	params := utils.ParsePagination(c)
+
+	notifications, total, unread, err := h.notificationService.GetMyNotifications(userID, params.Limit, params.Offset)
+	if err != nil {
+		return myResponse.InternalServerError(c, "Failed to retrieve notifications")
+	}
+
+	meta := dto.NotificationListMeta{
+		PaginationMeta: utils.CreateMeta(params, total),
+		UnreadCount:    unread,
+	}
+	return myResponse.Paginated(c, "Notifications retrieved successfully", notifications, meta)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark notification as read
+// @Description Mark one of the current user's notifications as read
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 {object} map[string]interface{} "Notification marked as read"
+// @Failure 400 {object} map[string]interface{} "Invalid notification ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Notification not found"
+// @Router /users/me/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c echo.Context) error {
+	notificationID := myRequest.PathParamUint(c, "id")
+	if notificationID == 0 {
+		return myResponse.BadRequest(c, "Invalid notification ID")
+	}
+
+	userID := echomw.CurrentUserID(c)
+	if err := h.notificationService.MarkAsRead(userID, notificationID); err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Notification marked as read", nil)
+}
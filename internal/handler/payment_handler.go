@@ -1,13 +1,23 @@
 package handler
 
 import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 	echomw "github.com/yoockh/go-api-utils/pkg-echo/middleware"
 	myRequest "github.com/yoockh/go-api-utils/pkg-echo/request"
 	myResponse "github.com/yoockh/go-api-utils/pkg-echo/response"
 	"github.com/yoockh/go-game-rental-api/internal/dto"
 	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
 	"github.com/yoockh/go-game-rental-api/internal/service"
 	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
@@ -26,7 +36,7 @@ func NewPaymentHandler(paymentService service.PaymentService) *PaymentHandler {
 
 // CreatePayment godoc
 // @Summary Create payment
-// @Description Create payment for a booking
+// @Description Create payment for a booking. Midtrans card payments, including deposit holds, need the card_token from Midtrans.js
 // @Tags Payments
 // @Accept json
 // @Produce json
@@ -49,9 +59,9 @@ func (h *PaymentHandler) CreatePayment(c echo.Context) error {
 		return myResponse.BadRequest(c, "Validation error: "+err.Error())
 	}
 
-	payment, err := h.paymentService.CreatePayment(userID, bookingID, req.Provider, req.PaymentType)
+	payment, err := h.paymentService.CreatePayment(c.Request().Context(), userID, bookingID, req.Provider, req.PaymentType, req.CardToken)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error()) // Return 403 jika service error
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Created(c, "Payment created successfully", payment)
@@ -83,12 +93,48 @@ func (h *PaymentHandler) GetPaymentByBooking(c echo.Context) error {
 
 	payment, err := h.paymentService.GetPaymentByBooking(userID, bookingID)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Success(c, "Payment retrieved successfully", payment)
 }
 
+// GetBookingByPayment godoc
+// @Summary Get booking by payment
+// @Description Get the booking a payment belongs to, with the game, payment and deposit status, e.g. when starting from a receipt
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Payment ID"
+// @Success 200 {object} dto.BookingDetailResponse "Booking retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid payment ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Not your payment"
+// @Failure 404 {object} map[string]interface{} "Payment or booking not found"
+// @Router /payments/{id}/booking [get]
+func (h *PaymentHandler) GetBookingByPayment(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+	if userID == 0 {
+		return myResponse.Unauthorized(c, "Unauthorized")
+	}
+
+	paymentID := myRequest.PathParamUint(c, "id")
+	if paymentID == 0 {
+		return myResponse.BadRequest(c, "Invalid payment ID")
+	}
+
+	booking, err := h.paymentService.GetBookingByPayment(userID, paymentID)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Booking retrieved successfully", dto.BookingDetailResponse{
+		Booking: booking,
+		Deposit: service.SummarizeDeposit(booking),
+	})
+}
+
 // GetPaymentDetail godoc
 // @Summary Get payment detail
 // @Description Get detailed payment information (Admin only)
@@ -111,32 +157,84 @@ func (h *PaymentHandler) GetPaymentDetail(c echo.Context) error {
 	role := echomw.CurrentRole(c)
 	payment, err := h.paymentService.GetPaymentDetail(model.UserRole(role), paymentID)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Success(c, "Payment retrieved successfully", payment)
 }
 
+// SearchPaymentByProviderID godoc
+// @Summary Search payment by provider ID
+// @Description Find a payment with its booking, user and game from a gateway transaction ID (Admin only)
+// @Tags Admin - Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider_id query string true "Provider payment ID (Midtrans transaction ID or Stripe PaymentIntent ID)"
+// @Success 200 {object} model.Payment "Payment retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Missing provider_id"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Payment not found"
+// @Router /admin/payments/search [get]
+func (h *PaymentHandler) SearchPaymentByProviderID(c echo.Context) error {
+	providerID := strings.TrimSpace(c.QueryParam("provider_id"))
+	if providerID == "" {
+		return myResponse.BadRequest(c, "provider_id is required")
+	}
+
+	role := echomw.CurrentRole(c)
+	payment, err := h.paymentService.GetPaymentByProviderID(model.UserRole(role), providerID)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Payment retrieved successfully", payment)
+}
+
+// maxWebhookBodyBytes caps webhook bodies; gateway notifications are a few KB
+const maxWebhookBodyBytes = 1 << 20
+
 // PaymentWebhook godoc
-// @Summary Payment webhook
-// @Description Receive payment status updates from payment provider
+// @Summary Midtrans payment webhook
+// @Description Receive payment status updates from Midtrans; the notification must carry a valid signature_key
 // @Tags Webhooks
 // @Accept json
 // @Produce json
 // @Param request body dto.PaymentWebhookRequest true "Webhook payload"
 // @Success 200 {object} map[string]interface{} "Webhook processed successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid webhook payload"
+// @Failure 401 {object} map[string]interface{} "Invalid signature"
 // @Router /webhooks/payments [post]
 func (h *PaymentHandler) PaymentWebhook(c echo.Context) error {
-	var webhookData map[string]interface{}
-	if err := c.Bind(&webhookData); err != nil {
+	return h.processWebhook(c, model.ProviderMidtrans)
+}
+
+// StripeWebhook godoc
+// @Summary Stripe payment webhook
+// @Description Receive payment_intent.* events from Stripe, signed with STRIPE_WEBHOOK_SECRET
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param Stripe-Signature header string true "Stripe's signature of the raw body"
+// @Success 200 {object} map[string]interface{} "Webhook processed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid webhook payload"
+// @Failure 401 {object} map[string]interface{} "Invalid signature"
+// @Router /webhooks/stripe [post]
+func (h *PaymentHandler) StripeWebhook(c echo.Context) error {
+	return h.processWebhook(c, model.ProviderStripe)
+}
+
+// processWebhook hands the raw body to the service; the route, not the payload, names the provider.
+// Stripe signs the exact bytes it sent, so the body is read raw rather than bound.
+func (h *PaymentHandler) processWebhook(c echo.Context, provider model.PaymentProvider) error {
+	payload, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookBodyBytes))
+	if err != nil {
 		return myResponse.BadRequest(c, "Invalid webhook payload: "+err.Error())
 	}
 
-	// Pass raw data to service (ProcessWebhook expects interface{})
-	err := h.paymentService.ProcessWebhook(webhookData)
+	err = h.paymentService.ProcessWebhook(c.Request().Context(), provider, payload, c.Request().Header.Get("Stripe-Signature"))
 	if err != nil {
-		return myResponse.BadRequest(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Success(c, "Webhook processed successfully", nil)
@@ -169,6 +267,150 @@ func (h *PaymentHandler) GetAllPayments(c echo.Context) error {
 	return myResponse.Paginated(c, "Payments retrieved successfully", payments, meta)
 }
 
+// GetRevenueReport godoc
+// @Summary Get revenue report
+// @Description Sum paid payments by paid_at per day, week (Monday start) or month, in UTC. from and to are inclusive and at most one year apart; periods without payments are returned with zero revenue (Admin only)
+// @Tags Admin - Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string true "First day (YYYY-MM-DD)"
+// @Param to query string true "Last day (YYYY-MM-DD)"
+// @Param group_by query string false "Bucket size" Enums(day, week, month) default(day)
+// @Success 200 {array} repository.RevenueBucket "Revenue report retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid range or grouping"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/payments/revenue [get]
+func (h *PaymentHandler) GetRevenueReport(c echo.Context) error {
+	fromParam := c.QueryParam("from")
+	toParam := c.QueryParam("to")
+	if fromParam == "" || toParam == "" {
+		return myResponse.BadRequest(c, "Both from and to are required")
+	}
+	from, err := time.Parse("2006-01-02", fromParam)
+	if err != nil {
+		return myResponse.BadRequest(c, "Invalid from format (use YYYY-MM-DD)")
+	}
+	to, err := time.Parse("2006-01-02", toParam)
+	if err != nil {
+		return myResponse.BadRequest(c, "Invalid to format (use YYYY-MM-DD)")
+	}
+
+	groupBy := c.QueryParam("group_by")
+	if groupBy == "" {
+		groupBy = repository.RevenueByDay
+	}
+
+	role := echomw.CurrentRole(c)
+	buckets, err := h.paymentService.GetRevenueReport(model.UserRole(role), from, to, groupBy)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Revenue report retrieved successfully", buckets)
+}
+
+// paymentExportHeader is the first row of GET /admin/payments/export
+var paymentExportHeader = []string{"id", "booking_id", "provider", "amount", "status", "paid_at", "method"}
+
+// ExportPayments godoc
+// @Summary Export payments as CSV
+// @Description Download payments oldest first as CSV (id, booking_id, provider, amount, status, paid_at, method), streamed in batches. from and to filter on created_at and are inclusive (Admin only)
+// @Tags Admin - Payments
+// @Produce text/csv
+// @Security BearerAuth
+// @Param status query string false "Filter by status" Enums(pending, authorized, paid, failed, refunded, expired, voided)
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Success 200 {file} file "CSV file"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Router /admin/payments/export [get]
+func (h *PaymentHandler) ExportPayments(c echo.Context) error {
+	filter := repository.PaymentExportFilter{Status: model.PaymentStatus(c.QueryParam("status"))}
+	if fromParam := c.QueryParam("from"); fromParam != "" {
+		from, err := time.Parse("2006-01-02", fromParam)
+		if err != nil {
+			return myResponse.BadRequest(c, "Invalid from format (use YYYY-MM-DD)")
+		}
+		filter.From = from
+	}
+	if toParam := c.QueryParam("to"); toParam != "" {
+		to, err := time.Parse("2006-01-02", toParam)
+		if err != nil {
+			return myResponse.BadRequest(c, "Invalid to format (use YYYY-MM-DD)")
+		}
+		filter.To = to.AddDate(0, 0, 1) // inclusive
+	}
+
+	// Headers go out with the first batch, so a rejected filter still gets a JSON error
+	w := csv.NewWriter(c.Response().Writer)
+	started := false
+	start := func() error {
+		started = true
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		res.Header().Set(echo.HeaderContentDisposition,
+			fmt.Sprintf(`attachment; filename="payments-%s.csv"`, time.Now().Format("20060102")))
+		res.WriteHeader(http.StatusOK)
+		return w.Write(paymentExportHeader)
+	}
+
+	role := echomw.CurrentRole(c)
+	err := h.paymentService.ExportPayments(model.UserRole(role), filter, func(batch []*model.Payment) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		for _, payment := range batch {
+			if err := w.Write(paymentExportRow(payment)); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+	})
+	if err != nil {
+		if !started {
+			return utils.MapServiceError(c, err)
+		}
+		// The status line is already sent; the client sees a truncated file
+		logrus.WithError(err).Error("Payment export aborted")
+		return nil
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func paymentExportRow(payment *model.Payment) []string {
+	paidAt := ""
+	if payment.PaidAt != nil {
+		paidAt = payment.PaidAt.UTC().Format(time.RFC3339)
+	}
+	method := ""
+	if payment.PaymentMethod != nil {
+		method = *payment.PaymentMethod
+	}
+	return []string{
+		strconv.FormatUint(uint64(payment.ID), 10),
+		strconv.FormatUint(uint64(payment.BookingID), 10),
+		string(payment.Provider),
+		strconv.FormatFloat(payment.Amount, 'f', 2, 64),
+		string(payment.Status),
+		paidAt,
+		method,
+	}
+}
+
 // GetPaymentsByStatus godoc
 // @Summary Get payments by status
 // @Description Get list of payments filtered by status (Admin only)
@@ -176,7 +418,7 @@ func (h *PaymentHandler) GetAllPayments(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param status query string true "Payment status" Enums(pending, completed, failed, refunded)
+// @Param status query string true "Payment status" Enums(pending, paid, failed, refunded, expired)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} map[string]interface{} "Payments retrieved successfully"
@@ -196,3 +438,201 @@ func (h *PaymentHandler) GetPaymentsByStatus(c echo.Context) error {
 	meta := utils.CreateMeta(params, total)
 	return myResponse.Paginated(c, "Payments retrieved successfully", payments, meta)
 }
+
+// MarkPaymentPaid godoc
+// @Summary Mark payment as paid
+// @Description Manually confirm a payment settled out-of-band, e.g. a verified bank transfer (Admin only)
+// @Tags Admin - Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Payment ID"
+// @Param request body dto.MarkPaymentPaidRequest true "Payment method and reason"
+// @Success 200 {object} model.Payment "Payment marked as paid"
+// @Failure 400 {object} map[string]interface{} "Invalid input or payment already paid"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Payment not found"
+// @Router /admin/payments/{id}/mark-paid [post]
+func (h *PaymentHandler) MarkPaymentPaid(c echo.Context) error {
+	paymentID := myRequest.PathParamUint(c, "id")
+	if paymentID == 0 {
+		return myResponse.BadRequest(c, "Invalid payment ID")
+	}
+
+	var req dto.MarkPaymentPaidRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+
+	payment, err := h.paymentService.MarkPaidManually(c.Request().Context(), adminID, model.UserRole(role), paymentID, req.PaymentMethod, req.Note)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Payment marked as paid", payment)
+}
+
+// MarkPaymentFailed godoc
+// @Summary Mark payment as failed
+// @Description Void a stuck pending payment, cancelling its booking and releasing stock (Admin only)
+// @Tags Admin - Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Payment ID"
+// @Param request body dto.MarkPaymentFailedRequest true "Failure reason"
+// @Success 200 {object} model.Payment "Payment marked as failed"
+// @Failure 400 {object} map[string]interface{} "Invalid input or payment not pending"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Payment not found"
+// @Router /admin/payments/{id}/mark-failed [post]
+func (h *PaymentHandler) MarkPaymentFailed(c echo.Context) error {
+	paymentID := myRequest.PathParamUint(c, "id")
+	if paymentID == 0 {
+		return myResponse.BadRequest(c, "Invalid payment ID")
+	}
+
+	var req dto.MarkPaymentFailedRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+
+	payment, err := h.paymentService.MarkFailedManually(adminID, model.UserRole(role), paymentID, req.Reason)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Payment marked as failed", payment)
+}
+
+// RefundPayment godoc
+// @Summary Refund payment
+// @Description Refund all or part of a paid payment through its gateway (Admin only)
+// @Tags Admin - Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Payment ID"
+// @Param request body dto.RefundPaymentRequest true "Refund amount and reason"
+// @Success 200 {object} model.Payment "Payment refunded"
+// @Failure 400 {object} map[string]interface{} "Invalid input or amount"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Payment not found"
+// @Failure 409 {object} map[string]interface{} "Payment not paid"
+// @Router /admin/payments/{id}/refund [post]
+func (h *PaymentHandler) RefundPayment(c echo.Context) error {
+	paymentID := myRequest.PathParamUint(c, "id")
+	if paymentID == 0 {
+		return myResponse.BadRequest(c, "Invalid payment ID")
+	}
+
+	var req dto.RefundPaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+
+	payment, err := h.paymentService.RefundPayment(adminID, model.UserRole(role), paymentID, req.Amount, req.Reason)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Payment refunded", payment)
+}
+
+// CaptureDepositHold godoc
+// @Summary Capture a deposit hold
+// @Description Capture an authorized payment once the game is returned (or overdue): the rental amount, plus the security deposit when keep_deposit is true. The rest of the hold is released (Admin only)
+// @Tags Admin - Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Payment ID"
+// @Param request body dto.CaptureDepositHoldRequest true "Whether to keep the deposit, and why"
+// @Success 200 {object} model.Payment "Deposit hold captured"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Payment not found"
+// @Failure 409 {object} map[string]interface{} "Payment not authorized or booking not returned"
+// @Router /admin/payments/{id}/capture [post]
+func (h *PaymentHandler) CaptureDepositHold(c echo.Context) error {
+	paymentID := myRequest.PathParamUint(c, "id")
+	if paymentID == 0 {
+		return myResponse.BadRequest(c, "Invalid payment ID")
+	}
+
+	var req dto.CaptureDepositHoldRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+
+	payment, err := h.paymentService.CaptureDepositHold(adminID, model.UserRole(role), paymentID, req.KeepDeposit, req.Note)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Deposit hold captured", payment)
+}
+
+// VoidDepositHold godoc
+// @Summary Void a deposit hold
+// @Description Release an authorized payment in full for a booking that was cancelled (Admin only)
+// @Tags Admin - Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Payment ID"
+// @Param request body dto.VoidDepositHoldRequest true "Void reason"
+// @Success 200 {object} model.Payment "Deposit hold voided"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 404 {object} map[string]interface{} "Payment not found"
+// @Failure 409 {object} map[string]interface{} "Payment not authorized or booking not cancelled"
+// @Router /admin/payments/{id}/void [post]
+func (h *PaymentHandler) VoidDepositHold(c echo.Context) error {
+	paymentID := myRequest.PathParamUint(c, "id")
+	if paymentID == 0 {
+		return myResponse.BadRequest(c, "Invalid payment ID")
+	}
+
+	var req dto.VoidDepositHoldRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	adminID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+
+	payment, err := h.paymentService.VoidDepositHold(adminID, model.UserRole(role), paymentID, req.Reason)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Deposit hold voided", payment)
+}
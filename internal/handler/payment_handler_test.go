@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/service"
+)
+
+// exportPaymentService feeds ExportPayments fixed batches and records the filter it got
+type exportPaymentService struct {
+	service.PaymentService
+	batches [][]*model.Payment
+	filter  repository.PaymentExportFilter
+	err     error
+}
+
+func (s *exportPaymentService) ExportPayments(requestorRole model.UserRole, filter repository.PaymentExportFilter, fn func([]*model.Payment) error) error {
+	s.filter = filter
+	if s.err != nil {
+		return s.err
+	}
+	for _, batch := range s.batches {
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportContext(e *echo.Echo, query string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, "/admin/payments/export?"+query, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("role", "admin")
+	return c, rec
+}
+
+// ============= TEST EXPORT PAYMENTS =============
+func TestExportPayments_WritesCSV(t *testing.T) {
+	paidAt := time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)
+	method := "bank_transfer"
+	svc := &exportPaymentService{batches: [][]*model.Payment{
+		{{ID: 1, BookingID: 10, Provider: model.ProviderMidtrans, Amount: 150000, Status: model.PaymentPaid, PaidAt: &paidAt, PaymentMethod: &method}},
+		{{ID: 2, BookingID: 11, Provider: model.ProviderStripe, Amount: 99.5, Status: model.PaymentPending}},
+	}}
+	handler := NewPaymentHandler(svc)
+	c, rec := exportContext(echo.New(), "status=paid&from=2026-03-01&to=2026-03-31")
+
+	if assert.NoError(t, handler.ExportPayments(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+		assert.True(t, strings.HasPrefix(rec.Header().Get(echo.HeaderContentDisposition), `attachment; filename="payments-`))
+
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		if assert.Len(t, lines, 3) {
+			assert.Equal(t, "id,booking_id,provider,amount,status,paid_at,method", lines[0])
+			assert.Equal(t, "1,10,midtrans,150000.00,paid,2026-03-05T14:30:00Z,bank_transfer", lines[1])
+			assert.Equal(t, "2,11,stripe,99.50,pending,,", lines[2])
+		}
+	}
+	assert.Equal(t, model.PaymentPaid, svc.filter.Status)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), svc.filter.From)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), svc.filter.To)
+}
+
+func TestExportPayments_EmptyStillHasHeader(t *testing.T) {
+	handler := NewPaymentHandler(&exportPaymentService{})
+	c, rec := exportContext(echo.New(), "")
+
+	if assert.NoError(t, handler.ExportPayments(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "id,booking_id,provider,amount,status,paid_at,method\n", rec.Body.String())
+	}
+}
+
+func TestExportPayments_ServiceErrorIsJSON(t *testing.T) {
+	handler := NewPaymentHandler(&exportPaymentService{err: service.ErrPaymentStatusInvalid})
+	c, rec := exportContext(echo.New(), "status=unknown")
+
+	if assert.NoError(t, handler.ExportPayments(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Contains(t, rec.Body.String(), "unknown payment status")
+	}
+}
+
+// bookingLookupPaymentService serves GetBookingByPayment from a fixed booking or error
+type bookingLookupPaymentService struct {
+	service.PaymentService
+	booking *model.Booking
+	err     error
+}
+
+func (s *bookingLookupPaymentService) GetBookingByPayment(userID uint, paymentID uint) (*model.Booking, error) {
+	return s.booking, s.err
+}
+
+func bookingByPaymentContext(e *echo.Echo, paymentID string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, "/payments/"+paymentID+"/booking", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(paymentID)
+	c.Set("user_id", uint(1))
+	return c, rec
+}
+
+// ============= TEST GET BOOKING BY PAYMENT =============
+func TestGetBookingByPayment_ReturnsBooking(t *testing.T) {
+	svc := &bookingLookupPaymentService{booking: &model.Booking{ID: 10, UserID: 1, SecurityDeposit: 50000}}
+	handler := NewPaymentHandler(svc)
+	c, rec := bookingByPaymentContext(echo.New(), "5")
+
+	if assert.NoError(t, handler.GetBookingByPayment(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"id":10`)
+		assert.Contains(t, rec.Body.String(), `"deposit"`)
+	}
+}
+
+func TestGetBookingByPayment_NotOwned(t *testing.T) {
+	handler := NewPaymentHandler(&bookingLookupPaymentService{err: service.ErrPaymentBookingNotOwned})
+	c, rec := bookingByPaymentContext(echo.New(), "5")
+
+	if assert.NoError(t, handler.GetBookingByPayment(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestGetBookingByPayment_InvalidID(t *testing.T) {
+	handler := NewPaymentHandler(&bookingLookupPaymentService{})
+	c, rec := bookingByPaymentContext(echo.New(), "abc")
+
+	if assert.NoError(t, handler.GetBookingByPayment(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
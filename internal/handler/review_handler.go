@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	echomw "github.com/yoockh/go-api-utils/pkg-echo/middleware"
@@ -63,12 +66,48 @@ func (h *ReviewHandler) CreateReview(c echo.Context) error {
 
 	err := h.reviewService.CreateReview(userID, bookingID, reviewData)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Created(c, "Review created successfully", nil)
 }
 
+// CanReview godoc
+// @Summary Check if a booking can be reviewed
+// @Description Check upfront whether the current user may review this booking, with the reason when not
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param booking_id path int true "Booking ID"
+// @Success 200 {object} dto.CanReviewResponse "Review eligibility"
+// @Failure 400 {object} map[string]interface{} "Invalid booking ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Booking not owned"
+// @Failure 404 {object} map[string]interface{} "Booking not found"
+// @Router /bookings/{booking_id}/can-review [get]
+func (h *ReviewHandler) CanReview(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+	if userID == 0 {
+		return myResponse.Unauthorized(c, "Unauthorized")
+	}
+
+	bookingID := myRequest.PathParamUint(c, "booking_id")
+	if bookingID == 0 {
+		return myResponse.BadRequest(c, "Invalid booking ID")
+	}
+
+	err := h.reviewService.CanReview(userID, bookingID)
+	switch {
+	case err == nil:
+		return myResponse.Success(c, "Review eligibility checked", dto.CanReviewResponse{CanReview: true})
+	case errors.Is(err, service.ErrReviewBookingNotCompleted), errors.Is(err, service.ErrReviewAlreadyExists):
+		return myResponse.Success(c, "Review eligibility checked", dto.CanReviewResponse{Reason: err.Error()})
+	default:
+		return utils.MapServiceError(c, err)
+	}
+}
+
 // GetGameReviews godoc
 // @Summary Get game reviews
 // @Description Get list of reviews for a specific game
@@ -98,3 +137,140 @@ func (h *ReviewHandler) GetGameReviews(c echo.Context) error {
 	meta := utils.CreateMeta(params, int64(len(reviews)))
 	return myResponse.Paginated(c, "Reviews retrieved successfully", reviews, meta)
 }
+
+// GetMyReviews godoc
+// @Summary Get my reviews
+// @Description Get reviews written by the current user, newest first, each with its game
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {array} dto.UserReviewResponse "Reviews retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /users/me/reviews [get]
+func (h *ReviewHandler) GetMyReviews(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+	params := utils.ParsePagination(c)
+
+	reviews, total, err := h.reviewService.GetUserReviews(userID, params.Limit, params.Offset)
+	if err != nil {
+		return myResponse.InternalServerError(c, "Failed to retrieve reviews")
+	}
+
+	meta := utils.CreateMeta(params, total)
+	return myResponse.Paginated(c, "Reviews retrieved successfully", dto.ToUserReviewResponseList(reviews), meta)
+}
+
+// UpdateReview godoc
+// @Summary Update review
+// @Description Change the rating and comment of your own review, up to 30 days after posting it
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Param request body dto.UpdateReviewRequest true "New rating and comment"
+// @Success 200 {object} map[string]interface{} "Review updated successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Review not owned or too old to edit"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Router /reviews/{id} [put]
+func (h *ReviewHandler) UpdateReview(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+
+	reviewID := myRequest.PathParamUint(c, "id")
+	if reviewID == 0 {
+		return myResponse.BadRequest(c, "Invalid review ID")
+	}
+
+	var req dto.UpdateReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	reviewData := &model.Review{
+		Rating:  req.Rating,
+		Comment: utils.PtrOrNil(req.Comment),
+	}
+	if err := h.reviewService.UpdateReview(userID, reviewID, reviewData); err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Review updated successfully", nil)
+}
+
+// DeleteReview godoc
+// @Summary Delete review
+// @Description Delete your own review
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Success 200 {object} map[string]interface{} "Review deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid review ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Review not owned"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Router /reviews/{id} [delete]
+func (h *ReviewHandler) DeleteReview(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+
+	reviewID := myRequest.PathParamUint(c, "id")
+	if reviewID == 0 {
+		return myResponse.BadRequest(c, "Invalid review ID")
+	}
+
+	if err := h.reviewService.DeleteReview(userID, reviewID); err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Review deleted successfully", nil)
+}
+
+// ReplyToReview godoc
+// @Summary Reply to review
+// @Description Post or replace the public reply on a review of one of your games (Admin only; super_admin on any game)
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Review ID"
+// @Param request body dto.ReplyReviewRequest true "Reply text"
+// @Success 200 {object} map[string]interface{} "Reply saved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Not the game's owner"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Router /reviews/{id}/reply [post]
+func (h *ReviewHandler) ReplyToReview(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+	role := echomw.CurrentRole(c)
+
+	reviewID := myRequest.PathParamUint(c, "id")
+	if reviewID == 0 {
+		return myResponse.BadRequest(c, "Invalid review ID")
+	}
+
+	var req dto.ReplyReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	req.Reply = strings.TrimSpace(req.Reply)
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	if err := h.reviewService.ReplyToReview(userID, model.UserRole(role), reviewID, req.Reply); err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Reply saved successfully", nil)
+}
@@ -1,6 +1,10 @@
 package handler
 
 import (
+	"fmt"
+	"net/url"
+	"strconv"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
@@ -9,19 +13,29 @@ import (
 	myResponse "github.com/yoockh/go-api-utils/pkg-echo/response"
 	"github.com/yoockh/go-game-rental-api/internal/dto"
 	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/repository/email"
 	"github.com/yoockh/go-game-rental-api/internal/service"
 	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
 
 type UserHandler struct {
-	userService service.UserService
-	validate    *validator.Validate
+	userService    service.UserService
+	bookingService service.BookingService
+	emailRepo      email.EmailRepository
+	resetURL       string
+	validate       *validator.Validate
 }
 
-func NewUserHandler(userService service.UserService) *UserHandler {
+// NewUserHandler takes resetURL, the same frontend page as password reset emails, which
+// accounts created by admins use to set their first password
+func NewUserHandler(userService service.UserService, bookingService service.BookingService, emailRepo email.EmailRepository, resetURL string) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		validate:    utils.GetValidator(),
+		userService:    userService,
+		bookingService: bookingService,
+		emailRepo:      emailRepo,
+		resetURL:       resetURL,
+		validate:       utils.GetValidator(),
 	}
 }
 
@@ -32,7 +46,8 @@ func NewUserHandler(userService service.UserService) *UserHandler {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} model.User "Profile retrieved successfully"
+// @Param include query string false "Pass 'stats' to attach booking counts" Enums(stats)
+// @Success 200 {object} dto.UserProfileResponse "Profile retrieved successfully"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Router /users/me [get]
 func (h *UserHandler) GetMyProfile(c echo.Context) error {
@@ -43,7 +58,16 @@ func (h *UserHandler) GetMyProfile(c echo.Context) error {
 		return myResponse.NotFound(c, "User not found")
 	}
 
-	return myResponse.Success(c, "Profile retrieved successfully", user)
+	if c.QueryParam("include") != "stats" {
+		return myResponse.Success(c, "Profile retrieved successfully", user)
+	}
+
+	stats, err := h.bookingService.GetUserBookingStats(userID)
+	if err != nil {
+		return myResponse.InternalServerError(c, "Failed to retrieve booking stats")
+	}
+
+	return myResponse.Success(c, "Profile retrieved successfully", dto.UserProfileResponse{User: user, Stats: stats})
 }
 
 // UpdateMyProfile godoc
@@ -71,7 +95,7 @@ func (h *UserHandler) UpdateMyProfile(c echo.Context) error {
 
 	err := h.userService.UpdateProfile(userID, &req)
 	if err != nil {
-		return myResponse.BadRequest(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	// Get updated profile
@@ -83,6 +107,62 @@ func (h *UserHandler) UpdateMyProfile(c echo.Context) error {
 	return myResponse.Success(c, "Profile updated successfully", user)
 }
 
+// ChangeMyPassword godoc
+// @Summary Change current user password
+// @Description Change the password after confirming the current one and sign out every session. The new password needs 8+ characters with a letter and a digit
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} map[string]interface{} "Password changed successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input or current password incorrect"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 422 {object} map[string]interface{} "New password too weak"
+// @Router /users/me/password [put]
+func (h *UserHandler) ChangeMyPassword(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+
+	var req dto.ChangePasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	if err := h.userService.ChangePassword(userID, req.OldPassword, req.NewPassword); err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	return myResponse.Success(c, "Password changed successfully", nil)
+}
+
+// GetMyPermissions godoc
+// @Summary Get current user permissions
+// @Description Get the current user's role and the capabilities it grants. The role is read from the database, so a role change shows up before the token is refreshed
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.UserPermissionsResponse "Permissions retrieved successfully"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /users/me/permissions [get]
+func (h *UserHandler) GetMyPermissions(c echo.Context) error {
+	userID := echomw.CurrentUserID(c)
+
+	user, err := h.userService.GetProfile(userID)
+	if err != nil {
+		return myResponse.NotFound(c, "User not found")
+	}
+
+	return myResponse.Success(c, "Permissions retrieved successfully", dto.UserPermissionsResponse{
+		Role:        user.Role,
+		Permissions: user.Role.Permissions(),
+	})
+}
+
 // GetAllUsers godoc
 // @Summary Get all users
 // @Description Get list of all users (Admin only)
@@ -92,7 +172,11 @@ func (h *UserHandler) UpdateMyProfile(c echo.Context) error {
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param role query string false "Filter by role" Enums(customer, admin, super_admin)
+// @Param is_active query bool false "Filter by active status"
+// @Param sort query string false "Sort order" Enums(created_desc, created_asc, last_login_desc, last_login_asc, name_asc, name_desc) default(created_desc)
 // @Success 200 {object} map[string]interface{} "Users retrieved successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden"
 // @Router /admin/users [get]
@@ -100,15 +184,91 @@ func (h *UserHandler) GetAllUsers(c echo.Context) error {
 	params := utils.ParsePagination(c)
 	role := echomw.CurrentRole(c) // BALIK PAKAI INI
 
-	users, totalCount, err := h.userService.GetAllUsers(model.UserRole(role), params.Limit, params.Offset)
+	var filter repository.UserFilter
+	if roleParam := c.QueryParam("role"); roleParam != "" {
+		switch model.UserRole(roleParam) {
+		case model.RoleCustomer, model.RoleAdmin, model.RoleSuperAdmin:
+			filter.Role = model.UserRole(roleParam)
+		default:
+			return myResponse.BadRequest(c, "Invalid role filter")
+		}
+	}
+	if activeParam := c.QueryParam("is_active"); activeParam != "" {
+		isActive, err := strconv.ParseBool(activeParam)
+		if err != nil {
+			return myResponse.BadRequest(c, "Invalid is_active filter (use true or false)")
+		}
+		filter.IsActive = &isActive
+	}
+	filter.Sort = repository.UserSortCreatedDesc
+	if sortParam := c.QueryParam("sort"); sortParam != "" {
+		filter.Sort = repository.UserSort(sortParam)
+		if !filter.Sort.IsValid() {
+			return myResponse.BadRequest(c, "Invalid sort (use created_desc, created_asc, last_login_desc, last_login_asc, name_asc or name_desc)")
+		}
+	}
+
+	users, totalCount, err := h.userService.GetAllUsers(model.UserRole(role), filter, params.Limit, params.Offset)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	meta := utils.CreateMeta(params, totalCount)
 	return myResponse.Paginated(c, "Users retrieved successfully", users, meta)
 }
 
+// CreateUser godoc
+// @Summary Create user
+// @Description Create an account with an explicit role and active state and email the user a link to set their password (valid 72 hours). Works when self-registration is disabled. Admins can create customers; only a super admin can create admin or super_admin accounts
+// @Tags Admin - Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateUserRequest true "Account details"
+// @Success 201 {object} model.User "User created successfully"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 409 {object} map[string]interface{} "Email already exists"
+// @Router /admin/users [post]
+func (h *UserHandler) CreateUser(c echo.Context) error {
+	var req dto.CreateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
+	role := echomw.CurrentRole(c)
+	user, token, err := h.userService.CreateUser(model.UserRole(role), &req)
+	if err != nil {
+		return utils.MapServiceError(c, err)
+	}
+
+	// Sent before responding so the admin knows whether the user actually got their link
+	link := fmt.Sprintf("%s?token=%s", h.resetURL, url.QueryEscape(token))
+	subject := "Set up your Game Rental Platform account"
+	htmlContent := fmt.Sprintf(`
+		<h1>Welcome %s!</h1>
+		<p>An administrator created a Game Rental Platform account for you (%s).</p>
+		<p><a href="%s">Set your password</a> to start using it.</p>
+		<p>This link expires in 72 hours.</p>
+	`, user.FullName, user.Email, link)
+	plainText := fmt.Sprintf("Welcome %s! Set your password for %s: %s (expires in 72 hours)", user.FullName, user.Email, link)
+
+	if err := h.emailRepo.SendEmail(c.Request().Context(), user.Email, subject, plainText, htmlContent); err != nil {
+		logrus.WithError(err).WithField("user_id", user.ID).Error("Failed to send set-password email")
+		// Forgot-password skips inactive accounts, so it only helps once this one is active
+		if !user.IsActive {
+			return myResponse.Created(c, "User created, but the set-password email could not be sent. Activate the account, then the user can request a new link with forgot-password.", user)
+		}
+		return myResponse.Created(c, "User created, but the set-password email could not be sent. The user can request a new link with forgot-password.", user)
+	}
+
+	return myResponse.Created(c, "User created successfully. A set-password link was emailed to the user.", user)
+}
+
 // GetUserDetail godoc
 // @Summary Get user detail
 // @Description Get detailed information about a specific user (Admin only)
@@ -132,7 +292,7 @@ func (h *UserHandler) GetUserDetail(c echo.Context) error {
 	role := echomw.CurrentRole(c) // BALIK PAKAI INI
 	user, err := h.userService.GetUserDetail(model.UserRole(role), userID)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	return myResponse.Success(c, "User retrieved successfully", user)
@@ -169,7 +329,7 @@ func (h *UserHandler) UpdateUserRole(c echo.Context) error {
 	role := echomw.CurrentRole(c) // BALIK PAKAI INI
 	err := h.userService.UpdateUserRole(model.UserRole(role), userID, req.Role)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	// Get updated user for response
@@ -181,29 +341,38 @@ func (h *UserHandler) UpdateUserRole(c echo.Context) error {
 	return myResponse.Success(c, "User role updated successfully", user)
 }
 
-// ToggleUserStatus godoc
-// @Summary Toggle user status
-// @Description Ban or unban a user (Admin only)
+// SetUserStatus godoc
+// @Summary Set user status
+// @Description Activate or deactivate a user (Admin only). Idempotent: the requested state is written as-is.
 // @Tags Admin - Users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "User ID"
+// @Param request body dto.UpdateUserStatusRequest true "Desired active state"
 // @Success 200 {object} map[string]interface{} "User status updated successfully"
-// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "Forbidden"
 // @Router /admin/users/{id}/status [patch]
-func (h *UserHandler) ToggleUserStatus(c echo.Context) error {
+func (h *UserHandler) SetUserStatus(c echo.Context) error {
 	userID := myRequest.PathParamUint(c, "id")
 	if userID == 0 {
 		return myResponse.BadRequest(c, "Invalid user ID")
 	}
 
+	var req dto.UpdateUserStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return myResponse.BadRequest(c, "Invalid input: "+err.Error())
+	}
+	if err := h.validate.Struct(&req); err != nil {
+		return myResponse.BadRequest(c, "Validation error: "+err.Error())
+	}
+
 	role := echomw.CurrentRole(c) // BALIK PAKAI INI
-	err := h.userService.ToggleUserStatus(model.UserRole(role), userID)
+	err := h.userService.SetUserStatus(model.UserRole(role), userID, *req.IsActive)
 	if err != nil {
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	// Get updated user for response
@@ -247,7 +416,7 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 	err := h.userService.DeleteUser(currentUserID, model.UserRole(role), userID)
 	if err != nil {
 		logrus.Error("Delete failed:", err)
-		return myResponse.Forbidden(c, err.Error())
+		return utils.MapServiceError(c, err)
 	}
 
 	logrus.Info("User deleted successfully")
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository/email"
+	"github.com/yoockh/go-game-rental-api/internal/service"
+)
+
+func changePasswordContext(e *echo.Echo, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPut, "/users/me/password", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", uint(1))
+	return c, rec
+}
+
+// ============= TEST CHANGE PASSWORD =============
+func TestChangeMyPassword_Success(t *testing.T) {
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService, nil, nil, "")
+	e := echo.New()
+
+	mockUserService.On("ChangePassword", uint(1), "oldpassword1", "newpassword1").Return(nil)
+
+	c, rec := changePasswordContext(e, `{"old_password": "oldpassword1", "new_password": "newpassword1"}`)
+
+	if assert.NoError(t, handler.ChangeMyPassword(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Password changed successfully")
+	}
+	mockUserService.AssertExpectations(t)
+}
+
+func TestChangeMyPassword_WrongCurrentPassword(t *testing.T) {
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService, nil, nil, "")
+	e := echo.New()
+
+	mockUserService.On("ChangePassword", uint(1), "wrongpassword1", "newpassword1").Return(service.ErrCurrentPasswordWrong)
+
+	c, rec := changePasswordContext(e, `{"old_password": "wrongpassword1", "new_password": "newpassword1"}`)
+
+	if assert.NoError(t, handler.ChangeMyPassword(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "current password is incorrect")
+	}
+}
+
+func TestChangeMyPassword_WeakNewPassword(t *testing.T) {
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService, nil, nil, "")
+	e := echo.New()
+
+	mockUserService.On("ChangePassword", uint(1), "oldpassword1", "short").Return(service.ErrPasswordTooWeak)
+
+	c, rec := changePasswordContext(e, `{"old_password": "oldpassword1", "new_password": "short"}`)
+
+	if assert.NoError(t, handler.ChangeMyPassword(c)) {
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "password must be at least 8 characters")
+	}
+}
+
+// ============= TEST PERMISSIONS =============
+func TestGetMyPermissions_Admin(t *testing.T) {
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService, nil, nil, "")
+	e := echo.New()
+
+	mockUserService.On("GetProfile", uint(1)).Return(&model.User{ID: 1, Role: model.RoleAdmin}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/me/permissions", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", uint(1))
+
+	if assert.NoError(t, handler.GetMyPermissions(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"role":"admin"`)
+		assert.Contains(t, rec.Body.String(), `"can_manage_games":true`)
+		assert.Contains(t, rec.Body.String(), `"can_assign_super_admin":false`)
+	}
+	mockUserService.AssertExpectations(t)
+}
+
+func TestGetMyPermissions_CustomerHasNone(t *testing.T) {
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService, nil, nil, "")
+	e := echo.New()
+
+	mockUserService.On("GetProfile", uint(1)).Return(&model.User{ID: 1, Role: model.RoleCustomer}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/me/permissions", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", uint(1))
+
+	if assert.NoError(t, handler.GetMyPermissions(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotRegexp(t, `"can_[a-z_]+":true`, rec.Body.String())
+	}
+}
+
+// ============= TEST ADMIN CREATE USER =============
+func TestCreateUser_EmailsSetPasswordLink(t *testing.T) {
+	mockUserService := new(MockUserService)
+	mockEmailRepo := &email.MockEmailRepository{}
+	handler := NewUserHandler(mockUserService, nil, mockEmailRepo, "http://localhost:3000/reset-password")
+	e := echo.New()
+
+	created := &model.User{ID: 5, Email: "staff@example.com", FullName: "Staff Member", Role: model.RoleAdmin, IsActive: true}
+	mockUserService.On("CreateUser", model.RoleAdmin, mock.AnythingOfType("*dto.CreateUserRequest")).Return(created, "setup-token", nil)
+
+	body := `{"email": "staff@example.com", "full_name": "Staff Member", "role": "admin", "is_active": true}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/users", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("role", "admin")
+
+	if assert.NoError(t, handler.CreateUser(c)) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "setup-token")
+	}
+	if assert.Len(t, mockEmailRepo.SentEmails, 1) {
+		assert.Equal(t, "staff@example.com", mockEmailRepo.SentEmails[0].To)
+		assert.Contains(t, mockEmailRepo.SentEmails[0].PlainText, "http://localhost:3000/reset-password?token=setup-token")
+	}
+	mockUserService.AssertExpectations(t)
+}
+
+func TestCreateUser_RequiresActiveState(t *testing.T) {
+	mockUserService := new(MockUserService)
+	handler := NewUserHandler(mockUserService, nil, &email.MockEmailRepository{}, "")
+	e := echo.New()
+
+	body := `{"email": "staff@example.com", "full_name": "Staff Member", "role": "admin"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/users", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("role", "admin")
+
+	if assert.NoError(t, handler.CreateUser(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+	mockUserService.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type BookingStatus string
 
@@ -8,25 +12,62 @@ const (
 	BookingPending   BookingStatus = "pending"
 	BookingConfirmed BookingStatus = "confirmed"
 	BookingActive    BookingStatus = "active"
+	// An active booking whose end date passed without the game coming back
+	BookingOverdue   BookingStatus = "overdue"
 	BookingCompleted BookingStatus = "completed"
 	BookingCancelled BookingStatus = "cancelled"
 )
 
+// BookingStatuses is the authoritative list and must match the booking_status enum in ddl.sql
+var BookingStatuses = []BookingStatus{BookingPending, BookingConfirmed, BookingActive, BookingOverdue, BookingCompleted, BookingCancelled}
+
+// IsValid reports whether s is one of the known booking statuses
+func (s BookingStatus) IsValid() bool {
+	for _, status := range BookingStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// DepositStatus is where a booking's security deposit stands, derived from its rental payment
+type DepositStatus string
+
+const (
+	DepositNone      DepositStatus = "none" // no deposit, or not paid yet
+	DepositHeld      DepositStatus = "held"
+	DepositRefunded  DepositStatus = "refunded"
+	DepositForfeited DepositStatus = "forfeited"
+)
+
 type Booking struct {
-	ID               uint          `gorm:"primarykey" json:"id"`
-	UserID           uint          `gorm:"not null" json:"user_id"`
-	GameID           uint          `gorm:"not null" json:"game_id"`
-	StartDate        time.Time     `gorm:"type:date;not null" json:"start_date" validate:"required"`
-	EndDate          time.Time     `gorm:"type:date;not null" json:"end_date" validate:"required"`
-	RentalDays       int           `gorm:"not null" json:"rental_days"`
-	DailyPrice       float64       `gorm:"type:decimal(10,2);not null" json:"daily_price"`
-	TotalRentalPrice float64       `gorm:"type:decimal(10,2);not null" json:"total_rental_price"`
-	SecurityDeposit  float64       `gorm:"type:decimal(10,2);default:0" json:"security_deposit"`
-	TotalAmount      float64       `gorm:"type:decimal(10,2);not null" json:"total_amount"`
-	Status           BookingStatus `gorm:"type:booking_status;default:pending" json:"status"`
-	Notes            *string       `json:"notes,omitempty"`
-	CreatedAt        time.Time     `json:"created_at"`
-	UpdatedAt        time.Time     `json:"updated_at"`
+	ID               uint      `gorm:"primarykey" json:"id"`
+	UserID           uint      `gorm:"not null" json:"user_id"`
+	GameID           uint      `gorm:"not null" json:"game_id"`
+	StartDate        time.Time `gorm:"type:date;not null" json:"start_date" validate:"required"`
+	EndDate          time.Time `gorm:"type:date;not null" json:"end_date" validate:"required"`
+	RentalDays       int       `gorm:"not null" json:"rental_days"`
+	DailyPrice       float64   `gorm:"type:decimal(10,2);not null" json:"daily_price"`
+	TotalRentalPrice float64   `gorm:"type:decimal(10,2);not null" json:"total_rental_price"`
+	SecurityDeposit  float64   `gorm:"type:decimal(10,2);default:0" json:"security_deposit"`
+	TotalAmount      float64   `gorm:"type:decimal(10,2);not null" json:"total_amount"`
+	// Added to TotalAmount by extensions and not yet covered by a payment
+	ExtensionAmountDue float64       `gorm:"type:decimal(10,2);default:0" json:"extension_amount_due"`
+	Status             BookingStatus `gorm:"type:booking_status;default:pending" json:"status"`
+	Notes              *string       `json:"notes,omitempty"`
+	// When the customer accepted the game's rental terms; nil if the game had none
+	TermsAcceptedAt *time.Time `json:"terms_accepted_at,omitempty"`
+	// Set when the customer cancels; reason is optional
+	CancellationReason *string    `gorm:"type:text" json:"cancellation_reason,omitempty"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	// Set by ConfirmReturn; LateFee is charged through a separate late_fee payment
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+	LateFee    float64    `gorm:"type:decimal(10,2);default:0" json:"late_fee"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	// Admin soft delete; GORM hides these rows from every normal query
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty" swaggertype:"string"`
 
 	// Relationships
 	User    User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
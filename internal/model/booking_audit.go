@@ -0,0 +1,73 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type BookingAuditAction string
+
+const (
+	AuditStatusUpdated   BookingAuditAction = "status_updated"
+	AuditReturnConfirmed BookingAuditAction = "return_confirmed"
+	AuditMarkedOverdue   BookingAuditAction = "marked_overdue"
+	AuditDeleted         BookingAuditAction = "deleted"
+	AuditRestored        BookingAuditAction = "restored"
+	AuditEmailResent     BookingAuditAction = "email_resent"
+
+	// Admin actions on the booking's payment
+	AuditPaymentMarkedPaid   BookingAuditAction = "payment_marked_paid"
+	AuditPaymentMarkedFailed BookingAuditAction = "payment_marked_failed"
+	AuditPaymentRefunded     BookingAuditAction = "payment_refunded"
+	AuditDepositCaptured     BookingAuditAction = "deposit_captured"
+	AuditDepositVoided       BookingAuditAction = "deposit_voided"
+)
+
+// BookingAudit records one change made to a booking outside the customer's own actions.
+// ActorID is nil for scheduled jobs such as ProcessOverdueBookings.
+type BookingAudit struct {
+	ID        uint               `gorm:"primaryKey" json:"id"`
+	BookingID uint               `gorm:"not null" json:"booking_id"`
+	ActorID   *uint              `json:"actor_id"`
+	Actor     *User              `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+	Action    BookingAuditAction `gorm:"type:varchar(30);not null" json:"action"`
+	Changes   FieldChanges       `gorm:"type:jsonb;not null;default:'{}'" json:"changes"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+func (BookingAudit) TableName() string {
+	return "booking_audits"
+}
+
+// FieldChange is a field's value before and after a change
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// FieldChanges maps a booking field (its JSON name) to how it changed, stored as JSONB
+type FieldChanges map[string]FieldChange
+
+func (c FieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(map[string]FieldChange(c))
+	return string(b), err
+}
+
+func (c *FieldChanges) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("cannot scan %T into FieldChanges", src)
+	}
+}
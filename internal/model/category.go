@@ -10,6 +10,7 @@ type Category struct {
 	Description *string   `json:"description,omitempty"`
 	IsActive    bool      `gorm:"default:true" json:"is_active"`
 	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 
 	// Relationships
 	Games []Game `gorm:"foreignKey:CategoryID" json:"-"`
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+type TokenPurpose string
+
+const (
+	TokenPasswordReset     TokenPurpose = "password_reset"
+	TokenEmailVerification TokenPurpose = "email_verification"
+)
+
+// EmailVerificationToken is a single-use token sent by email. Only the SHA-256
+// hash is stored, so a database leak doesn't expose usable links.
+type EmailVerificationToken struct {
+	ID        uint         `gorm:"primaryKey" json:"id"`
+	UserID    uint         `gorm:"not null" json:"user_id"`
+	TokenHash string       `gorm:"uniqueIndex;not null" json:"-"`
+	Purpose   TokenPurpose `gorm:"not null" json:"purpose"`
+	ExpiresAt time.Time    `gorm:"not null" json:"expires_at"`
+	IsUsed    bool         `gorm:"default:false" json:"is_used"`
+	UsedAt    *time.Time   `json:"used_at,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+func (EmailVerificationToken) TableName() string {
+	return "email_verification_tokens"
+}
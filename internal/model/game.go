@@ -1,6 +1,9 @@
 package model
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -12,6 +15,21 @@ const (
 	ConditionFair      GameCondition = "fair"
 )
 
+// DepositMode decides how the security deposit is collected
+type DepositMode string
+
+const (
+	// DepositCharge charges the deposit together with the rental
+	DepositCharge DepositMode = "charge"
+	// DepositHold only authorizes the booking total; the rental (and any kept deposit) is captured on return
+	DepositHold DepositMode = "hold"
+)
+
+// IsValid reports whether m is a known deposit mode
+func (m DepositMode) IsValid() bool {
+	return m == DepositCharge || m == DepositHold
+}
+
 type Game struct {
 	ID                uint          `gorm:"primaryKey" json:"id"`
 	AdminID           uint          `gorm:"not null" json:"admin_id"`
@@ -19,13 +37,20 @@ type Game struct {
 	CategoryID        uint          `gorm:"not null" json:"category_id"`
 	Category          *Category     `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
 	Name              string        `gorm:"type:varchar(200);not null" json:"name"`
+	Slug              string        `gorm:"type:varchar(220);uniqueIndex;not null" json:"slug"`
 	Description       *string       `gorm:"type:text" json:"description"`
 	Platform          *string       `gorm:"type:varchar(100)" json:"platform"`
 	Stock             int           `gorm:"not null;default:0" json:"stock"`
 	AvailableStock    int           `gorm:"not null;default:0" json:"available_stock"`
 	RentalPricePerDay float64       `gorm:"type:decimal(10,2);not null" json:"rental_price_per_day"`
 	SecurityDeposit   float64       `gorm:"type:decimal(10,2);not null" json:"security_deposit"`
+	LateFeePerDay     float64       `gorm:"type:decimal(10,2);not null;default:0" json:"late_fee_per_day"`
 	Condition         GameCondition `gorm:"type:varchar(20);not null" json:"condition"`
+	Images            StringList    `gorm:"type:jsonb;not null;default:'[]'" json:"images"`
+	// Shown before booking; customers must accept it when set
+	RentalTerms *string `gorm:"type:text" json:"rental_terms"`
+	// Empty uses the DEPOSIT_MODE setting
+	DepositMode DepositMode `gorm:"type:varchar(10)" json:"deposit_mode,omitempty"`
 
 	IsActive  bool      `gorm:"default:true" json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
@@ -35,3 +60,40 @@ type Game struct {
 func (Game) TableName() string {
 	return "games"
 }
+
+// GameSlugRedirect keeps a slug a game used before it was renamed, so shared links still resolve
+type GameSlugRedirect struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Slug      string    `gorm:"type:varchar(220);uniqueIndex;not null" json:"slug"`
+	GameID    uint      `gorm:"not null" json:"game_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (GameSlugRedirect) TableName() string {
+	return "game_slug_redirects"
+}
+
+// StringList is a []string stored as a JSONB array
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	return string(b), err
+}
+
+func (l *StringList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into StringList", src)
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// GameReport is a customer's complaint about a listing itself (wrong info, offensive content).
+// Each user can report a game once.
+type GameReport struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	GameID    uint      `gorm:"not null" json:"game_id"`
+	UserID    uint      `gorm:"not null" json:"user_id"`
+	User      *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Reason    string    `gorm:"type:text;not null" json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (GameReport) TableName() string {
+	return "game_reports"
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+type NotificationType string
+
+const (
+	NotificationBookingCreated   NotificationType = "booking_created"
+	NotificationBookingStatus    NotificationType = "booking_status"
+	NotificationPaymentPending   NotificationType = "payment_pending"
+	NotificationPaymentConfirmed NotificationType = "payment_confirmed"
+	NotificationPaymentExpired   NotificationType = "payment_expired"
+)
+
+type Notification struct {
+	ID        uint             `gorm:"primaryKey" json:"id"`
+	UserID    uint             `gorm:"not null" json:"user_id"`
+	Type      NotificationType `gorm:"not null" json:"type"`
+	Title     string           `gorm:"not null" json:"title"`
+	Message   string           `gorm:"type:text;not null" json:"message"`
+	BookingID *uint            `json:"booking_id,omitempty"`
+	ReadAt    *time.Time       `json:"read_at,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
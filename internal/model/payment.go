@@ -7,10 +7,13 @@ import (
 type PaymentStatus string
 
 const (
-	PaymentPending  PaymentStatus = "pending"
-	PaymentPaid     PaymentStatus = "paid"
-	PaymentFailed   PaymentStatus = "failed"
-	PaymentRefunded PaymentStatus = "refunded"
+	PaymentPending    PaymentStatus = "pending"
+	PaymentAuthorized PaymentStatus = "authorized" // deposit hold: funds reserved, not yet captured
+	PaymentPaid       PaymentStatus = "paid"
+	PaymentFailed     PaymentStatus = "failed"
+	PaymentRefunded   PaymentStatus = "refunded"
+	PaymentExpired    PaymentStatus = "expired"
+	PaymentVoided     PaymentStatus = "voided" // deposit hold released without capturing anything
 )
 
 type PaymentProvider string
@@ -20,6 +23,14 @@ const (
 	ProviderMidtrans PaymentProvider = "midtrans"
 )
 
+// PaymentPurpose separates a booking's rental payment from supplementary charges
+type PaymentPurpose string
+
+const (
+	PaymentPurposeRental  PaymentPurpose = "rental"
+	PaymentPurposeLateFee PaymentPurpose = "late_fee"
+)
+
 type Payment struct {
 	ID                uint            `gorm:"primarykey" json:"id"`
 	BookingID         uint            `gorm:"not null" json:"booking_id"`
@@ -27,11 +38,22 @@ type Payment struct {
 	ProviderPaymentID *string         `json:"provider_payment_id,omitempty"`
 	Amount            float64         `gorm:"type:decimal(12,2);not null" json:"amount"`
 	Status            PaymentStatus   `gorm:"type:payment_status;default:pending" json:"status"`
+	DepositMode       DepositMode     `gorm:"type:varchar(10);not null;default:charge" json:"deposit_mode"`
+	DepositReleased   bool            `gorm:"not null;default:false" json:"deposit_released"` // hold captured without the deposit
+	Purpose           PaymentPurpose  `gorm:"type:varchar(20);not null;default:rental" json:"purpose"`
 	PaymentMethod     *string         `json:"payment_method,omitempty"`
 	PaidAt            *time.Time      `json:"paid_at,omitempty"`
 	FailedAt          *time.Time      `json:"failed_at,omitempty"`
 	FailureReason     *string         `json:"failure_reason,omitempty"`
+	ProcessedBy       *uint           `json:"processed_by,omitempty"`
+	AdminNote         *string         `gorm:"type:text" json:"admin_note,omitempty"`
+	RefundedAt        *time.Time      `json:"refunded_at,omitempty"`
+	RefundAmount      *float64        `gorm:"type:decimal(12,2)" json:"refund_amount,omitempty"`
+	RefundReason      *string         `json:"refund_reason,omitempty"`
+	ExpiresAt         *time.Time      `json:"expires_at,omitempty"`             // nil when the provider's default expiry applies
+	ClientSecret      string          `gorm:"-" json:"client_secret,omitempty"` // Stripe only, returned once on create and never stored
 	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
 
 	// Relationships
 	Booking Booking `gorm:"foreignKey:BookingID" json:"booking,omitempty"`
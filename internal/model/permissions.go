@@ -0,0 +1,44 @@
+package model
+
+// Permissions are the capabilities a role grants. Services check these instead of
+// comparing roles so the mapping lives in one place.
+type Permissions struct {
+	CanManageGames      bool `json:"can_manage_games"`
+	CanManageAnyGame    bool `json:"can_manage_any_game"` // edit or delete games listed by other admins
+	CanViewAllGames     bool `json:"can_view_all_games"`  // admin listing including inactive games
+	CanManageCategories bool `json:"can_manage_categories"`
+	CanManageBookings   bool `json:"can_manage_bookings"`
+	CanManagePayments   bool `json:"can_manage_payments"`
+	CanManageUsers      bool `json:"can_manage_users"`
+	CanAssignSuperAdmin bool `json:"can_assign_super_admin"`
+	CanViewDashboard    bool `json:"can_view_dashboard"`
+}
+
+// Permissions returns the capabilities for r; unknown roles get none
+func (r UserRole) Permissions() Permissions {
+	switch r {
+	case RoleSuperAdmin:
+		return Permissions{
+			CanManageGames:      true,
+			CanManageAnyGame:    true,
+			CanViewAllGames:     true,
+			CanManageCategories: true,
+			CanManageBookings:   true,
+			CanManagePayments:   true,
+			CanManageUsers:      true,
+			CanAssignSuperAdmin: true,
+			CanViewDashboard:    true,
+		}
+	case RoleAdmin:
+		return Permissions{
+			CanManageGames:      true,
+			CanManageCategories: true,
+			CanManageBookings:   true,
+			CanManagePayments:   true,
+			CanManageUsers:      true,
+			CanViewDashboard:    true,
+		}
+	default:
+		return Permissions{}
+	}
+}
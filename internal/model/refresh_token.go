@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// RefreshToken lets a client obtain new access tokens without re-entering credentials.
+// Only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null" json:"user_id"`
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -18,10 +18,13 @@ type User struct {
 	Phone     *string   `json:"phone,omitempty"`
 	Address   *string   `json:"address,omitempty"`
 	Role      UserRole  `gorm:"type:user_role;default:customer" json:"role"`
-	IsActive  bool      `gorm:"default:true" json:"is_active"`
+	IsActive  bool      `json:"is_active"` // no gorm default: it would drop an explicit false on insert
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// Set on each successful login; nil for accounts that never logged in
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+
 	// Relationships
 	Games    []Game    `gorm:"foreignKey:AdminID" json:"-"`
 	Bookings []Booking `gorm:"foreignKey:UserID" json:"-"`
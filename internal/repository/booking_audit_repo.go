@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"gorm.io/gorm"
+)
+
+type BookingAuditRepository interface {
+	Create(entry *model.BookingAudit) error
+	GetByBooking(bookingID uint, limit, offset int) ([]*model.BookingAudit, error)
+	CountByBooking(bookingID uint) (int64, error)
+}
+
+type bookingAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewBookingAuditRepository(db *gorm.DB) BookingAuditRepository {
+	return &bookingAuditRepository{db: db}
+}
+
+func (r *bookingAuditRepository) Create(entry *model.BookingAudit) error {
+	return r.db.Create(entry).Error
+}
+
+// GetByBooking lists a booking's audit entries newest first, with the acting user
+func (r *bookingAuditRepository) GetByBooking(bookingID uint, limit, offset int) ([]*model.BookingAudit, error) {
+	var entries []*model.BookingAudit
+	err := r.db.Preload("Actor").
+		Where("booking_id = ?", bookingID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *bookingAuditRepository) CountByBooking(bookingID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.BookingAudit{}).Where("booking_id = ?", bookingID).Count(&count).Error
+	return count, err
+}
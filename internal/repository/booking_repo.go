@@ -1,10 +1,27 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/yoockh/go-api-utils/pkg-echo/orm"
 	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 	"gorm.io/gorm"
 )
 
+// ErrDuplicateBooking is returned when the user already holds a non-cancelled booking
+// for the same game and dates (idx_bookings_unique_active)
+var ErrDuplicateBooking = utils.NewConflictError("booking_duplicate", "you already have a booking for this game on these dates")
+
+const uniqueViolationCode = "23505"
+
+// rentalPaymentOnly keeps Booking.Payment on the rental payment when a booking also has a late-fee payment
+var rentalPaymentOnly = func(db *gorm.DB) *gorm.DB {
+	return db.Where("purpose = ?", model.PaymentPurposeRental)
+}
+
 type BookingRepository interface {
 	// Basic CRUD
 	Create(booking *model.Booking) error
@@ -12,13 +29,28 @@ type BookingRepository interface {
 	Update(booking *model.Booking) error
 
 	// Query methods
-	GetUserBookings(userID uint, limit, offset int) ([]*model.Booking, error)
+	GetUserBookings(userID uint, status model.BookingStatus, limit, offset int) ([]*model.Booking, error)
 	GetAllBookings(limit, offset int) ([]*model.Booking, error)
-	CountUserBookings(userID uint) (int64, error)
+	Search(query string, limit, offset int) ([]*model.Booking, error)
+	CountSearch(query string) (int64, error)
+	CountUserBookings(userID uint, status model.BookingStatus) (int64, error)
+	CountUserBookingsByStatus(userID uint) (map[model.BookingStatus]int64, error)
 	Count() (int64, error)
+	CountByStatus() (map[model.BookingStatus]int64, error)
+	GetOverdueBookings(before time.Time) ([]*model.Booking, error)
 
 	// Status updates
 	UpdateStatus(bookingID uint, status model.BookingStatus) error
+	TransitionStatus(bookingID uint, from, to model.BookingStatus) (bool, error)
+	MarkCancelled(bookingID uint, reason *string) error
+	ApplyExtension(booking *model.Booking) (bool, error)
+	CompleteReturn(booking *model.Booking, lateFeePayment *model.Payment, audit *model.BookingAudit) (bool, error)
+	CreateWithStockReservation(booking *model.Booking) error
+
+	// Soft delete
+	SoftDelete(bookingID uint) error
+	GetDeletedByID(bookingID uint) (*model.Booking, error)
+	Restore(bookingID uint) error
 }
 
 type bookingRepository struct {
@@ -30,12 +62,28 @@ func NewBookingRepository(db *gorm.DB) BookingRepository {
 }
 
 func (r *bookingRepository) Create(booking *model.Booking) error {
-	return r.db.Create(booking).Error
+	err := r.db.Create(booking).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == "idx_bookings_unique_active" {
+		return ErrDuplicateBooking
+	}
+	return err
+}
+
+// CreateWithStockReservation reserves a copy of the game and inserts the booking in one
+// transaction, so a failed insert never leaks the decremented stock
+func (r *bookingRepository) CreateWithStockReservation(booking *model.Booking) error {
+	return orm.WithTransaction(r.db, func(tx *gorm.DB) error {
+		if err := NewGameRepository(tx).ReserveStock(booking.GameID); err != nil {
+			return err
+		}
+		return NewBookingRepository(tx).Create(booking)
+	})
 }
 
 func (r *bookingRepository) GetByID(id uint) (*model.Booking, error) {
 	var booking model.Booking
-	if err := r.db.Preload("User").Preload("Game").Preload("Payment").First(&booking, id).Error; err != nil {
+	if err := r.db.Preload("User").Preload("Game").Preload("Payment", rentalPaymentOnly).First(&booking, id).Error; err != nil {
 		return nil, err
 	}
 	return &booking, nil
@@ -45,32 +93,187 @@ func (r *bookingRepository) Update(booking *model.Booking) error {
 	return r.db.Save(booking).Error
 }
 
-func (r *bookingRepository) GetUserBookings(userID uint, limit, offset int) ([]*model.Booking, error) {
+// userBookingsScope limits to the user's bookings, optionally to one status (empty means all)
+func (r *bookingRepository) userBookingsScope(userID uint, status model.BookingStatus) *gorm.DB {
+	query := r.db.Model(&model.Booking{}).Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	return query
+}
+
+func (r *bookingRepository) GetUserBookings(userID uint, status model.BookingStatus, limit, offset int) ([]*model.Booking, error) {
 	var bookings []*model.Booking
-	err := r.db.Where("user_id = ?", userID).Preload("Game").Preload("Payment").
+	err := r.userBookingsScope(userID, status).Preload("Game").Preload("Payment", rentalPaymentOnly).
 		Order("created_at DESC").Limit(limit).Offset(offset).Find(&bookings).Error
 	return bookings, err
 }
 
 func (r *bookingRepository) GetAllBookings(limit, offset int) ([]*model.Booking, error) {
 	var bookings []*model.Booking
-	err := r.db.Preload("User").Preload("Game").Preload("Payment").
+	err := r.db.Preload("User").Preload("Game").Preload("Payment", rentalPaymentOnly).
 		Order("created_at DESC").Limit(limit).Offset(offset).Find(&bookings).Error
 	return bookings, err
 }
 
-func (r *bookingRepository) CountUserBookings(userID uint) (int64, error) {
+// searchScope matches the query literally against booking notes, customer email/name and game name
+func (r *bookingRepository) searchScope(query string) *gorm.DB {
+	pattern := "%" + escapeLike(query) + "%"
+	return r.db.Model(&model.Booking{}).
+		Joins("JOIN users ON users.id = bookings.user_id").
+		Joins("JOIN games ON games.id = bookings.game_id").
+		Where("bookings.notes ILIKE ? OR users.email ILIKE ? OR users.full_name ILIKE ? OR games.name ILIKE ?",
+			pattern, pattern, pattern, pattern)
+}
+
+func (r *bookingRepository) Search(query string, limit, offset int) ([]*model.Booking, error) {
+	var bookings []*model.Booking
+	err := r.searchScope(query).
+		Preload("User").Preload("Game").Preload("Payment", rentalPaymentOnly).
+		Order("bookings.created_at DESC").Limit(limit).Offset(offset).Find(&bookings).Error
+	return bookings, err
+}
+
+func (r *bookingRepository) CountSearch(query string) (int64, error) {
+	var count int64
+	err := r.searchScope(query).Count(&count).Error
+	return count, err
+}
+
+func (r *bookingRepository) CountUserBookings(userID uint, status model.BookingStatus) (int64, error) {
 	var count int64
-	err := r.db.Model(&model.Booking{}).Where("user_id = ?", userID).Count(&count).Error
+	err := r.userBookingsScope(userID, status).Count(&count).Error
 	return count, err
 }
 
+func (r *bookingRepository) CountUserBookingsByStatus(userID uint) (map[model.BookingStatus]int64, error) {
+	return countByStatus(r.db.Model(&model.Booking{}).Where("user_id = ?", userID))
+}
+
+// CountByStatus counts every booking grouped by status in a single query
+func (r *bookingRepository) CountByStatus() (map[model.BookingStatus]int64, error) {
+	return countByStatus(r.db.Model(&model.Booking{}))
+}
+
+// countByStatus groups the bookings in scope by status; statuses with no rows are absent
+func countByStatus(scope *gorm.DB) (map[model.BookingStatus]int64, error) {
+	var rows []struct {
+		Status model.BookingStatus
+		Count  int64
+	}
+	err := scope.Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[model.BookingStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
 func (r *bookingRepository) Count() (int64, error) {
 	var count int64
 	err := r.db.Model(&model.Booking{}).Count(&count).Error
 	return count, err
 }
 
+// GetOverdueBookings lists active bookings whose end date is earlier than before
+func (r *bookingRepository) GetOverdueBookings(before time.Time) ([]*model.Booking, error) {
+	var bookings []*model.Booking
+	err := r.db.
+		Where("status = ? AND end_date < ?", model.BookingActive, before).
+		Preload("User").
+		Preload("Game").
+		Order("end_date ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
 func (r *bookingRepository) UpdateStatus(bookingID uint, status model.BookingStatus) error {
 	return r.db.Model(&model.Booking{}).Where("id = ?", bookingID).Update("status", status).Error
 }
+
+// MarkCancelled sets the booking to cancelled and records when and, optionally, why
+func (r *bookingRepository) MarkCancelled(bookingID uint, reason *string) error {
+	return r.db.Model(&model.Booking{}).Where("id = ?", bookingID).Updates(map[string]interface{}{
+		"status":              model.BookingCancelled,
+		"cancellation_reason": reason,
+		"cancelled_at":        time.Now(),
+	}).Error
+}
+
+// ApplyExtension writes the new end date and totals while the booking is still active.
+// It reports whether a row changed, so an extension racing a return is not applied.
+func (r *bookingRepository) ApplyExtension(booking *model.Booking) (bool, error) {
+	result := r.db.Model(&model.Booking{}).
+		Where("id = ? AND status = ?", booking.ID, model.BookingActive).
+		Updates(map[string]interface{}{
+			"end_date":             booking.EndDate,
+			"rental_days":          booking.RentalDays,
+			"total_rental_price":   booking.TotalRentalPrice,
+			"total_amount":         booking.TotalAmount,
+			"extension_amount_due": booking.ExtensionAmountDue,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CompleteReturn marks an active or overdue booking completed with its return time and late
+// fee, puts the copy back in stock and records lateFeePayment (nil when there's no fee) and the
+// audit entry, all in one transaction. It reports whether the booking was still out, so a
+// return is applied once.
+func (r *bookingRepository) CompleteReturn(booking *model.Booking, lateFeePayment *model.Payment, audit *model.BookingAudit) (bool, error) {
+	completed := false
+	err := orm.WithTransaction(r.db, func(tx *gorm.DB) error {
+		result := tx.Model(&model.Booking{}).
+			Where("id = ? AND status IN ?", booking.ID, []model.BookingStatus{model.BookingActive, model.BookingOverdue}).
+			Updates(map[string]interface{}{
+				"status":      model.BookingCompleted,
+				"returned_at": booking.ReturnedAt,
+				"late_fee":    booking.LateFee,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		completed = true
+
+		if err := NewGameRepository(tx).ReleaseStock(booking.GameID); err != nil {
+			return err
+		}
+		if lateFeePayment != nil {
+			if err := tx.Create(lateFeePayment).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(audit).Error
+	})
+	return completed && err == nil, err
+}
+
+func (r *bookingRepository) SoftDelete(bookingID uint) error {
+	return r.db.Delete(&model.Booking{}, bookingID).Error
+}
+
+// GetDeletedByID finds a booking only if it has been soft-deleted
+func (r *bookingRepository) GetDeletedByID(bookingID uint) (*model.Booking, error) {
+	var booking model.Booking
+	err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", bookingID).First(&booking).Error
+	if err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
+
+func (r *bookingRepository) Restore(bookingID uint) error {
+	return r.db.Unscoped().Model(&model.Booking{}).Where("id = ?", bookingID).Update("deleted_at", nil).Error
+}
+
+// TransitionStatus moves the booking to `to` only if it is currently `from`.
+// It reports whether this call made the change, so side effects run exactly once.
+func (r *bookingRepository) TransitionStatus(bookingID uint, from, to model.BookingStatus) (bool, error) {
+	result := r.db.Model(&model.Booking{}).
+		Where("id = ? AND status = ?", bookingID, from).
+		Update("status", to)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newMockDB opens a gorm connection backed by sqlmock and counts every query issued through it
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, *int) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	mock.MatchExpectationsInOrder(false)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	queries := 0
+	err = db.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		queries++
+	})
+	require.NoError(t, err)
+
+	return db, mock, &queries
+}
+
+// ============= TEST GET ALL BOOKINGS QUERY COUNT =============
+func TestGetAllBookings_PreloadsWithBoundedQueries(t *testing.T) {
+	db, mock, queries := newMockDB(t)
+	repo := NewBookingRepository(db)
+
+	now := time.Now()
+	bookingRows := sqlmock.NewRows([]string{"id", "user_id", "game_id", "start_date", "end_date", "status", "created_at"})
+	for i := 1; i <= 10; i++ {
+		bookingRows.AddRow(i, i, i, now, now, "confirmed", now)
+	}
+	userRows := sqlmock.NewRows([]string{"id", "email"})
+	gameRows := sqlmock.NewRows([]string{"id", "name"})
+	paymentRows := sqlmock.NewRows([]string{"id", "booking_id", "status"})
+	for i := 1; i <= 10; i++ {
+		userRows.AddRow(i, "user@example.com")
+		gameRows.AddRow(i, "Game")
+		paymentRows.AddRow(i, i, "paid")
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bookings"`)).WillReturnRows(bookingRows)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users"`)).WillReturnRows(userRows)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "games"`)).WillReturnRows(gameRows)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "payments"`)).WillReturnRows(paymentRows)
+
+	bookings, err := repo.GetAllBookings(10, 0)
+	require.NoError(t, err)
+	assert.Len(t, bookings, 10)
+	for _, b := range bookings {
+		assert.Equal(t, b.UserID, b.User.ID)
+		assert.Equal(t, b.GameID, b.Game.ID)
+		assert.NotNil(t, b.Payment)
+	}
+
+	// One query for the page plus one per preloaded relation, regardless of page size
+	assert.Equal(t, 4, *queries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST CREATE DUPLICATE BOOKING =============
+func TestCreate_DuplicateBookingIsTranslated(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewBookingRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "bookings"`)).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_bookings_unique_active"})
+
+	err := repo.Create(&model.Booking{UserID: 1, GameID: 2, StartDate: time.Now(), EndDate: time.Now()})
+	assert.ErrorIs(t, err, ErrDuplicateBooking)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST CREATE WITH STOCK RESERVATION =============
+func TestCreateWithStockReservation_Commits(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+	repo := NewBookingRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "games" SET "available_stock"=available_stock - 1`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "bookings"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	booking := &model.Booking{UserID: 1, GameID: 2, Status: model.BookingPending}
+	assert.NoError(t, repo.CreateWithStockReservation(booking))
+	assert.Equal(t, uint(7), booking.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateWithStockReservation_RollsBackStockOnInsertFailure(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+	repo := NewBookingRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "games" SET "available_stock"=available_stock - 1`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "bookings"`)).
+		WillReturnError(&pgconn.PgError{Code: uniqueViolationCode, ConstraintName: "idx_bookings_unique_active"})
+	// Rolling back undoes the decrement, leaving available_stock as it was
+	mock.ExpectRollback()
+
+	booking := &model.Booking{UserID: 1, GameID: 2, Status: model.BookingPending}
+	err := repo.CreateWithStockReservation(booking)
+	assert.ErrorIs(t, err, ErrDuplicateBooking)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateWithStockReservation_OutOfStockSkipsInsert(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+	repo := NewBookingRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "games" SET "available_stock"=available_stock - 1`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	booking := &model.Booking{UserID: 1, GameID: 2, Status: model.BookingPending}
+	assert.ErrorIs(t, repo.CreateWithStockReservation(booking), ErrOutOfStock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST COMPLETE RETURN =============
+func TestCompleteReturn_WritesAuditInTransaction(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+	repo := NewBookingRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "bookings" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "games" SET "available_stock"=LEAST(available_stock + 1, stock)`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "booking_audits"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectCommit()
+
+	audit := &model.BookingAudit{BookingID: 1, Action: model.AuditReturnConfirmed}
+	completed, err := repo.CompleteReturn(&model.Booking{ID: 1, GameID: 2}, nil, audit)
+	assert.NoError(t, err)
+	assert.True(t, completed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompleteReturn_RollsBackWhenAuditFails(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+	repo := NewBookingRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "bookings" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "games" SET "available_stock"=LEAST(available_stock + 1, stock)`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "booking_audits"`)).
+		WillReturnError(errors.New("connection reset"))
+	// The return, the restock and the audit entry land together or not at all
+	mock.ExpectRollback()
+
+	audit := &model.BookingAudit{BookingID: 1, Action: model.AuditReturnConfirmed}
+	completed, err := repo.CompleteReturn(&model.Booking{ID: 1, GameID: 2}, nil, audit)
+	assert.Error(t, err)
+	assert.False(t, completed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST BOOKING STATUSES MATCH THE SCHEMA =============
+func TestBookingStatuses_MatchDDLEnum(t *testing.T) {
+	ddl, err := os.ReadFile("../../migrations/ddl.sql")
+	require.NoError(t, err)
+
+	enum := regexp.MustCompile(`CREATE TYPE booking_status AS ENUM \(([^)]*)\)`).FindSubmatch(ddl)
+	require.NotNil(t, enum, "booking_status enum not found in ddl.sql")
+
+	var dbStatuses []model.BookingStatus
+	for _, v := range strings.Split(string(enum[1]), ",") {
+		dbStatuses = append(dbStatuses, model.BookingStatus(strings.Trim(strings.TrimSpace(v), "'")))
+	}
+	assert.Equal(t, dbStatuses, model.BookingStatuses)
+}
+
+// ============= TEST UPDATE STATUS ROUND-TRIPS EVERY STATUS =============
+func TestUpdateStatus_WritesEveryStatus(t *testing.T) {
+	for _, status := range model.BookingStatuses {
+		t.Run(string(status), func(t *testing.T) {
+			db, mock, _ := newMockDB(t)
+			repo := NewBookingRepository(db)
+
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "bookings" SET "status"=$1`)).
+				WithArgs(string(status), sqlmock.AnyArg(), uint(1)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bookings"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, string(status)))
+
+			require.NoError(t, repo.UpdateStatus(1, status))
+
+			var booking model.Booking
+			require.NoError(t, db.First(&booking, 1).Error)
+			assert.Equal(t, status, booking.Status)
+			assert.True(t, booking.Status.IsValid())
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// ============= TEST COUNT BY STATUS =============
+func TestCountByStatus_GroupsInOneQuery(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewBookingRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT status, COUNT(*) AS count FROM "bookings" WHERE "bookings"."deleted_at" IS NULL GROUP BY "status"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+			AddRow("active", 4).
+			AddRow("completed", 9))
+
+	counts, err := repo.CountByStatus()
+	require.NoError(t, err)
+	assert.Equal(t, map[model.BookingStatus]int64{model.BookingActive: 4, model.BookingCompleted: 9}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST SEARCH =============
+func TestCountSearch_WildcardsMatchLiterally(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewBookingRepository(db)
+
+	pattern := `%50\%\_off%`
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "bookings" JOIN users ON users.id = bookings.user_id JOIN games ON games.id = bookings.game_id WHERE`)).
+		WithArgs(pattern, pattern, pattern, pattern).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	_, err := repo.CountSearch("50%_off")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
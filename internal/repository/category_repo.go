@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/yoockh/go-api-utils/pkg-echo/orm"
 	"github.com/yoockh/go-game-rental-api/internal/model"
 	"gorm.io/gorm"
 )
@@ -13,12 +14,14 @@ type CategoryRepository interface {
 	GetActiveCategories() ([]*model.Category, error)
 	Update(category *model.Category) error
 	Delete(id uint) error
+	ReassignGamesAndDelete(categoryID, targetCategoryID uint) error
 
 	// Admin methods
 	UpdateActiveStatus(categoryID uint, isActive bool) error
 
 	// Statistics
 	CountGamesInCategory(categoryID uint) (int64, error)
+	CountGamesByCategory() (map[uint]int64, error)
 }
 
 type categoryRepository struct {
@@ -62,6 +65,17 @@ func (r *categoryRepository) Delete(id uint) error {
 	return r.db.Delete(&model.Category{}, id).Error
 }
 
+// ReassignGamesAndDelete moves every game to the target category and deletes the source in one transaction
+func (r *categoryRepository) ReassignGamesAndDelete(categoryID, targetCategoryID uint) error {
+	return orm.WithTransaction(r.db, func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Game{}).Where("category_id = ?", categoryID).
+			Update("category_id", targetCategoryID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Category{}, categoryID).Error
+	})
+}
+
 func (r *categoryRepository) UpdateActiveStatus(categoryID uint, isActive bool) error {
 	return r.db.Model(&model.Category{}).Where("id = ?", categoryID).Update("is_active", isActive).Error
 }
@@ -71,3 +85,22 @@ func (r *categoryRepository) CountGamesInCategory(categoryID uint) (int64, error
 	err := r.db.Model(&model.Game{}).Where("category_id = ?", categoryID).Count(&count).Error
 	return count, err
 }
+
+// CountGamesByCategory returns game counts for every category in one grouped query
+func (r *categoryRepository) CountGamesByCategory() (map[uint]int64, error) {
+	var rows []struct {
+		CategoryID uint
+		Count      int64
+	}
+	err := r.db.Model(&model.Game{}).Select("category_id, COUNT(*) AS count").
+		Group("category_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
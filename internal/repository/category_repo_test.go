@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// ============= TEST REASSIGN AND DELETE COMMITS =============
+func TestReassignGamesAndDelete_Commits(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+	repo := NewCategoryRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "games" SET "category_id"=$1`)).
+		WithArgs(uint(2), sqlmock.AnyArg(), uint(1)).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "categories"`)).
+		WithArgs(uint(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.ReassignGamesAndDelete(1, 2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST REASSIGN AND DELETE ROLLS BACK =============
+func TestReassignGamesAndDelete_RollsBackOnDeleteFailure(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+	repo := NewCategoryRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "games" SET "category_id"=$1`)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "categories"`)).
+		WillReturnError(errors.New("delete failed"))
+	mock.ExpectRollback()
+
+	assert.Error(t, repo.ReassignGamesAndDelete(1, 2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
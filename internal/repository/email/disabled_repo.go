@@ -0,0 +1,27 @@
+package email
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+)
+
+// DisabledEmailRepository swallows every send, used when EMAILS_ENABLED=false
+type DisabledEmailRepository struct{}
+
+func (d *DisabledEmailRepository) SendEmail(ctx context.Context, to, subject, plainText, htmlContent string) error {
+	logrus.WithFields(logrus.Fields{
+		"to":      utils.RedactEmail(to),
+		"subject": subject,
+	}).Info("Email sending disabled, skipping send")
+	return nil
+}
+
+func (d *DisabledEmailRepository) SendWithTemplate(ctx context.Context, to, templateID string, dynamicData map[string]interface{}) error {
+	logrus.WithFields(logrus.Fields{
+		"to":          utils.RedactEmail(to),
+		"template_id": templateID,
+	}).Info("Email sending disabled, skipping template send")
+	return nil
+}
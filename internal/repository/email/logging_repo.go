@@ -0,0 +1,53 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+)
+
+// LoggingEmailRepository wraps any EmailRepository and logs every send the same way
+type LoggingEmailRepository struct {
+	next EmailRepository
+}
+
+func NewLoggingEmailRepository(next EmailRepository) *LoggingEmailRepository {
+	return &LoggingEmailRepository{next: next}
+}
+
+func (l *LoggingEmailRepository) SendEmail(ctx context.Context, to, subject, plainText, htmlContent string) error {
+	fields := logrus.Fields{"subject": subject}
+	return l.observe(ctx, to, fields, func() error {
+		return l.next.SendEmail(ctx, to, subject, plainText, htmlContent)
+	})
+}
+
+func (l *LoggingEmailRepository) SendWithTemplate(ctx context.Context, to, templateID string, dynamicData map[string]interface{}) error {
+	fields := logrus.Fields{"template_id": templateID}
+	return l.observe(ctx, to, fields, func() error {
+		return l.next.SendWithTemplate(ctx, to, templateID, dynamicData)
+	})
+}
+
+func (l *LoggingEmailRepository) observe(ctx context.Context, to string, fields logrus.Fields, send func() error) error {
+	fields["to"] = utils.RedactEmail(to)
+	if requestID := utils.RequestIDFromContext(ctx); requestID != "" {
+		fields["request_id"] = requestID
+	}
+
+	entry := logrus.WithFields(fields)
+	entry.Debug("Email send attempt")
+
+	start := time.Now()
+	err := send()
+	entry = entry.WithField("latency_ms", time.Since(start).Milliseconds())
+
+	if err != nil {
+		entry.WithError(err).Error("Email send failed")
+		return err
+	}
+	entry.Info("Email sent")
+	return nil
+}
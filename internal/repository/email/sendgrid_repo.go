@@ -10,6 +10,7 @@ import (
 	"github.com/sendgrid/sendgrid-go"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
 	"github.com/sirupsen/logrus"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
 
 var (
@@ -30,15 +31,20 @@ type SendGridRepository struct {
 
 func NewSendGridRepository() (*SendGridRepository, error) {
 	apiKey := os.Getenv("SENDGRID_API_KEY")
-	fromAddr := os.Getenv("SENDGRID_FROM_EMAIL")
+	fromAddr := strings.TrimSpace(os.Getenv("SENDGRID_FROM_EMAIL"))
 	fromName := os.Getenv("SENDGRID_FROM_NAME")
 
 	if apiKey == "" || fromAddr == "" {
 		return nil, fmt.Errorf("sendgrid not configured: missing API_KEY or FROM_EMAIL")
 	}
+	// Checked here so a bad sender fails at startup rather than on every send
+	if !isValidEmail(fromAddr) {
+		return nil, fmt.Errorf("sendgrid misconfigured: SENDGRID_FROM_EMAIL %q is not a valid email address", fromAddr)
+	}
 	if fromName == "" {
 		fromName = "Game Rental"
 	}
+	fromName = withEnvTag(fromName, os.Getenv("APP_ENV"))
 
 	return &SendGridRepository{
 		client:   sendgrid.NewSendClient(apiKey),
@@ -64,21 +70,17 @@ func (s *SendGridRepository) SendEmail(ctx context.Context, to, subject, plainTe
 
 	resp, err := s.client.Send(message)
 	if err != nil {
-		logrus.WithError(err).WithField("to", to).Error("SendGrid send failed")
+		logrus.WithError(err).WithField("to", utils.RedactEmail(to)).Error("SendGrid send failed")
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 	if resp.StatusCode >= 400 {
 		logrus.WithFields(logrus.Fields{
 			"status": resp.StatusCode,
 			"body":   resp.Body,
-			"to":     to,
+			"to":     utils.RedactEmail(to),
 		}).Error("SendGrid error")
 		return fmt.Errorf("sendgrid error: status=%d", resp.StatusCode)
 	}
-	logrus.WithFields(logrus.Fields{
-		"to":      to,
-		"subject": subject,
-	}).Info("Email sent successfully")
 	return nil
 }
 
@@ -104,23 +106,19 @@ func (s *SendGridRepository) SendWithTemplate(ctx context.Context, to, templateI
 	resp, err := s.client.Send(message)
 	if err != nil {
 		logrus.WithError(err).WithFields(logrus.Fields{
-			"to":         to,
+			"to":          utils.RedactEmail(to),
 			"template_id": templateID,
 		}).Error("SendGrid template send failed")
 		return fmt.Errorf("failed to send template email: %w", err)
 	}
 	if resp.StatusCode >= 400 {
 		logrus.WithFields(logrus.Fields{
-			"status":     resp.StatusCode,
-			"to":         to,
+			"status":      resp.StatusCode,
+			"to":          utils.RedactEmail(to),
 			"template_id": templateID,
 		}).Error("SendGrid template error")
 		return fmt.Errorf("sendgrid template error: status=%d", resp.StatusCode)
 	}
-	logrus.WithFields(logrus.Fields{
-		"to":         to,
-		"template_id": templateID,
-	}).Info("Template email sent successfully")
 	return nil
 }
 
@@ -135,6 +133,7 @@ type MockEmail struct {
 	HTMLContent string
 	TemplateID  string
 	Data        map[string]interface{}
+	RequestID   string
 }
 
 func (m *MockEmailRepository) SendEmail(ctx context.Context, to, subject, plainText, htmlContent string) error {
@@ -143,6 +142,7 @@ func (m *MockEmailRepository) SendEmail(ctx context.Context, to, subject, plainT
 		Subject:     subject,
 		PlainText:   plainText,
 		HTMLContent: htmlContent,
+		RequestID:   utils.RequestIDFromContext(ctx),
 	})
 	return nil
 }
@@ -156,6 +156,16 @@ func (m *MockEmailRepository) SendWithTemplate(ctx context.Context, to, template
 	return nil
 }
 
+// withEnvTag prefixes the sender name outside production, e.g. "[STAGING] Game Rental".
+// An unset APP_ENV counts as production.
+func withEnvTag(fromName, appEnv string) string {
+	env := strings.ToLower(strings.TrimSpace(appEnv))
+	if env == "" || env == "production" || env == "prod" {
+		return fromName
+	}
+	return "[" + strings.ToUpper(env) + "] " + fromName
+}
+
 // stripHTML removes HTML tags for plaintext fallback
 func stripHTML(html string) string {
 	plain := htmlTagRegex.ReplaceAllString(html, "")
@@ -165,4 +175,4 @@ func stripHTML(html string) string {
 // isValidEmail validates email format
 func isValidEmail(email string) bool {
 	return emailRegex.MatchString(email)
-}
\ No newline at end of file
+}
@@ -0,0 +1,31 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSendGridRepository_RejectsInvalidFromAddress(t *testing.T) {
+	t.Setenv("SENDGRID_API_KEY", "SG.test")
+	t.Setenv("SENDGRID_FROM_EMAIL", "noreply@localhost")
+
+	repo, err := NewSendGridRepository()
+
+	assert.Nil(t, repo)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "SENDGRID_FROM_EMAIL")
+	}
+}
+
+func TestNewSendGridRepository_AcceptsValidFromAddress(t *testing.T) {
+	t.Setenv("SENDGRID_API_KEY", "SG.test")
+	t.Setenv("SENDGRID_FROM_EMAIL", " noreply@example.com ")
+
+	repo, err := NewSendGridRepository()
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, repo) {
+		assert.Equal(t, "noreply@example.com", repo.fromAddr)
+	}
+}
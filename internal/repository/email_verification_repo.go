@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"gorm.io/gorm"
+)
+
+type EmailVerificationRepository interface {
+	Create(token *model.EmailVerificationToken) error
+	GetByHash(tokenHash string, purpose model.TokenPurpose) (*model.EmailVerificationToken, error)
+	MarkUsed(tokenID uint) (bool, error)
+	HasUnused(userID uint, purpose model.TokenPurpose) (bool, error)
+}
+
+type emailVerificationRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationRepository(db *gorm.DB) EmailVerificationRepository {
+	return &emailVerificationRepository{db: db}
+}
+
+func (r *emailVerificationRepository) Create(token *model.EmailVerificationToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *emailVerificationRepository) GetByHash(tokenHash string, purpose model.TokenPurpose) (*model.EmailVerificationToken, error) {
+	var token model.EmailVerificationToken
+	err := r.db.Where("token_hash = ? AND purpose = ?", tokenHash, purpose).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed consumes an unused token and reports whether this call did it, so two
+// concurrent requests can't both redeem the same link
+func (r *emailVerificationRepository) MarkUsed(tokenID uint) (bool, error) {
+	result := r.db.Model(&model.EmailVerificationToken{}).
+		Where("id = ? AND is_used = ?", tokenID, false).
+		Updates(map[string]interface{}{
+			"is_used": true,
+			"used_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// HasUnused reports whether the user has an unredeemed token for purpose, expired or not
+func (r *emailVerificationRepository) HasUnused(userID uint, purpose model.TokenPurpose) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.EmailVerificationToken{}).
+		Where("user_id = ? AND purpose = ? AND is_used = ?", userID, purpose, false).
+		Count(&count).Error
+	return count > 0, err
+}
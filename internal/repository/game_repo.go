@@ -1,24 +1,66 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 	"gorm.io/gorm"
 )
 
+// ErrOutOfStock is returned when a copy can't be reserved because none is available
+var ErrOutOfStock = utils.NewConflictError("game_out_of_stock", "no copies of this game are available")
+
+// stockHoldingStatuses are the booking statuses that keep a copy away from other renters.
+// Pending bookings count because their reservation already took stock, and overdue ones
+// because the copy hasn't come back yet.
+var stockHoldingStatuses = []model.BookingStatus{
+	model.BookingPending,
+	model.BookingConfirmed,
+	model.BookingActive,
+	model.BookingOverdue,
+}
+
+// GameFilter narrows game lists; zero fields are ignored
+type GameFilter struct {
+	AdminID    uint
+	CategoryID uint
+	Platform   string // case-insensitive exact match
+	MinPrice   float64
+	MaxPrice   float64
+	Condition  model.GameCondition
+}
+
 type GameRepository interface {
 	// Basic CRUD
 	Create(game *model.Game) error
 	GetByID(id uint) (*model.Game, error)
 	Update(game *model.Game) error
 	Delete(id uint) error
+	GetByIDs(ids []uint) ([]*model.Game, error)
+	BulkSetActive(ids []uint, isActive bool) error
+
+	// Slugs
+	GetBySlug(slug string) (*model.Game, error)
+	GetGameIDBySlugRedirect(slug string) (uint, error)
+	SlugInUse(slug string, excludeGameID uint) (bool, error)
+	UpdateWithSlugRedirect(game *model.Game, oldSlug string) error
 
 	// Query methods for public catalog
 	GetAll(limit, offset int) ([]*model.Game, error)
+	Filter(opts GameFilter, limit, offset int) ([]*model.Game, int64, error)
 	Search(query string, limit, offset int) ([]*model.Game, error)
+	CountSearch(query string) (int64, error)
 	Count() (int64, error)
 
+	// Admin listing, including inactive games
+	ListForAdmin(filter GameFilter, limit, offset int) ([]*model.Game, error)
+	CountForAdmin(filter GameFilter) (int64, error)
+
 	// Stock management
 	CheckAvailability(gameID uint) (bool, error)
+	CheckAvailabilityForRange(gameID uint, start, end time.Time) (bool, error)
 	ReserveStock(gameID uint) error
 	ReleaseStock(gameID uint) error
 }
@@ -51,6 +93,59 @@ func (r *gameRepository) Delete(id uint) error {
 	return r.db.Delete(&model.Game{}, id).Error
 }
 
+func (r *gameRepository) GetByIDs(ids []uint) ([]*model.Game, error) {
+	var games []*model.Game
+	err := r.db.Where("id IN ?", ids).Find(&games).Error
+	return games, err
+}
+
+// BulkSetActive flips is_active for all ids in a single UPDATE, so the batch applies atomically
+func (r *gameRepository) BulkSetActive(ids []uint, isActive bool) error {
+	return r.db.Model(&model.Game{}).Where("id IN ?", ids).Update("is_active", isActive).Error
+}
+
+func (r *gameRepository) GetBySlug(slug string) (*model.Game, error) {
+	var game model.Game
+	if err := r.db.Preload("Admin").Preload("Category").Where("slug = ?", slug).First(&game).Error; err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+// GetGameIDBySlugRedirect finds the game that used slug before being renamed
+func (r *gameRepository) GetGameIDBySlugRedirect(slug string) (uint, error) {
+	var redirect model.GameSlugRedirect
+	if err := r.db.Where("slug = ?", slug).First(&redirect).Error; err != nil {
+		return 0, err
+	}
+	return redirect.GameID, nil
+}
+
+// SlugInUse reports whether another game holds slug, either as its current slug or as an old one
+func (r *gameRepository) SlugInUse(slug string, excludeGameID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Game{}).Where("slug = ? AND id <> ?", slug, excludeGameID).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	err = r.db.Model(&model.GameSlugRedirect{}).Where("slug = ? AND game_id <> ?", slug, excludeGameID).Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateWithSlugRedirect saves a renamed game and keeps oldSlug pointing at it, in one transaction.
+// A redirect for the game's new slug is dropped, since that slug is live again.
+func (r *gameRepository) UpdateWithSlugRedirect(game *model.Game, oldSlug string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(game).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("slug = ?", game.Slug).Delete(&model.GameSlugRedirect{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.GameSlugRedirect{Slug: oldSlug, GameID: game.ID}).Error
+	})
+}
+
 func (r *gameRepository) GetAll(limit, offset int) ([]*model.Game, error) {
 	var games []*model.Game
 	// Tidak perlu Session lagi, sudah global
@@ -65,19 +160,32 @@ func (r *gameRepository) GetAll(limit, offset int) ([]*model.Game, error) {
 	return games, err
 }
 
-func (r *gameRepository) Search(query string, limit, offset int) ([]*model.Game, error) {
-	var games []*model.Game
+// searchScope matches active games whose name, description or platform contains query
+func (r *gameRepository) searchScope(query string) *gorm.DB {
 	searchPattern := "%" + query + "%"
-	err := r.db.Session(&gorm.Session{PrepareStmt: false}).
+	return r.db.Session(&gorm.Session{PrepareStmt: false}).
+		Model(&model.Game{}).
 		Where("is_active = ? AND (name ILIKE ? OR description ILIKE ? OR platform ILIKE ?)",
-			true, searchPattern, searchPattern, searchPattern).
+			true, searchPattern, searchPattern, searchPattern)
+}
+
+func (r *gameRepository) Search(query string, limit, offset int) ([]*model.Game, error) {
+	var games []*model.Game
+	err := r.searchScope(query).
 		Preload("Admin").
 		Preload("Category").
+		Order("created_at DESC").
 		Limit(limit).Offset(offset).
 		Find(&games).Error
 	return games, err
 }
 
+func (r *gameRepository) CountSearch(query string) (int64, error) {
+	var count int64
+	err := r.searchScope(query).Count(&count).Error
+	return count, err
+}
+
 func (r *gameRepository) Count() (int64, error) {
 	var count int64
 	err := r.db.Session(&gorm.Session{PrepareStmt: false}).
@@ -87,6 +195,74 @@ func (r *gameRepository) Count() (int64, error) {
 	return count, err
 }
 
+func (r *gameRepository) applyFilter(query *gorm.DB, filter GameFilter) *gorm.DB {
+	if filter.AdminID != 0 {
+		query = query.Where("admin_id = ?", filter.AdminID)
+	}
+	if filter.CategoryID != 0 {
+		query = query.Where("category_id = ?", filter.CategoryID)
+	}
+	if filter.Platform != "" {
+		query = query.Where("platform ILIKE ?", escapeLike(filter.Platform))
+	}
+	if filter.MinPrice > 0 {
+		query = query.Where("rental_price_per_day >= ?", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		query = query.Where("rental_price_per_day <= ?", filter.MaxPrice)
+	}
+	if filter.Condition != "" {
+		query = query.Where("condition = ?", filter.Condition)
+	}
+	return query
+}
+
+// Filter lists active games matching opts with the total across all pages.
+// An empty GameFilter behaves like GetAll and Count.
+func (r *gameRepository) Filter(opts GameFilter, limit, offset int) ([]*model.Game, int64, error) {
+	scope := func() *gorm.DB {
+		return r.applyFilter(r.db.Model(&model.Game{}).Where("is_active = ?", true), opts)
+	}
+
+	var total int64
+	if err := scope().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var games []*model.Game
+	err := scope().
+		Preload("Admin").
+		Preload("Category").
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&games).Error
+	return games, total, err
+}
+
+// escapeLike makes value match literally in a LIKE/ILIKE pattern
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (r *gameRepository) ListForAdmin(filter GameFilter, limit, offset int) ([]*model.Game, error) {
+	var games []*model.Game
+	err := r.applyFilter(r.db, filter).
+		Preload("Admin").
+		Preload("Category").
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&games).Error
+	return games, err
+}
+
+func (r *gameRepository) CountForAdmin(filter GameFilter) (int64, error) {
+	var count int64
+	err := r.applyFilter(r.db.Model(&model.Game{}), filter).Count(&count).Error
+	return count, err
+}
+
 func (r *gameRepository) CheckAvailability(gameID uint) (bool, error) {
 	var game model.Game
 	if err := r.db.Select("available_stock").First(&game, gameID).Error; err != nil {
@@ -95,9 +271,39 @@ func (r *gameRepository) CheckAvailability(gameID uint) (bool, error) {
 	return game.AvailableStock > 0, nil
 }
 
+// CheckAvailabilityForRange reports whether at least one copy is free for the whole
+// [start, end] window. Dates are inclusive, so a booking ending on the requested start
+// day still holds the copy that day. Every booking in stockHoldingStatuses touching the
+// window is counted against stock, which errs on the side of not overbooking.
+func (r *gameRepository) CheckAvailabilityForRange(gameID uint, start, end time.Time) (bool, error) {
+	var game model.Game
+	if err := r.db.Select("id", "stock").First(&game, gameID).Error; err != nil {
+		return false, err
+	}
+
+	var overlapping int64
+	err := r.db.Model(&model.Booking{}).
+		Where("game_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?",
+			gameID, stockHoldingStatuses, end, start).
+		Count(&overlapping).Error
+	if err != nil {
+		return false, err
+	}
+
+	return overlapping < int64(game.Stock), nil
+}
+
+// ReserveStock takes one copy, or returns ErrOutOfStock when none is left
 func (r *gameRepository) ReserveStock(gameID uint) error {
-	return r.db.Model(&model.Game{}).Where("id = ? AND available_stock > 0", gameID).
-		Update("available_stock", gorm.Expr("available_stock - 1")).Error
+	result := r.db.Model(&model.Game{}).Where("id = ? AND available_stock > 0", gameID).
+		Update("available_stock", gorm.Expr("available_stock - 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOutOfStock
+	}
+	return nil
 }
 
 func (r *gameRepository) ReleaseStock(gameID uint) error {
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+)
+
+// ============= TEST CHECK AVAILABILITY FOR RANGE =============
+func TestCheckAvailabilityForRange_QueriesInclusiveOverlap(t *testing.T) {
+	start := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	db, mock, _ := newMockDB(t)
+	repo := NewGameRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","stock" FROM "games"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "stock"}).AddRow(1, 1))
+	// Bookings overlap when they start by the requested end and end on or after the requested start
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "bookings" WHERE (game_id = $1 AND status IN ($2,$3,$4,$5) AND start_date <= $6 AND end_date >= $7) AND "bookings"."deleted_at" IS NULL`)).
+		WithArgs(uint(1),
+			driver.Value(string(model.BookingPending)),
+			driver.Value(string(model.BookingConfirmed)),
+			driver.Value(string(model.BookingActive)),
+			driver.Value(string(model.BookingOverdue)),
+			end, start).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	available, err := repo.CheckAvailabilityForRange(1, start, end)
+	require.NoError(t, err)
+	assert.True(t, available)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// timeArg matches a bound time parameter against cond
+type timeArg func(time.Time) bool
+
+func (cond timeArg) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	return ok && cond(t)
+}
+
+func TestCheckAvailabilityForRange_AdjacentAndNestedRanges(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+	// One booking holds the only copy from the 10th to the 15th inclusive
+	existingStart, existingEnd := day(10), day(15)
+
+	cases := []struct {
+		name       string
+		start, end time.Time
+		available  bool
+	}{
+		{"ends the day before", day(5), day(9), true},
+		{"starts the day after", day(16), day(20), true},
+		{"starts on the existing end day", day(15), day(18), false},
+		{"ends on the existing start day", day(7), day(10), false},
+		{"nested inside", day(11), day(13), false},
+		{"wraps around", day(8), day(18), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, _ := newMockDB(t)
+			repo := NewGameRepository(db)
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","stock" FROM "games"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "stock"}).AddRow(1, 1))
+			// The existing booking is counted only when the bound dates satisfy
+			// start_date <= $6 AND end_date >= $7 for it; anything else counts nothing
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "bookings"`)).
+				WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+					timeArg(func(end time.Time) bool { return !existingStart.After(end) }),
+					timeArg(func(start time.Time) bool { return !existingEnd.Before(start) })).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "bookings"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+			available, err := repo.CheckAvailabilityForRange(1, tc.start, tc.end)
+			require.NoError(t, err)
+			assert.Equal(t, tc.available, available)
+		})
+	}
+}
+
+func TestCheckAvailabilityForRange_ComparesOverlapWithStock(t *testing.T) {
+	cases := []struct {
+		name        string
+		stock       int
+		overlapping int
+		available   bool
+	}{
+		{"no overlapping bookings", 1, 0, true},
+		{"last copy held", 1, 1, false},
+		{"one copy left", 2, 1, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, _ := newMockDB(t)
+			repo := NewGameRepository(db)
+
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","stock" FROM "games"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "stock"}).AddRow(1, tc.stock))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "bookings"`)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.overlapping))
+
+			day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+			available, err := repo.CheckAvailabilityForRange(1, day, day)
+			require.NoError(t, err)
+			assert.Equal(t, tc.available, available)
+		})
+	}
+}
+
+// ============= TEST RESERVE STOCK =============
+func TestReserveStock_OutOfStock(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewGameRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "games" SET "available_stock"=available_stock - 1`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.ReserveStock(1), ErrOutOfStock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST FILTER =============
+func TestFilter_NoFiltersListsActiveGames(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewGameRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "games" WHERE is_active = $1`)).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "games" WHERE is_active = $1 ORDER BY created_at DESC LIMIT $2`)).
+		WithArgs(true, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	games, total, err := repo.Filter(GameFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, games)
+	assert.Equal(t, int64(42), total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilter_AllFilters(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewGameRepository(db)
+
+	filter := GameFilter{
+		CategoryID: 3,
+		Platform:   "PS5",
+		MinPrice:   10000,
+		MaxPrice:   50000,
+		Condition:  model.ConditionGood,
+	}
+	where := `WHERE is_active = $1 AND category_id = $2 AND platform ILIKE $3 AND rental_price_per_day >= $4 AND rental_price_per_day <= $5 AND condition = $6`
+	args := []driver.Value{true, uint(3), "PS5", 10000.0, 50000.0, string(model.ConditionGood)}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "games" ` + where)).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "games" ` + where + ` ORDER BY created_at DESC LIMIT $7 OFFSET $8`)).
+		WithArgs(append(args, 5, 5)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, total, err := repo.Filter(filter, 5, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilter_PlatformWildcardsMatchLiterally(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewGameRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "games" WHERE is_active = $1 AND platform ILIKE $2`)).
+		WithArgs(true, `100\%\_pc`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "games" WHERE is_active = $1 AND platform ILIKE $2`)).
+		WithArgs(true, `100\%\_pc`, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, _, err := repo.Filter(GameFilter{Platform: "100%_pc"}, 10, 0)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST SEARCH COUNT =============
+func TestSearch_CountCoversAllPages(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewGameRepository(db)
+
+	predicate := `WHERE is_active = $1 AND (name ILIKE $2 OR description ILIKE $3 OR platform ILIKE $4)`
+	args := []driver.Value{true, "%zelda%", "%zelda%", "%zelda%"}
+
+	// 25 matches, served 10 per page
+	page := sqlmock.NewRows([]string{"id", "admin_id", "category_id", "name"})
+	for i := 1; i <= 10; i++ {
+		page.AddRow(i, 0, 0, "Zelda")
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "games" ` + predicate + ` ORDER BY created_at DESC LIMIT $5`)).
+		WithArgs(append(args, 10)...).
+		WillReturnRows(page)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "games" ` + predicate)).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(25))
+
+	games, err := repo.Search("zelda", 10, 0)
+	require.NoError(t, err)
+	total, err := repo.CountSearch("zelda")
+	require.NoError(t, err)
+
+	assert.Len(t, games, 10)
+	assert.Equal(t, int64(25), total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+	"gorm.io/gorm"
+)
+
+// ErrGameAlreadyReported is returned when the user has reported this game before (idx_game_reports_game_user)
+var ErrGameAlreadyReported = utils.NewConflictError("game_already_reported", "you have already reported this game")
+
+// GameReportSummary is one game's report count for the admin review list
+type GameReportSummary struct {
+	GameID         uint      `json:"game_id"`
+	GameName       string    `json:"game_name"`
+	ReportCount    int64     `json:"report_count"`
+	LatestReportAt time.Time `json:"latest_report_at"`
+	Flagged        bool      `json:"flagged" gorm:"-"`
+}
+
+type GameReportRepository interface {
+	Create(report *model.GameReport) error
+	CountByGame(gameID uint) (int64, error)
+	GetByGame(gameID uint, limit, offset int) ([]*model.GameReport, error)
+
+	// Per-game summaries, most reported first; minReports 0 includes every reported game
+	Summaries(minReports int64, limit, offset int) ([]*GameReportSummary, error)
+	CountSummaries(minReports int64) (int64, error)
+}
+
+type gameReportRepository struct {
+	db *gorm.DB
+}
+
+func NewGameReportRepository(db *gorm.DB) GameReportRepository {
+	return &gameReportRepository{db: db}
+}
+
+func (r *gameReportRepository) Create(report *model.GameReport) error {
+	err := r.db.Create(report).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == "idx_game_reports_game_user" {
+		return ErrGameAlreadyReported
+	}
+	return err
+}
+
+func (r *gameReportRepository) CountByGame(gameID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.GameReport{}).Where("game_id = ?", gameID).Count(&count).Error
+	return count, err
+}
+
+func (r *gameReportRepository) GetByGame(gameID uint, limit, offset int) ([]*model.GameReport, error) {
+	var reports []*model.GameReport
+	err := r.db.Preload("User").
+		Where("game_id = ?", gameID).
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&reports).Error
+	return reports, err
+}
+
+// summaryScope groups reports per game, keeping games with at least minReports
+func (r *gameReportRepository) summaryScope(minReports int64) *gorm.DB {
+	return r.db.Model(&model.GameReport{}).
+		Joins("JOIN games ON games.id = game_reports.game_id").
+		Group("game_reports.game_id, games.name").
+		Having("COUNT(*) >= ?", minReports)
+}
+
+func (r *gameReportRepository) Summaries(minReports int64, limit, offset int) ([]*GameReportSummary, error) {
+	var summaries []*GameReportSummary
+	err := r.summaryScope(minReports).
+		Select("game_reports.game_id, games.name AS game_name, COUNT(*) AS report_count, MAX(game_reports.created_at) AS latest_report_at").
+		Order("report_count DESC, latest_report_at DESC").
+		Limit(limit).Offset(offset).
+		Scan(&summaries).Error
+	return summaries, err
+}
+
+func (r *gameReportRepository) CountSummaries(minReports int64) (int64, error) {
+	var count int64
+	err := r.db.Table("(?) AS reported", r.summaryScope(minReports).Select("game_reports.game_id")).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	Create(notification *model.Notification) error
+	GetUserNotifications(userID uint, limit, offset int) ([]*model.Notification, error)
+	CountUserNotifications(userID uint) (int64, error)
+	CountUnread(userID uint) (int64, error)
+	MarkAsRead(notificationID, userID uint) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(notification *model.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *notificationRepository) GetUserNotifications(userID uint, limit, offset int) ([]*model.Notification, error) {
+	var notifications []*model.Notification
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *notificationRepository) CountUserNotifications(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Notification{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *notificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkAsRead is scoped to the owner so users can't touch each other's notifications.
+// Re-reading an already read notification is a no-op.
+func (r *notificationRepository) MarkAsRead(notificationID, userID uint) error {
+	var notification model.Notification
+	if err := r.db.Where("id = ? AND user_id = ?", notificationID, userID).First(&notification).Error; err != nil {
+		return err
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+	return r.db.Model(&notification).Update("read_at", time.Now()).Error
+}
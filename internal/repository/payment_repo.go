@@ -1,10 +1,34 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/yoockh/go-game-rental-api/internal/model"
 	"gorm.io/gorm"
 )
 
+// Revenue report groupings
+const (
+	RevenueByDay   = "day"
+	RevenueByWeek  = "week"
+	RevenueByMonth = "month"
+)
+
+// PaymentExportFilter narrows ExportInBatches; zero fields are ignored
+type PaymentExportFilter struct {
+	Status model.PaymentStatus
+	From   time.Time // created_at >= From
+	To     time.Time // created_at < To
+}
+
+// RevenueBucket is the paid total for one calendar period. Weeks start on Monday;
+// periods are in UTC.
+type RevenueBucket struct {
+	PeriodStart time.Time `json:"period_start"`
+	Revenue     float64   `json:"revenue"`
+	Payments    int64     `json:"payments"`
+}
+
 type PaymentRepository interface {
 	// Basic CRUD
 	Create(payment *model.Payment) error
@@ -19,10 +43,20 @@ type PaymentRepository interface {
 	GetAllPayments(limit, offset int) ([]*model.Payment, error)
 	CountAllPayments() (int64, error)
 	CountByStatus(status model.PaymentStatus) (int64, error)
+	SumPaidAmount() (float64, error)
+	GetRevenueBuckets(from, to time.Time, groupBy string) ([]RevenueBucket, error)
+	ExportInBatches(filter PaymentExportFilter, batchSize int, fn func([]*model.Payment) error) error
 
 	// Status updates
 	MarkAsPaid(paymentID uint, providerPaymentID string, paymentMethod string) error
 	MarkAsFailed(paymentID uint, failureReason string) error
+	MarkAsPaidManually(paymentID uint, adminID uint, paymentMethod string, note string) (bool, error)
+	MarkAsFailedManually(paymentID uint, adminID uint, failureReason string) (bool, error)
+	MarkAsRefunded(paymentID uint, adminID uint, amount float64, reason string) (bool, error)
+	ReopenRefund(paymentID uint) error
+	MarkHoldCaptured(paymentID uint, adminID uint, amount float64, depositReleased bool, note string) (bool, error)
+	MarkHoldVoided(paymentID uint, adminID uint, reason string) (bool, error)
+	ReopenHold(paymentID uint, amount float64) error
 }
 
 type paymentRepository struct {
@@ -60,9 +94,10 @@ func (r *paymentRepository) Update(payment *model.Payment) error {
 	return r.db.Save(payment).Error
 }
 
+// GetByBookingID returns the booking's rental payment, never a supplementary late-fee one
 func (r *paymentRepository) GetByBookingID(bookingID uint) (*model.Payment, error) {
 	var payment model.Payment
-	err := r.db.Where("booking_id = ?", bookingID).First(&payment).Error
+	err := r.db.Where("booking_id = ? AND purpose = ?", bookingID, model.PaymentPurposeRental).First(&payment).Error
 	if err != nil {
 		return nil, err
 	}
@@ -102,10 +137,109 @@ func (r *paymentRepository) MarkAsFailed(paymentID uint, failureReason string) e
 	}).Error
 }
 
+// MarkAsPaidManually only flips pending payments so a concurrent webhook or
+// second admin click cannot confirm the same payment twice. It reports whether this call did it.
+func (r *paymentRepository) MarkAsPaidManually(paymentID uint, adminID uint, paymentMethod string, note string) (bool, error) {
+	result := r.db.Model(&model.Payment{}).
+		Where("id = ? AND status = ?", paymentID, model.PaymentPending).
+		Updates(map[string]interface{}{
+			"status":         model.PaymentPaid,
+			"payment_method": paymentMethod,
+			"processed_by":   adminID,
+			"admin_note":     note,
+			"paid_at":        gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkAsRefunded flips a paid payment to refunded, so the same refund can't be recorded twice.
+// It reports whether this call made the change.
+func (r *paymentRepository) MarkAsRefunded(paymentID uint, adminID uint, amount float64, reason string) (bool, error) {
+	result := r.db.Model(&model.Payment{}).
+		Where("id = ? AND status = ?", paymentID, model.PaymentPaid).
+		Updates(map[string]interface{}{
+			"status":        model.PaymentRefunded,
+			"refund_amount": amount,
+			"refund_reason": reason,
+			"processed_by":  adminID,
+			"refunded_at":   gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReopenRefund puts a claimed refund back to paid, for when the gateway refused it
+func (r *paymentRepository) ReopenRefund(paymentID uint) error {
+	return r.db.Model(&model.Payment{}).
+		Where("id = ? AND status = ?", paymentID, model.PaymentRefunded).
+		Updates(map[string]interface{}{
+			"status":        model.PaymentPaid,
+			"refund_amount": nil,
+			"refund_reason": nil,
+			"refunded_at":   nil,
+		}).Error
+}
+
+// MarkHoldCaptured settles an authorized deposit hold as paid for the captured amount, noting
+// whether the deposit was released. Only authorized payments change, so a hold can't be captured
+// twice; it reports whether this call claimed the hold.
+func (r *paymentRepository) MarkHoldCaptured(paymentID uint, adminID uint, amount float64, depositReleased bool, note string) (bool, error) {
+	result := r.db.Model(&model.Payment{}).
+		Where("id = ? AND status = ?", paymentID, model.PaymentAuthorized).
+		Updates(map[string]interface{}{
+			"status":           model.PaymentPaid,
+			"amount":           amount,
+			"deposit_released": depositReleased,
+			"processed_by":     adminID,
+			"admin_note":       note,
+			"paid_at":          gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkHoldVoided records that an authorized deposit hold was released without a capture,
+// reporting whether this call claimed the hold
+func (r *paymentRepository) MarkHoldVoided(paymentID uint, adminID uint, reason string) (bool, error) {
+	result := r.db.Model(&model.Payment{}).
+		Where("id = ? AND status = ?", paymentID, model.PaymentAuthorized).
+		Updates(map[string]interface{}{
+			"status":       model.PaymentVoided,
+			"admin_note":   reason,
+			"processed_by": adminID,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReopenHold puts a claimed hold back to authorized at its original amount, for when the
+// gateway refused the capture or void the claim was made for
+func (r *paymentRepository) ReopenHold(paymentID uint, amount float64) error {
+	return r.db.Model(&model.Payment{}).
+		Where("id = ? AND status IN ?", paymentID, []model.PaymentStatus{model.PaymentPaid, model.PaymentVoided}).
+		Updates(map[string]interface{}{
+			"status":           model.PaymentAuthorized,
+			"amount":           amount,
+			"deposit_released": false,
+			"paid_at":          nil,
+		}).Error
+}
+
+// MarkAsFailedManually voids a payment that is still pending, recording the admin who did it.
+// It reports whether this call did it.
+func (r *paymentRepository) MarkAsFailedManually(paymentID uint, adminID uint, failureReason string) (bool, error) {
+	result := r.db.Model(&model.Payment{}).
+		Where("id = ? AND status = ?", paymentID, model.PaymentPending).
+		Updates(map[string]interface{}{
+			"status":         model.PaymentFailed,
+			"failure_reason": failureReason,
+			"processed_by":   adminID,
+			"failed_at":      gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
 func (r *paymentRepository) GetAllPayments(limit, offset int) ([]*model.Payment, error) {
 	var payments []*model.Payment
-	err := r.db.Preload("Booking").Order("created_at DESC").
-		Limit(limit).Offset(offset).Find(&payments).Error
+	err := r.db.Preload("Booking").Preload("Booking.User").Preload("Booking.Game").
+		Order("created_at DESC").Limit(limit).Offset(offset).Find(&payments).Error
 	return payments, err
 }
 
@@ -120,3 +254,88 @@ func (r *paymentRepository) CountByStatus(status model.PaymentStatus) (int64, er
 	err := r.db.Model(&model.Payment{}).Where("status = ?", status).Count(&count).Error
 	return count, err
 }
+
+// SumPaidAmount totals the amount of every paid payment, rental and late fee alike
+func (r *paymentRepository) SumPaidAmount() (float64, error) {
+	var total float64
+	err := r.db.Model(&model.Payment{}).
+		Where("status = ?", model.PaymentPaid).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// GetRevenueBuckets totals paid payments by paid_at over [from, to), one bucket per
+// period including empty ones. groupBy must be RevenueByDay, RevenueByWeek or RevenueByMonth.
+// Postgres sums each period; only the empty periods are filled in here.
+func (r *paymentRepository) GetRevenueBuckets(from, to time.Time, groupBy string) ([]RevenueBucket, error) {
+	var rows []RevenueBucket
+	err := r.db.Model(&model.Payment{}).
+		// date_trunc weeks start on Monday, like revenuePeriodStart
+		Select("date_trunc(?, paid_at) AS period_start, SUM(amount) AS revenue, COUNT(*) AS payments", groupBy).
+		Where("status = ? AND paid_at >= ? AND paid_at < ?", model.PaymentPaid, from, to).
+		Group("period_start").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets []RevenueBucket
+	index := make(map[time.Time]int)
+	for start := revenuePeriodStart(from, groupBy); start.Before(to); start = nextRevenuePeriod(start, groupBy) {
+		index[start] = len(buckets)
+		buckets = append(buckets, RevenueBucket{PeriodStart: start})
+	}
+	for _, row := range rows {
+		if i, ok := index[revenuePeriodStart(row.PeriodStart, groupBy)]; ok {
+			buckets[i].Revenue += row.Revenue
+			buckets[i].Payments += row.Payments
+		}
+	}
+	return buckets, nil
+}
+
+// revenuePeriodStart truncates t to the start of its day, Monday-based week or month in UTC
+func revenuePeriodStart(t time.Time, groupBy string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch groupBy {
+	case RevenueByWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case RevenueByMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func nextRevenuePeriod(start time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case RevenueByWeek:
+		return start.AddDate(0, 0, 7)
+	case RevenueByMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// ExportInBatches walks the matching payments in id order, batchSize rows per query, so an
+// export never holds the whole table in memory. An error from fn stops the walk and is returned.
+func (r *paymentRepository) ExportInBatches(filter PaymentExportFilter, batchSize int, fn func([]*model.Payment) error) error {
+	query := r.db.Model(&model.Payment{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var batch []*model.Payment
+	return query.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+)
+
+// ============= TEST SUM PAID AMOUNT =============
+func TestSumPaidAmount(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewPaymentRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(amount), 0) FROM "payments" WHERE status = $1`)).
+		WithArgs("paid").
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(1250000.50))
+
+	total, err := repo.SumPaidAmount()
+	require.NoError(t, err)
+	assert.Equal(t, 1250000.50, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST REVENUE BUCKETS =============
+func TestGetRevenueBuckets_MonthBoundary(t *testing.T) {
+	from := time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC) // a Monday
+	to := time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)
+	// Summed by Postgres, so only one row per non-empty period comes back
+	query := regexp.QuoteMeta(`SELECT date_trunc($1, paid_at) AS period_start, SUM(amount) AS revenue, COUNT(*) AS payments FROM "payments" WHERE status = $2 AND paid_at >= $3 AND paid_at < $4 GROUP BY "period_start"`)
+	summed := func(periods ...RevenueBucket) *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"period_start", "revenue", "payments"})
+		for _, p := range periods {
+			rows.AddRow(p.PeriodStart, p.Revenue, p.Payments)
+		}
+		return rows
+	}
+
+	cases := []struct {
+		groupBy string
+		rows    []RevenueBucket
+		want    []RevenueBucket
+	}{
+		{RevenueByMonth,
+			[]RevenueBucket{
+				{PeriodStart: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Revenue: 140, Payments: 2},
+				{PeriodStart: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Revenue: 250, Payments: 2},
+			},
+			[]RevenueBucket{
+				{PeriodStart: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Revenue: 140, Payments: 2},
+				{PeriodStart: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Revenue: 250, Payments: 2},
+			}},
+		{RevenueByWeek,
+			[]RevenueBucket{
+				{PeriodStart: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), Revenue: 50, Payments: 1},
+			},
+			[]RevenueBucket{
+				{PeriodStart: time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC)},
+				{PeriodStart: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), Revenue: 50, Payments: 1},
+			}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.groupBy, func(t *testing.T) {
+			db, mock, _ := newMockDB(t)
+			repo := NewPaymentRepository(db)
+			mock.ExpectQuery(query).WithArgs(tc.groupBy, "paid", from, to).WillReturnRows(summed(tc.rows...))
+
+			buckets, err := repo.GetRevenueBuckets(from, to, tc.groupBy)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, buckets)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run(RevenueByDay, func(t *testing.T) {
+		db, mock, _ := newMockDB(t)
+		repo := NewPaymentRepository(db)
+		mock.ExpectQuery(query).WithArgs(RevenueByDay, "paid", from, to).WillReturnRows(summed(
+			RevenueBucket{PeriodStart: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), Revenue: 140, Payments: 2},
+			RevenueBucket{PeriodStart: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Revenue: 200, Payments: 1},
+		))
+
+		buckets, err := repo.GetRevenueBuckets(from, to, RevenueByDay)
+		require.NoError(t, err)
+		// Every day of the range is present, empty ones included
+		require.Len(t, buckets, 14)
+		assert.Equal(t, RevenueBucket{PeriodStart: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), Revenue: 140, Payments: 2}, buckets[5])
+		assert.Equal(t, RevenueBucket{PeriodStart: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Revenue: 200, Payments: 1}, buckets[6])
+		assert.Equal(t, RevenueBucket{PeriodStart: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)}, buckets[7])
+	})
+}
+
+// ============= TEST EXPORT IN BATCHES =============
+func TestExportInBatches_PagesByID(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+	repo := NewPaymentRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "payments" WHERE status = $1 ORDER BY "payments"."id" LIMIT $2`)).
+		WithArgs("paid", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "booking_id", "amount", "status"}).
+			AddRow(1, 10, 100.0, "paid").
+			AddRow(2, 11, 200.0, "paid"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "payments" WHERE status = $1 AND "payments"."id" > $2 ORDER BY "payments"."id" LIMIT $3`)).
+		WithArgs("paid", 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "booking_id", "amount", "status"}).
+			AddRow(3, 12, 300.0, "paid"))
+
+	var batchSizes []int
+	var ids []uint
+	err := repo.ExportInBatches(PaymentExportFilter{Status: "paid"}, 2, func(batch []*model.Payment) error {
+		batchSizes = append(batchSizes, len(batch))
+		for _, payment := range batch {
+			ids = append(ids, payment.ID)
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, batchSizes)
+	assert.Equal(t, []uint{1, 2, 3}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	Create(token *model.RefreshToken) error
+	GetByHash(tokenHash string) (*model.RefreshToken, error)
+	Revoke(tokenID uint) (bool, error)
+	RevokeAllForUser(userID uint) error
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) Create(token *model.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *refreshTokenRepository) GetByHash(tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Revoke marks the token revoked and reports whether this call did it
+func (r *refreshTokenRepository) Revoke(tokenID uint) (bool, error) {
+	result := r.db.Model(&model.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", tokenID).
+		Update("revoked_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+// RevokeAllForUser revokes every outstanding refresh token of the user, signing out all sessions
+func (r *refreshTokenRepository) RevokeAllForUser(userID uint) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
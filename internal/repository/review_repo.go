@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"math"
+
 	"github.com/yoockh/go-game-rental-api/internal/model"
 	"gorm.io/gorm"
 )
@@ -8,10 +10,17 @@ import (
 type ReviewRepository interface {
 	// Basic CRUD
 	Create(review *model.Review) error
+	GetByID(id uint) (*model.Review, error)
+	Update(review *model.Review) error
+	UpdateReply(review *model.Review) error
+	Delete(id uint) error
 
 	// Query methods
 	GetByBookingID(bookingID uint) (*model.Review, error)
 	GetGameReviews(gameID uint, limit, offset int) ([]*model.Review, error)
+	GetUserReviews(userID uint, limit, offset int) ([]*model.Review, error)
+	CountUserReviews(userID uint) (int64, error)
+	GetGameRatingStats(gameID uint) (avg float64, count int64, err error)
 }
 
 type reviewRepository struct {
@@ -26,6 +35,28 @@ func (r *reviewRepository) Create(review *model.Review) error {
 	return r.db.Create(review).Error
 }
 
+func (r *reviewRepository) GetByID(id uint) (*model.Review, error) {
+	var review model.Review
+	if err := r.db.First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// Update writes rating and comment only; the booking, user and game links never change
+func (r *reviewRepository) Update(review *model.Review) error {
+	return r.db.Model(review).Select("rating", "comment").Updates(review).Error
+}
+
+// UpdateReply writes reply and replied_at only, leaving the author's rating and comment alone
+func (r *reviewRepository) UpdateReply(review *model.Review) error {
+	return r.db.Model(review).Select("reply", "replied_at").Updates(review).Error
+}
+
+func (r *reviewRepository) Delete(id uint) error {
+	return r.db.Delete(&model.Review{}, id).Error
+}
+
 func (r *reviewRepository) GetByBookingID(bookingID uint) (*model.Review, error) {
 	var review model.Review
 	err := r.db.Where("booking_id = ?", bookingID).First(&review).Error
@@ -47,3 +78,34 @@ func (r *reviewRepository) GetGameReviews(gameID uint, limit, offset int) ([]*mo
 		Find(&reviews).Error
 	return reviews, err
 }
+
+func (r *reviewRepository) GetUserReviews(userID uint, limit, offset int) ([]*model.Review, error) {
+	var reviews []*model.Review
+	err := r.db.
+		Preload("Game").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&reviews).Error
+	return reviews, err
+}
+
+func (r *reviewRepository) CountUserReviews(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Review{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// GetGameRatingStats returns the average rating rounded to one decimal and the review count, 0/0 without reviews
+func (r *reviewRepository) GetGameRatingStats(gameID uint) (float64, int64, error) {
+	var row struct {
+		Avg   float64
+		Count int64
+	}
+	err := r.db.Model(&model.Review{}).Select("COALESCE(AVG(rating), 0) AS avg, COUNT(*) AS count").
+		Where("game_id = ?", gameID).Scan(&row).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	return math.Round(row.Avg*10) / 10, row.Count, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============= TEST USER REVIEWS =============
+func TestGetUserReviews_LoadsGamesAndCountsOwnReviews(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewReviewRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "reviews" WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`)).
+		WithArgs(uint(3), 10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "game_id", "rating"}).
+			AddRow(11, 3, 5, 4).
+			AddRow(12, 3, 6, 5))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "games" WHERE "games"."id" IN ($1,$2)`)).
+		WithArgs(5, 6).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "Zelda").AddRow(6, "Mario"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "reviews" WHERE user_id = $1`)).
+		WithArgs(uint(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+	reviews, err := repo.GetUserReviews(3, 10, 10)
+	require.NoError(t, err)
+	total, err := repo.CountUserReviews(3)
+	require.NoError(t, err)
+
+	require.Len(t, reviews, 2)
+	if assert.NotNil(t, reviews[0].Game) {
+		assert.Equal(t, "Zelda", reviews[0].Game.Name)
+	}
+	assert.Equal(t, int64(12), total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST GAME RATING STATS =============
+func TestGetGameRatingStats_RoundsToOneDecimal(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewReviewRepository(db)
+
+	// Ratings 5, 4, 4 average to 4.333...
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(AVG(rating), 0) AS avg, COUNT(*) AS count FROM "reviews" WHERE game_id = $1`)).
+		WithArgs(uint(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"avg", "count"}).AddRow(13.0/3.0, 3))
+
+	avg, count, err := repo.GetGameRatingStats(5)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4.3, avg)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetGameRatingStats_NoReviews(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewReviewRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM "reviews" WHERE game_id = $1`)).
+		WithArgs(uint(9)).
+		WillReturnRows(sqlmock.NewRows([]string{"avg", "count"}).AddRow(0, 0))
+
+	avg, count, err := repo.GetGameRatingStats(9)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, avg)
+	assert.Equal(t, int64(0), count)
+}
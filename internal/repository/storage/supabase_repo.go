@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	maxFileSize = 10 * 1024 * 1024 // 10MB
+	MaxFileSize = 10 * 1024 * 1024 // 10MB, also enforced by callers before reading uploads
 	maxRetries  = 3
 )
 
@@ -58,8 +58,8 @@ func (s *SupabaseRepository) UploadFile(ctx context.Context, destinationPath str
 	if len(data) == 0 {
 		return "", fmt.Errorf("empty data provided")
 	}
-	if len(data) > maxFileSize {
-		return "", fmt.Errorf("file too large: max %dMB", maxFileSize/(1024*1024))
+	if len(data) > MaxFileSize {
+		return "", fmt.Errorf("file too large: max %dMB", MaxFileSize/(1024*1024))
 	}
 
 	// Sanitasi path
@@ -164,6 +164,7 @@ func (s *SupabaseRepository) GetPublicURL(path string) string {
 
 type MockStorageRepository struct {
 	UploadedFiles []MockFile
+	DeletedFiles  []string
 }
 
 type MockFile struct {
@@ -184,9 +185,10 @@ func (m *MockStorageRepository) UploadFile(ctx context.Context, destinationPath
 }
 
 func (m *MockStorageRepository) DeleteFile(ctx context.Context, destinationPath string) error {
+	m.DeletedFiles = append(m.DeletedFiles, destinationPath)
 	return nil
 }
 
 func (m *MockStorageRepository) GetPublicURL(path string) string {
 	return "https://mock-storage.com/" + path
-}
\ No newline at end of file
+}
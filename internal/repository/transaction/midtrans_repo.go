@@ -3,25 +3,54 @@ package transaction
 import (
 	"context"
 	"crypto/sha512"
+	"crypto/subtle"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	midtrans "github.com/midtrans/midtrans-go"
 	"github.com/midtrans/midtrans-go/coreapi"
 	"github.com/sirupsen/logrus"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
 
+// ErrGatewayTimeout is returned when ctx ends before the gateway answers
+var ErrGatewayTimeout = errors.New("payment gateway timeout")
+
+// TransactionRepository methods return ErrGatewayTimeout once ctx is done, but the
+// underlying gateway request may still be in flight and complete afterwards
 type TransactionRepository interface {
+	// CreateCharge understands params["expiry_minutes"] (int) to override the provider's default expiry
+	// and params["token_id"] (string), the Midtrans.js card token that card charges need
 	CreateCharge(ctx context.Context, orderID string, grossAmount int64, paymentType string, params map[string]interface{}) (string, string, error)
 	GetStatus(ctx context.Context, transactionID string) (string, error)
+	Refund(ctx context.Context, providerPaymentID string, amount int64) error
+	// Capture and Void settle a charge created with params["authorize_only"] = true
+	Capture(ctx context.Context, providerPaymentID string, amount int64) error
+	Void(ctx context.Context, providerPaymentID string) error
+	// VerifyNotification checks Midtrans's signature_key on a notification
 	VerifyNotification(orderID, statusCode, grossAmount, signatureKey string) bool
+	// VerifyWebhookSignature checks a signed webhook body, e.g. Stripe's Stripe-Signature header
+	VerifyWebhookSignature(payload []byte, signatureHeader string) bool
 }
 
+// defaultMidtransTimeout bounds a single gateway call when the caller's ctx has no deadline
+const defaultMidtransTimeout = 15 * time.Second
+
+const (
+	maxChargeAttempts  = 3
+	chargeRetryBackoff = 500 * time.Millisecond
+)
+
 type MidtransRepository struct {
 	core      *coreapi.Client
 	serverKey string
+	// secondaryServerKey also verifies notifications while a key rotation is in progress
+	secondaryServerKey string
+	timeout            time.Duration
 }
 
 func NewMidtransRepository() (*MidtransRepository, error) {
@@ -40,13 +69,43 @@ func NewMidtransRepository() (*MidtransRepository, error) {
 	}
 
 	return &MidtransRepository{
-		core:      &c,
-		serverKey: key,
+		core:               &c,
+		serverKey:          key,
+		secondaryServerKey: os.Getenv("MIDTRANS_SERVER_KEY_SECONDARY"),
+		timeout:            time.Duration(utils.GetEnvInt("MIDTRANS_TIMEOUT_SECONDS", int(defaultMidtransTimeout/time.Second))) * time.Second,
 	}, nil
 }
 
+// callWithTimeout runs fn in a goroutine and returns ErrGatewayTimeout when ctx or the
+// repository timeout expires first. The Midtrans SDK ignores context, so the abandoned
+// HTTP request may still be in flight and even succeed after we have given up.
+func callWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func() (T, error)) (T, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		val T
+		err error
+	}
+	done := make(chan result, 1) // buffered so the goroutine never blocks after a timeout
+	go func() {
+		val, err := fn()
+		done <- result{val, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.val, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, fmt.Errorf("%w: %v", ErrGatewayTimeout, ctx.Err())
+	}
+}
+
 func (m *MidtransRepository) CreateCharge(ctx context.Context, orderID string, grossAmount int64, paymentType string, params map[string]interface{}) (string, string, error) {
-	_ = ctx // ctx unused - Midtrans SDK doesn't support context
 	// Log unknown payment types but allow them
 	knownTypes := map[string]bool{
 		"credit_card": true, "bank_transfer": true, "echannel": true,
@@ -63,8 +122,49 @@ func (m *MidtransRepository) CreateCharge(ctx context.Context, orderID string, g
 			GrossAmt: grossAmount,
 		},
 	}
+	// Card charges go through the Midtrans.js token; Midtrans only supports pre-authorization on them
+	tokenID, _ := params["token_id"].(string)
+	if authorize, _ := params["authorize_only"].(bool); authorize {
+		if tokenID == "" {
+			return "", "", errors.New("payment gateway error: card pre-authorization requires token_id")
+		}
+		req.CreditCard = &coreapi.CreditCardDetails{TokenID: tokenID, Type: "authorize"}
+	} else if tokenID != "" {
+		req.CreditCard = &coreapi.CreditCardDetails{TokenID: tokenID}
+	}
+	if minutes, ok := params["expiry_minutes"].(int); ok && minutes > 0 {
+		req.CustomExpiry = &coreapi.CustomExpiry{
+			ExpiryDuration: minutes,
+			Unit:           "minute",
+		}
+	}
 
-	resp, err := m.core.ChargeTransaction(req)
+	// The same orderID is sent on every attempt, so Midtrans rejects a duplicate instead of charging twice
+	var resp *coreapi.ChargeResponse
+	var err error
+	for attempt := 1; attempt <= maxChargeAttempts; attempt++ {
+		resp, err = callWithTimeout(ctx, m.timeout, func() (*coreapi.ChargeResponse, error) {
+			resp, midErr := m.core.ChargeTransaction(req)
+			if midErr != nil {
+				return nil, midErr
+			}
+			return resp, nil
+		})
+		if err == nil || !isRetryableChargeError(err) || attempt == maxChargeAttempts {
+			break
+		}
+
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"order_id": orderID,
+			"attempt":  attempt,
+		}).Warn("Midtrans charge failed, retrying")
+
+		select {
+		case <-time.After(time.Duration(attempt) * chargeRetryBackoff):
+		case <-ctx.Done():
+			return "", "", fmt.Errorf("payment gateway error: %w", ctx.Err())
+		}
+	}
 	if err != nil {
 		logrus.WithError(err).WithField("order_id", orderID).Error("Midtrans charge failed")
 		return "", "", fmt.Errorf("payment gateway error: %w", err)
@@ -85,9 +185,28 @@ func (m *MidtransRepository) CreateCharge(ctx context.Context, orderID string, g
 	return resp.TransactionID, redirect, nil
 }
 
+// isRetryableChargeError reports whether a charge failure is transient (network or 5xx).
+// Declines and validation errors come back as 4xx and are final; timeouts are not retried
+// because the original request may still go through.
+func isRetryableChargeError(err error) bool {
+	if errors.Is(err, ErrGatewayTimeout) {
+		return false
+	}
+	var midErr *midtrans.Error
+	if !errors.As(err, &midErr) {
+		return false
+	}
+	return midErr.StatusCode == 0 || midErr.StatusCode >= 500
+}
+
 func (m *MidtransRepository) GetStatus(ctx context.Context, transactionID string) (string, error) {
-	_ = ctx // ctx unused - Midtrans SDK doesn't support context
-	resp, err := m.core.CheckTransaction(transactionID)
+	resp, err := callWithTimeout(ctx, m.timeout, func() (*coreapi.TransactionStatusResponse, error) {
+		resp, midErr := m.core.CheckTransaction(transactionID)
+		if midErr != nil {
+			return nil, midErr
+		}
+		return resp, nil
+	})
 	if err != nil {
 		logrus.WithError(err).WithField("transaction_id", transactionID).Error("Midtrans status check failed")
 		return "", fmt.Errorf("failed to check payment status: %w", err)
@@ -95,14 +214,120 @@ func (m *MidtransRepository) GetStatus(ctx context.Context, transactionID string
 	return resp.TransactionStatus, nil
 }
 
+// Refund refunds amount (a full or partial refund) of a settled Midtrans transaction
+func (m *MidtransRepository) Refund(ctx context.Context, providerPaymentID string, amount int64) error {
+	req := &coreapi.RefundReq{
+		RefundKey: fmt.Sprintf("%s-refund-%d", providerPaymentID, amount),
+		Amount:    amount,
+	}
+
+	_, err := callWithTimeout(ctx, m.timeout, func() (*coreapi.RefundResponse, error) {
+		resp, midErr := m.core.RefundTransaction(providerPaymentID, req)
+		if midErr != nil {
+			return nil, midErr
+		}
+		return resp, nil
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("transaction_id", providerPaymentID).Error("Midtrans refund failed")
+		return fmt.Errorf("payment gateway error: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"transaction_id": providerPaymentID,
+		"amount":         amount,
+	}).Info("Midtrans refund created")
+	return nil
+}
+
+// Capture takes amount (up to the authorized total) from a pre-authorized card charge;
+// Midtrans releases whatever is left
+func (m *MidtransRepository) Capture(ctx context.Context, providerPaymentID string, amount int64) error {
+	req := &coreapi.CaptureReq{
+		TransactionID: providerPaymentID,
+		GrossAmt:      float64(amount),
+	}
+
+	_, err := callWithTimeout(ctx, m.timeout, func() (*coreapi.CaptureResponse, error) {
+		resp, midErr := m.core.CaptureTransaction(req)
+		if midErr != nil {
+			return nil, midErr
+		}
+		return resp, nil
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("transaction_id", providerPaymentID).Error("Midtrans capture failed")
+		return fmt.Errorf("payment gateway error: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"transaction_id": providerPaymentID,
+		"amount":         amount,
+	}).Info("Midtrans capture created")
+	return nil
+}
+
+// Void cancels a pre-authorized charge so none of it is taken
+func (m *MidtransRepository) Void(ctx context.Context, providerPaymentID string) error {
+	_, err := callWithTimeout(ctx, m.timeout, func() (*coreapi.CancelResponse, error) {
+		resp, midErr := m.core.CancelTransaction(providerPaymentID)
+		if midErr != nil {
+			return nil, midErr
+		}
+		return resp, nil
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("transaction_id", providerPaymentID).Error("Midtrans void failed")
+		return fmt.Errorf("payment gateway error: %w", err)
+	}
+
+	logrus.WithField("transaction_id", providerPaymentID).Info("Midtrans authorization voided")
+	return nil
+}
+
+// VerifyNotification accepts a signature made with either the primary or the secondary
+// server key, so notifications keep verifying while Midtrans switches between them
 func (m *MidtransRepository) VerifyNotification(orderID, statusCode, grossAmount, signatureKey string) bool {
-	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + m.serverKey))
+	keys := []struct {
+		name, key string
+	}{
+		{"primary", m.serverKey},
+		{"secondary", m.secondaryServerKey},
+	}
+	for _, k := range keys {
+		if k.key != "" && signatureMatches(orderID+statusCode+grossAmount+k.key, signatureKey) {
+			logrus.WithFields(logrus.Fields{
+				"order_id":    orderID,
+				"signed_with": k.name,
+			}).Info("Midtrans notification signature verified")
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyWebhookSignature doesn't apply to Midtrans, which signs fields of the notification
+// (checked by VerifyNotification) rather than the raw body
+func (m *MidtransRepository) VerifyWebhookSignature(payload []byte, signatureHeader string) bool {
+	return false
+}
+
+func signatureMatches(payload, signatureKey string) bool {
+	sum := sha512.Sum512([]byte(payload))
 	expected := hex.EncodeToString(sum[:])
-	return strings.EqualFold(expected, signatureKey)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(signatureKey))) == 1
 }
 
 type MockTransactionRepository struct {
-	Charges []MockCharge
+	Charges  []MockCharge
+	Refunds  []MockRefund
+	Captures []MockRefund // same shape: provider payment ID and amount
+	Voids    []string
+}
+
+type MockRefund struct {
+	ProviderPaymentID string
+	Amount            int64
 }
 
 type MockCharge struct {
@@ -124,19 +349,43 @@ func (m *MockTransactionRepository) CreateCharge(ctx context.Context, orderID st
 }
 
 func (m *MockTransactionRepository) GetStatus(ctx context.Context, transactionID string) (string, error) {
-	_ = ctx // ctx unused in mock
+	_ = ctx            // ctx unused in mock
 	return "paid", nil // Always paid for testing
 }
 
+func (m *MockTransactionRepository) Refund(ctx context.Context, providerPaymentID string, amount int64) error {
+	_ = ctx // ctx unused in mock
+	m.Refunds = append(m.Refunds, MockRefund{ProviderPaymentID: providerPaymentID, Amount: amount})
+	return nil
+}
+
+func (m *MockTransactionRepository) Capture(ctx context.Context, providerPaymentID string, amount int64) error {
+	_ = ctx // ctx unused in mock
+	m.Captures = append(m.Captures, MockRefund{ProviderPaymentID: providerPaymentID, Amount: amount})
+	return nil
+}
+
+func (m *MockTransactionRepository) Void(ctx context.Context, providerPaymentID string) error {
+	_ = ctx // ctx unused in mock
+	m.Voids = append(m.Voids, providerPaymentID)
+	return nil
+}
+
 func (m *MockTransactionRepository) VerifyNotification(orderID, statusCode, grossAmount, signatureKey string) bool {
 	return true // Always valid for testing
 }
 
+func (m *MockTransactionRepository) VerifyWebhookSignature(payload []byte, signatureHeader string) bool {
+	return true // Always valid for testing
+}
+
 // MapStatusToInternal maps Midtrans status to internal status
 func MapStatusToInternal(midtransStatus string) string {
 	switch midtransStatus {
 	case "capture", "settlement":
 		return "paid"
+	case "authorize":
+		return "authorized"
 	case "pending":
 		return "pending"
 	case "deny", "cancel", "expire", "failure":
@@ -144,4 +393,4 @@ func MapStatusToInternal(midtransStatus string) string {
 	default:
 		return midtransStatus
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,96 @@
+package transaction
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	midtrans "github.com/midtrans/midtrans-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallWithTimeout_ReturnsResult(t *testing.T) {
+	val, err := callWithTimeout(context.Background(), time.Second, func() (string, error) {
+		return "ok", nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", val)
+}
+
+func TestCallWithTimeout_GivesUpOnHungCall(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	start := time.Now()
+	_, err := callWithTimeout(context.Background(), 20*time.Millisecond, func() (string, error) {
+		<-release
+		return "late", nil
+	})
+
+	assert.True(t, errors.Is(err, ErrGatewayTimeout))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestCallWithTimeout_HonorsCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := callWithTimeout(ctx, time.Minute, func() (string, error) {
+		time.Sleep(50 * time.Millisecond)
+		return "late", nil
+	})
+
+	assert.True(t, errors.Is(err, ErrGatewayTimeout))
+}
+
+func TestIsRetryableChargeError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network error", &midtrans.Error{Message: "connection reset"}, true},
+		{"server error", &midtrans.Error{StatusCode: 503}, true},
+		{"declined", &midtrans.Error{StatusCode: 402}, false},
+		{"duplicate order", &midtrans.Error{StatusCode: 406}, false},
+		{"timeout", ErrGatewayTimeout, false},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, isRetryableChargeError(tc.err))
+		})
+	}
+}
+
+func midtransSignature(orderID, statusCode, grossAmount, serverKey string) string {
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerifyNotification_AcceptsEitherKeyDuringRotation(t *testing.T) {
+	repo := &MidtransRepository{serverKey: "new-key", secondaryServerKey: "old-key"}
+
+	assert.True(t, repo.VerifyNotification("order-1", "200", "50000.00", midtransSignature("order-1", "200", "50000.00", "new-key")))
+	assert.True(t, repo.VerifyNotification("order-1", "200", "50000.00", midtransSignature("order-1", "200", "50000.00", "old-key")))
+	assert.False(t, repo.VerifyNotification("order-1", "200", "50000.00", midtransSignature("order-1", "200", "50000.00", "other-key")))
+}
+
+func TestVerifyNotification_IgnoresUnsetSecondaryKey(t *testing.T) {
+	repo := &MidtransRepository{serverKey: "new-key"}
+
+	assert.False(t, repo.VerifyNotification("order-1", "200", "50000.00", midtransSignature("order-1", "200", "50000.00", "")))
+}
+
+func TestCreateCharge_AuthorizeRequiresCardToken(t *testing.T) {
+	repo := &MidtransRepository{serverKey: "key"} // nil core: the charge must fail before reaching it
+
+	_, _, err := repo.CreateCharge(context.Background(), "booking-1", 150000, "credit_card", map[string]interface{}{"authorize_only": true})
+
+	assert.ErrorContains(t, err, "token_id")
+}
@@ -0,0 +1,307 @@
+package transaction
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+)
+
+// ErrPaymentDeclined is returned when Stripe rejects the card; retrying won't help
+var ErrPaymentDeclined = errors.New("payment declined")
+
+const stripeAPIBase = "https://api.stripe.com/v1"
+
+// stripeZeroDecimalCurrencies are charged in whole units. Stripe takes every other currency
+// in hundredths, IDR included, except stripeThreeDecimalCurrencies, which it takes in thousandths.
+var stripeZeroDecimalCurrencies = map[string]bool{
+	"bif": true, "clp": true, "djf": true, "gnf": true, "jpy": true, "kmf": true, "krw": true, "mga": true,
+	"pyg": true, "rwf": true, "ugx": true, "vnd": true, "vuv": true, "xaf": true, "xof": true, "xpf": true,
+}
+
+var stripeThreeDecimalCurrencies = map[string]bool{
+	"bhd": true, "jod": true, "kwd": true, "omr": true, "tnd": true,
+}
+
+// stripeSignatureTolerance is how old a signed webhook may be, matching Stripe's own libraries
+const stripeSignatureTolerance = 5 * time.Minute
+
+// StripeRepository creates PaymentIntents over Stripe's REST API.
+// CreateCharge returns the intent ID and its client secret for the frontend to confirm.
+type StripeRepository struct {
+	client    *http.Client
+	baseURL   string
+	secretKey string
+	currency  string
+	// webhookSecret (whsec_...) signs webhook deliveries; when empty every webhook is rejected
+	webhookSecret string
+}
+
+func NewStripeRepository() (*StripeRepository, error) {
+	key := os.Getenv("STRIPE_SECRET_KEY")
+	if key == "" {
+		return nil, fmt.Errorf("stripe not configured: missing STRIPE_SECRET_KEY")
+	}
+
+	webhookSecret := os.Getenv("STRIPE_WEBHOOK_SECRET")
+	if webhookSecret == "" {
+		logrus.Warn("STRIPE_WEBHOOK_SECRET not set: Stripe webhooks will be rejected")
+	}
+
+	return &StripeRepository{
+		client:        &http.Client{Timeout: time.Duration(utils.GetEnvInt("STRIPE_TIMEOUT_SECONDS", 15)) * time.Second},
+		baseURL:       stripeAPIBase,
+		secretKey:     key,
+		currency:      strings.ToLower(utils.GetEnvString("STRIPE_CURRENCY", "idr")),
+		webhookSecret: webhookSecret,
+	}, nil
+}
+
+type stripePaymentIntent struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	ClientSecret string `json:"client_secret"`
+}
+
+type stripeErrorResponse struct {
+	Error struct {
+		Type        string `json:"type"`
+		Code        string `json:"code"`
+		DeclineCode string `json:"decline_code"`
+		Message     string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateCharge creates a PaymentIntent for grossAmount in major units; Stripe expects the
+// currency's smallest unit, so it is sent through minorUnits. orderID doubles as the idempotency key.
+// params["authorize_only"] makes it a manual-capture intent that only places a hold.
+func (s *StripeRepository) CreateCharge(ctx context.Context, orderID string, grossAmount int64, paymentType string, params map[string]interface{}) (string, string, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(s.minorUnits(grossAmount), 10))
+	form.Set("currency", s.currency)
+	form.Set("metadata[order_id]", orderID)
+	if paymentType != "" {
+		form.Set("payment_method_types[]", paymentType)
+	} else {
+		form.Set("automatic_payment_methods[enabled]", "true")
+	}
+	if authorize, _ := params["authorize_only"].(bool); authorize {
+		form.Set("capture_method", "manual")
+	}
+
+	var intent stripePaymentIntent
+	if err := s.do(ctx, http.MethodPost, "/payment_intents", form, orderID, &intent); err != nil {
+		logrus.WithError(err).WithField("order_id", orderID).Error("Stripe payment intent failed")
+		return "", "", err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"order_id":  orderID,
+		"intent_id": intent.ID,
+		"status":    intent.Status,
+	}).Info("Stripe payment intent created")
+
+	return intent.ID, intent.ClientSecret, nil
+}
+
+func (s *StripeRepository) GetStatus(ctx context.Context, transactionID string) (string, error) {
+	var intent stripePaymentIntent
+	if err := s.do(ctx, http.MethodGet, "/payment_intents/"+url.PathEscape(transactionID), nil, "", &intent); err != nil {
+		logrus.WithError(err).WithField("transaction_id", transactionID).Error("Stripe status check failed")
+		return "", fmt.Errorf("failed to check payment status: %w", err)
+	}
+	return intent.Status, nil
+}
+
+// Refund refunds amount in major units against the PaymentIntent
+func (s *StripeRepository) Refund(ctx context.Context, providerPaymentID string, amount int64) error {
+	form := url.Values{}
+	form.Set("payment_intent", providerPaymentID)
+	form.Set("amount", strconv.FormatInt(s.minorUnits(amount), 10))
+
+	var refund struct {
+		ID string `json:"id"`
+	}
+	idempotencyKey := fmt.Sprintf("%s-refund-%d", providerPaymentID, amount)
+	if err := s.do(ctx, http.MethodPost, "/refunds", form, idempotencyKey, &refund); err != nil {
+		logrus.WithError(err).WithField("intent_id", providerPaymentID).Error("Stripe refund failed")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"intent_id": providerPaymentID,
+		"refund_id": refund.ID,
+		"amount":    amount,
+	}).Info("Stripe refund created")
+	return nil
+}
+
+// Capture takes amount in major units from a manual-capture PaymentIntent; Stripe releases the rest
+func (s *StripeRepository) Capture(ctx context.Context, providerPaymentID string, amount int64) error {
+	form := url.Values{}
+	form.Set("amount_to_capture", strconv.FormatInt(s.minorUnits(amount), 10))
+
+	var intent stripePaymentIntent
+	idempotencyKey := fmt.Sprintf("%s-capture-%d", providerPaymentID, amount)
+	path := "/payment_intents/" + url.PathEscape(providerPaymentID) + "/capture"
+	if err := s.do(ctx, http.MethodPost, path, form, idempotencyKey, &intent); err != nil {
+		logrus.WithError(err).WithField("intent_id", providerPaymentID).Error("Stripe capture failed")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"intent_id": providerPaymentID,
+		"status":    intent.Status,
+		"amount":    amount,
+	}).Info("Stripe payment intent captured")
+	return nil
+}
+
+// Void cancels a manual-capture PaymentIntent, releasing the whole hold
+func (s *StripeRepository) Void(ctx context.Context, providerPaymentID string) error {
+	var intent stripePaymentIntent
+	path := "/payment_intents/" + url.PathEscape(providerPaymentID) + "/cancel"
+	if err := s.do(ctx, http.MethodPost, path, url.Values{}, providerPaymentID+"-void", &intent); err != nil {
+		logrus.WithError(err).WithField("intent_id", providerPaymentID).Error("Stripe void failed")
+		return err
+	}
+
+	logrus.WithField("intent_id", providerPaymentID).Info("Stripe payment intent cancelled")
+	return nil
+}
+
+// minorUnits converts amount in major units to the smallest unit of the configured currency
+func (s *StripeRepository) minorUnits(amount int64) int64 {
+	switch {
+	case stripeZeroDecimalCurrencies[s.currency]:
+		return amount
+	case stripeThreeDecimalCurrencies[s.currency]:
+		return amount * 1000
+	default:
+		return amount * 100
+	}
+}
+
+// VerifyNotification doesn't apply to Stripe, which signs the raw body in the
+// Stripe-Signature header instead of sending a signature_key field
+func (s *StripeRepository) VerifyNotification(orderID, statusCode, grossAmount, signatureKey string) bool {
+	return false
+}
+
+// VerifyWebhookSignature checks the Stripe-Signature header against the raw request body
+func (s *StripeRepository) VerifyWebhookSignature(payload []byte, signatureHeader string) bool {
+	return verifyStripeSignature(payload, signatureHeader, s.webhookSecret, time.Now())
+}
+
+// verifyStripeSignature accepts the header when any v1 signature is the HMAC-SHA256 of
+// "<t>.<payload>" under secret and t is within stripeSignatureTolerance of now
+func verifyStripeSignature(payload []byte, header, secret string, now time.Time) bool {
+	if secret == "" || header == "" {
+		return false
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return false
+	}
+	age := now.Sub(time.Unix(unix, 0))
+	if age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(expected), []byte(signature)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *StripeRepository) do(ctx context.Context, method, path string, form url.Values, idempotencyKey string, out interface{}) error {
+	var body *strings.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	} else {
+		body = strings.NewReader("")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(s.secretKey, "")
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("payment gateway error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var stripeErr stripeErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&stripeErr)
+		if stripeErr.Error.Type == "card_error" {
+			return fmt.Errorf("%w: %s", ErrPaymentDeclined, stripeErr.Error.Message)
+		}
+		return fmt.Errorf("payment gateway error: status=%d message=%s", resp.StatusCode, stripeErr.Error.Message)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid gateway response: %w", err)
+	}
+	return nil
+}
+
+// MapStripeEventToInternal maps a Stripe PaymentIntent webhook event type to an internal status
+func MapStripeEventToInternal(eventType string) string {
+	switch eventType {
+	case "payment_intent.succeeded":
+		return "paid"
+	case "payment_intent.amount_capturable_updated":
+		return "authorized"
+	case "payment_intent.processing", "payment_intent.requires_action":
+		return "pending"
+	case "payment_intent.payment_failed", "payment_intent.canceled":
+		return "failed"
+	default:
+		return eventType
+	}
+}
@@ -0,0 +1,148 @@
+package transaction
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStripeRepository(t *testing.T, handler http.HandlerFunc) *StripeRepository {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &StripeRepository{
+		client:    server.Client(),
+		baseURL:   server.URL,
+		secretKey: "sk_test_123",
+		currency:  "idr",
+	}
+}
+
+func TestStripeCreateCharge_Success(t *testing.T) {
+	repo := newTestStripeRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		user, _, _ := r.BasicAuth()
+		assert.Equal(t, "sk_test_123", user)
+		assert.Equal(t, "/payment_intents", r.URL.Path)
+		assert.Equal(t, "booking-7", r.Header.Get("Idempotency-Key"))
+		assert.Equal(t, "15000000", r.PostForm.Get("amount"))
+		assert.Equal(t, "idr", r.PostForm.Get("currency"))
+		assert.Equal(t, "booking-7", r.PostForm.Get("metadata[order_id]"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"pi_123","status":"requires_payment_method","client_secret":"pi_123_secret_abc"}`))
+	})
+
+	intentID, clientSecret, err := repo.CreateCharge(context.Background(), "booking-7", 150000, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "pi_123", intentID)
+	assert.Equal(t, "pi_123_secret_abc", clientSecret)
+}
+
+func TestStripeCreateCharge_DeclinedCard(t *testing.T) {
+	repo := newTestStripeRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"error":{"type":"card_error","code":"card_declined","decline_code":"insufficient_funds","message":"Your card has insufficient funds."}}`))
+	})
+
+	_, _, err := repo.CreateCharge(context.Background(), "booking-7", 150000, "card", nil)
+	assert.True(t, errors.Is(err, ErrPaymentDeclined))
+}
+
+func TestStripeCreateCharge_AuthorizeOnlyUsesManualCapture(t *testing.T) {
+	repo := newTestStripeRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "manual", r.PostForm.Get("capture_method"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"pi_123","status":"requires_payment_method","client_secret":"pi_123_secret_abc"}`))
+	})
+
+	_, _, err := repo.CreateCharge(context.Background(), "booking-7", 150000, "card", map[string]interface{}{"authorize_only": true})
+	require.NoError(t, err)
+}
+
+func TestStripeCapture_SendsAmountToCapture(t *testing.T) {
+	repo := newTestStripeRepository(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "/payment_intents/pi_123/capture", r.URL.Path)
+		assert.Equal(t, "pi_123-capture-100000", r.Header.Get("Idempotency-Key"))
+		assert.Equal(t, "10000000", r.PostForm.Get("amount_to_capture"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"pi_123","status":"succeeded"}`))
+	})
+
+	require.NoError(t, repo.Capture(context.Background(), "pi_123", 100000))
+}
+
+func TestStripeRefund_UsesCurrencyMinorUnits(t *testing.T) {
+	cases := []struct {
+		currency string
+		want     string
+	}{
+		{"idr", "5000000"}, // Stripe takes IDR in hundredths
+		{"jpy", "50000"},
+		{"kwd", "50000000"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.currency, func(t *testing.T) {
+			repo := newTestStripeRepository(t, func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, tc.want, r.PostForm.Get("amount"))
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"id":"re_123"}`))
+			})
+			repo.currency = tc.currency
+
+			require.NoError(t, repo.Refund(context.Background(), "pi_123", 50000))
+		})
+	}
+}
+
+func stripeSignatureHeader(payload []byte, secret string, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(payload)))
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyStripeSignature(t *testing.T) {
+	payload := []byte(`{"type":"payment_intent.succeeded","data":{"object":{"id":"pi_123"}}}`)
+	now := time.Unix(1700000000, 0)
+	valid := stripeSignatureHeader(payload, "whsec_test", now)
+
+	cases := []struct {
+		name    string
+		payload []byte
+		header  string
+		secret  string
+		want    bool
+	}{
+		{"valid", payload, valid, "whsec_test", true},
+		{"valid among rolled secrets", payload, valid + ",v1=deadbeef", "whsec_test", true},
+		{"wrong secret", payload, valid, "whsec_other", false},
+		{"tampered body", []byte(`{"type":"payment_intent.succeeded","data":{"object":{"id":"pi_999"}}}`), valid, "whsec_test", false},
+		{"stale timestamp", payload, stripeSignatureHeader(payload, "whsec_test", now.Add(-6*time.Minute)), "whsec_test", false},
+		{"missing header", payload, "", "whsec_test", false},
+		{"secret not configured", payload, valid, "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, verifyStripeSignature(tc.payload, tc.header, tc.secret, now))
+		})
+	}
+}
@@ -1,21 +1,63 @@
 package repository
 
 import (
+	"time"
+
+	"github.com/yoockh/go-api-utils/pkg-echo/orm"
 	"github.com/yoockh/go-game-rental-api/internal/model"
 	"gorm.io/gorm"
 )
 
+// UserSort orders admin user listings
+type UserSort string
+
+const (
+	UserSortCreatedDesc   UserSort = "created_desc"
+	UserSortCreatedAsc    UserSort = "created_asc"
+	UserSortLastLoginDesc UserSort = "last_login_desc"
+	UserSortLastLoginAsc  UserSort = "last_login_asc"
+	UserSortNameAsc       UserSort = "name_asc"
+	UserSortNameDesc      UserSort = "name_desc"
+)
+
+// userSortOrders maps each UserSort to its ORDER BY. id breaks ties so pages don't overlap,
+// and users who never logged in sort last either way.
+var userSortOrders = map[UserSort]string{
+	UserSortCreatedDesc:   "created_at DESC, id DESC",
+	UserSortCreatedAsc:    "created_at ASC, id ASC",
+	UserSortLastLoginDesc: "last_login_at DESC NULLS LAST, id DESC",
+	UserSortLastLoginAsc:  "last_login_at ASC NULLS LAST, id ASC",
+	UserSortNameAsc:       "full_name ASC, id ASC",
+	UserSortNameDesc:      "full_name DESC, id DESC",
+}
+
+// IsValid reports whether s is a known sort
+func (s UserSort) IsValid() bool {
+	_, ok := userSortOrders[s]
+	return ok
+}
+
+// UserFilter narrows admin user listings. Zero values mean "no filter".
+type UserFilter struct {
+	Role     model.UserRole
+	IsActive *bool
+	// Sort orders GetAll; empty means UserSortCreatedDesc. Count ignores it.
+	Sort UserSort
+}
+
 type UserRepository interface {
 	Create(user *model.User) error
+	CreateWithToken(user *model.User, token *model.EmailVerificationToken) error
 	GetByID(id uint) (*model.User, error)
 	GetByEmail(email string) (*model.User, error)
 	Update(user *model.User) error
 	Delete(id uint) error
 
-	GetAll(limit, offset int) ([]*model.User, error)
+	GetAll(filter UserFilter, limit, offset int) ([]*model.User, error)
 	UpdateRole(userID uint, newRole model.UserRole) error
 	UpdateActiveStatus(userID uint, isActive bool) error
-	Count() (int64, error)
+	UpdateLastLogin(userID uint, at time.Time) error
+	Count(filter UserFilter) (int64, error)
 }
 
 type userRepository struct {
@@ -30,6 +72,18 @@ func (r *userRepository) Create(user *model.User) error {
 	return r.db.Create(user).Error
 }
 
+// CreateWithToken inserts user and its first token in one transaction, so a failed token
+// insert can't leave behind an account that nobody received a link for
+func (r *userRepository) CreateWithToken(user *model.User, token *model.EmailVerificationToken) error {
+	return orm.WithTransaction(r.db, func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		token.UserID = user.ID
+		return tx.Create(token).Error
+	})
+}
+
 func (r *userRepository) GetByID(id uint) (*model.User, error) {
 	var user model.User
 	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
@@ -38,9 +92,10 @@ func (r *userRepository) GetByID(id uint) (*model.User, error) {
 	return &user, nil
 }
 
+// GetByEmail matches case-insensitively so legacy mixed-case rows are still found.
 func (r *userRepository) GetByEmail(email string) (*model.User, error) {
 	var user model.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.Where("LOWER(email) = LOWER(?)", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -55,9 +110,13 @@ func (r *userRepository) Delete(id uint) error {
 	return r.db.Unscoped().Delete(&model.User{}, id).Error
 }
 
-func (r *userRepository) GetAll(limit, offset int) ([]*model.User, error) {
+func (r *userRepository) GetAll(filter UserFilter, limit, offset int) ([]*model.User, error) {
 	var users []*model.User
-	err := r.db.Limit(limit).Offset(offset).Find(&users).Error
+	order, ok := userSortOrders[filter.Sort]
+	if !ok {
+		order = userSortOrders[UserSortCreatedDesc]
+	}
+	err := r.applyFilter(r.db, filter).Order(order).Limit(limit).Offset(offset).Find(&users).Error
 	return users, err
 }
 
@@ -69,8 +128,23 @@ func (r *userRepository) UpdateActiveStatus(userID uint, isActive bool) error {
 	return r.db.Model(&model.User{}).Where("id = ?", userID).Update("is_active", isActive).Error
 }
 
-func (r *userRepository) Count() (int64, error) {
+// UpdateLastLogin writes the column directly so updated_at keeps meaning "profile changed"
+func (r *userRepository) UpdateLastLogin(userID uint, at time.Time) error {
+	return r.db.Model(&model.User{}).Where("id = ?", userID).UpdateColumn("last_login_at", at).Error
+}
+
+func (r *userRepository) Count(filter UserFilter) (int64, error) {
 	var count int64
-	err := r.db.Model(&model.User{}).Count(&count).Error
+	err := r.applyFilter(r.db.Model(&model.User{}), filter).Count(&count).Error
 	return count, err
 }
+
+func (r *userRepository) applyFilter(query *gorm.DB, filter UserFilter) *gorm.DB {
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	return query
+}
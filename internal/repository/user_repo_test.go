@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+)
+
+// ============= TEST GET ALL SORT =============
+func TestUserGetAll_SortsByLastLogin(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY last_login_at DESC NULLS LAST, id DESC`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	users, err := repo.GetAll(UserFilter{Sort: UserSortLastLoginDesc}, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST GET ALL DEFAULT SORT =============
+func TestUserGetAll_DefaultsToNewestFirst(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY created_at DESC, id DESC`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.GetAll(UserFilter{}, 10, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ============= TEST CREATE =============
+func TestUserCreate_InsertsInactiveAccount(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	repo := NewUserRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+		WithArgs("new@example.com", sqlmock.AnyArg(), "New User", nil, nil, model.RoleCustomer, false, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	err := repo.Create(&model.User{Email: "new@example.com", Password: "hash", FullName: "New User", Role: model.RoleCustomer, IsActive: false})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserCreate_KeepsExplicitRoleAndStatus(t *testing.T) {
+	for _, active := range []bool{true, false} {
+		db, mock, _ := newMockDB(t)
+		repo := NewUserRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+			WithArgs("staff@example.com", sqlmock.AnyArg(), "Staff", nil, nil, model.RoleAdmin, active, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		err := repo.Create(&model.User{Email: "staff@example.com", Password: "hash", FullName: "Staff", Role: model.RoleAdmin, IsActive: active})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}
+}
+
+// ============= TEST CREATE USER WITH TOKEN =============
+func TestCreateWithToken_RollsBackUserWhenTokenFails(t *testing.T) {
+	db, mock, _ := newMockDB(t)
+	mock.MatchExpectationsInOrder(true)
+	repo := NewUserRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "email_verification_tokens"`)).
+		WillReturnError(errors.New("connection reset"))
+	// No orphan account is left to block a retry with the same email
+	mock.ExpectRollback()
+
+	token := &model.EmailVerificationToken{TokenHash: "hash", Purpose: model.TokenPasswordReset}
+	err := repo.CreateWithToken(&model.User{Email: "staff@example.com", Role: model.RoleAdmin}, token)
+	assert.Error(t, err)
+	assert.Equal(t, uint(8), token.UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,38 +2,82 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/yoockh/go-game-rental-api/internal/dto"
 	"github.com/yoockh/go-game-rental-api/internal/model"
 	"github.com/yoockh/go-game-rental-api/internal/repository"
 	"github.com/yoockh/go-game-rental-api/internal/repository/email"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
 
 var (
-	ErrBookingNotFound       = errors.New("booking not found")
-	ErrBookingNotOwned       = errors.New("you don't own this booking")
-	ErrBookingInvalidDate    = errors.New("invalid booking dates")
-	ErrBookingCannotCancel   = errors.New("cannot cancel booking in current status")
-	ErrGameStockInsufficient = errors.New("insufficient stock")
+	ErrBookingNotFound       = utils.NewNotFoundError("booking_not_found", "booking not found")
+	ErrBookingNotOwned       = utils.NewForbiddenError("booking_not_owned", "you don't own this booking")
+	ErrBookingInvalidDate    = utils.NewBadRequestError("booking_invalid_date", "invalid booking dates")
+	ErrBookingCannotCancel   = utils.NewConflictError("booking_cannot_cancel", "cannot cancel booking in current status")
+	ErrBookingNotPending     = utils.NewConflictError("booking_not_pending", "booking is not in pending status")
+	ErrGameStockInsufficient = utils.NewConflictError("game_stock_insufficient", "insufficient stock")
+	ErrGameNotAvailable      = utils.NewBadRequestError("game_not_available", "game is not available for booking")
+	ErrBookingAmountTooLow   = utils.NewBadRequestError("booking_amount_too_low", "booking total is below the minimum payable amount")
+	ErrBookingTooFarAhead    = utils.NewBadRequestError("booking_too_far_ahead", "booking start date is too far in the future")
+	ErrBookingCannotDelete   = utils.NewConflictError("booking_cannot_delete", "cannot delete a booking whose game is still out")
+	ErrBookingStatusChanged  = utils.NewConflictError("booking_status_changed", "booking status changed meanwhile; reload it and try again")
+	ErrBookingRestoreExpired = utils.NewConflictError("booking_restore_expired", "booking was deleted too long ago to restore")
+	ErrBookingNoConfirmation = utils.NewConflictError("booking_no_confirmation", "cancelled bookings have no confirmation email to resend")
+	ErrBookingCannotExtend   = utils.NewConflictError("booking_cannot_extend", "only active bookings can be extended")
+	ErrBookingExtendDate     = utils.NewBadRequestError("booking_extend_date", "new end date must be after the current end date")
+	ErrBookingExtendTooFar   = utils.NewBadRequestError("booking_extend_too_far_ahead", "new end date is too far in the future")
+	ErrBookingTermsRequired  = utils.NewBadRequestError("booking_terms_required", "you must accept this game's rental terms")
+	ErrBookingNotReturnable  = utils.NewConflictError("booking_not_returnable", "only active or overdue bookings can be returned")
+	ErrResendTooSoon         = utils.NewServiceError("resend_too_soon", http.StatusTooManyRequests, "confirmation was resent recently, try again later")
+	ErrEmailSendFailed       = utils.NewServiceError("email_send_failed", http.StatusBadGateway, "failed to send email")
 )
 
+// bookingRestoreWindow is how long a soft-deleted booking can still be restored
+const bookingRestoreWindow = 30 * 24 * time.Hour
+
+// resendConfirmationCooldown limits each booking to one resent confirmation per window
+const resendConfirmationCooldown = 10 * time.Minute
+
+// minBookingAmount is the smallest gross amount the payment gateway accepts (IDR 1).
+const minBookingAmount = 1.0
+
 type BookingService interface {
 	// Customer
-	Create(userID uint, bookingData *model.Booking) error
-	GetUserBookings(userID uint, limit, offset int) ([]*model.Booking, int64, error)
+	Create(ctx context.Context, userID uint, bookingData *model.Booking) error
+	GetUserBookings(userID uint, status model.BookingStatus, limit, offset int) ([]*model.Booking, int64, error)
 	GetByID(userID uint, bookingID uint) (*model.Booking, error)
-	Cancel(userID uint, bookingID uint) error
+	GetDepositStatus(userID uint, bookingID uint) (*dto.DepositSummary, error)
+	Cancel(userID uint, bookingID uint, reason string) error
+	ExtendBooking(userID, bookingID uint, newEndDate time.Time) error
+	GetUserBookingStats(userID uint) (*dto.UserBookingStats, error)
+
+	// Public
+	Quote(gameID uint, startDate, endDate time.Time) (*dto.BookingQuoteResponse, error)
 
 	// Admin
 	GetAll(requestorRole model.UserRole, limit, offset int) ([]*model.Booking, int64, error)
-	UpdateStatus(requestorRole model.UserRole, bookingID uint, status model.BookingStatus) error
+	Search(requestorRole model.UserRole, query string, limit, offset int) ([]*model.Booking, int64, error)
+	UpdateStatus(ctx context.Context, requestorID uint, requestorRole model.UserRole, bookingID uint, status model.BookingStatus) error
+	ConfirmReturn(requestorID uint, requestorRole model.UserRole, bookingID uint, returnedAt time.Time) (*model.Booking, error)
+	SoftDelete(requestorID uint, requestorRole model.UserRole, bookingID uint) error
+	Restore(requestorID uint, requestorRole model.UserRole, bookingID uint) error
+	GetAuditLog(requestorRole model.UserRole, bookingID uint, limit, offset int) ([]*model.BookingAudit, int64, error)
+	ResendConfirmation(ctx context.Context, requestorID uint, requestorRole model.UserRole, bookingID uint) (string, error)
 
 	// System (for payment)
-	ConfirmPayment(bookingID uint) error
+	ConfirmPayment(ctx context.Context, bookingID uint) error
 	FailPayment(bookingID uint) error
+	ExpirePayment(bookingID uint) error
+
+	// System (for scheduled jobs)
+	ProcessOverdueBookings() (int, error)
 }
 
 type bookingService struct {
@@ -41,6 +85,15 @@ type bookingService struct {
 	gameRepo    repository.GameRepository
 	userRepo    repository.UserRepository
 	emailRepo   email.EmailRepository
+	notifier    NotificationService
+	auditRepo   repository.BookingAuditRepository
+	// maxAdvanceDays caps how far ahead StartDate may be, so stock isn't locked months out
+	maxAdvanceDays int
+
+	// lastResent tracks confirmation resends per booking still inside the cooldown;
+	// in-memory, so per instance
+	resendMu   sync.Mutex
+	lastResent map[uint]time.Time
 }
 
 func NewBookingService(
@@ -48,30 +101,75 @@ func NewBookingService(
 	gameRepo repository.GameRepository,
 	userRepo repository.UserRepository,
 	emailRepo email.EmailRepository,
+	notifier NotificationService,
+	auditRepo repository.BookingAuditRepository,
+	maxAdvanceDays int,
 ) BookingService {
 	return &bookingService{
-		bookingRepo: bookingRepo,
-		gameRepo:    gameRepo,
-		userRepo:    userRepo,
-		emailRepo:   emailRepo,
+		bookingRepo:    bookingRepo,
+		gameRepo:       gameRepo,
+		userRepo:       userRepo,
+		emailRepo:      emailRepo,
+		notifier:       notifier,
+		auditRepo:      auditRepo,
+		maxAdvanceDays: maxAdvanceDays,
+		lastResent:     make(map[uint]time.Time),
+	}
+}
+
+// CalculateCost prices a rental of game over the inclusive [startDate, endDate] range.
+// It validates the dates and the gateway minimum but never touches stock.
+func CalculateCost(game *model.Game, startDate, endDate time.Time) (*dto.BookingCost, error) {
+	if startDate.After(endDate) || startDate.Before(time.Now().Truncate(24*time.Hour)) {
+		return nil, ErrBookingInvalidDate
 	}
+
+	rentalDays := int(endDate.Sub(startDate).Hours()/24) + 1
+	totalRentalPrice := float64(rentalDays) * game.RentalPricePerDay
+	totalAmount := totalRentalPrice + game.SecurityDeposit
+
+	// Catch misconfigured free games before they reach the gateway
+	if totalAmount < minBookingAmount {
+		return nil, ErrBookingAmountTooLow
+	}
+
+	return &dto.BookingCost{
+		RentalDays:       rentalDays,
+		DailyPrice:       game.RentalPricePerDay,
+		TotalRentalPrice: totalRentalPrice,
+		SecurityDeposit:  game.SecurityDeposit,
+		TotalAmount:      totalAmount,
+	}, nil
 }
 
-func (s *bookingService) Create(userID uint, bookingData *model.Booking) error {
+func (s *bookingService) Create(ctx context.Context, userID uint, bookingData *model.Booking) error {
+	if bookingData.StartDate.After(s.latestBookableDate()) {
+		return ErrBookingTooFarAhead
+	}
+
 	game, err := s.gameRepo.GetByID(bookingData.GameID)
 	if err != nil {
 		return ErrGameNotFound
 	}
 
 	if !game.IsActive {
-		return errors.New("game is not available for booking")
+		return ErrGameNotAvailable
 	}
 
-	if bookingData.StartDate.After(bookingData.EndDate) || bookingData.StartDate.Before(time.Now().Truncate(24*time.Hour)) {
-		return ErrBookingInvalidDate
+	// Acceptance only means something when there were terms to accept
+	if game.RentalTerms == nil {
+		bookingData.TermsAcceptedAt = nil
+	} else if bookingData.TermsAcceptedAt == nil {
+		return ErrBookingTermsRequired
 	}
 
-	available, err := s.gameRepo.CheckAvailability(game.ID)
+	cost, err := CalculateCost(game, bookingData.StartDate, bookingData.EndDate)
+	if err != nil {
+		return err
+	}
+
+	// A copy must be free for the whole requested window, not just in stock right now
+	available, err := s.gameRepo.CheckAvailabilityForRange(game.ID, bookingData.StartDate, bookingData.EndDate)
 	if err != nil {
 		return err
 	}
@@ -79,53 +177,27 @@ func (s *bookingService) Create(userID uint, bookingData *model.Booking) error {
 		return ErrGameStockInsufficient
 	}
 
-	rentalDays := int(bookingData.EndDate.Sub(bookingData.StartDate).Hours()/24) + 1
-	totalRentalPrice := float64(rentalDays) * game.RentalPricePerDay
-	totalAmount := totalRentalPrice + game.SecurityDeposit
-
 	bookingData.UserID = userID
-	bookingData.RentalDays = rentalDays
-	bookingData.DailyPrice = game.RentalPricePerDay
-	bookingData.TotalRentalPrice = totalRentalPrice
-	bookingData.SecurityDeposit = game.SecurityDeposit
-	bookingData.TotalAmount = totalAmount
+	bookingData.RentalDays = cost.RentalDays
+	bookingData.DailyPrice = cost.DailyPrice
+	bookingData.TotalRentalPrice = cost.TotalRentalPrice
+	bookingData.SecurityDeposit = cost.SecurityDeposit
+	bookingData.TotalAmount = cost.TotalAmount
 	bookingData.Status = model.BookingPending
 
-	err = s.gameRepo.ReserveStock(game.ID)
-	if err != nil {
+	if err := s.bookingRepo.CreateWithStockReservation(bookingData); err != nil {
 		return err
 	}
 
-	if err := s.bookingRepo.Create(bookingData); err != nil {
-		return err
-	}
+	s.notifier.Notify(userID, model.NotificationBookingCreated, "Booking created",
+		fmt.Sprintf("Your booking for %s is waiting for payment.", game.Name), &bookingData.ID)
 
 	// SEND EMAIL: Booking confirmation
 	user, _ := s.userRepo.GetByID(userID)
 	if user != nil {
+		mailCtx := context.WithoutCancel(ctx)
 		go func() {
-			subject := "Booking Confirmation - Game Rental"
-			platform := "Unknown"
-			if game.Platform != nil {
-				platform = *game.Platform
-			}
-			htmlContent := fmt.Sprintf(`
-				<h1>Booking Confirmation</h1>
-				<p>Hi %s,</p>
-				<p>Your booking has been created successfully!</p>
-				<h3>Details:</h3>
-				<ul>
-					<li><strong>Game:</strong> %s</li>
-					<li><strong>Platform:</strong> %s</li>
-					<li><strong>Period:</strong> %s to %s (%d days)</li>
-					<li><strong>Total:</strong> Rp %.0f</li>
-				</ul>
-				<p><strong>Next:</strong> Please complete the payment.</p>
-			`, user.FullName, game.Name, platform, bookingData.StartDate.Format("2006-01-02"), bookingData.EndDate.Format("2006-01-02"), rentalDays, totalAmount)
-
-			plainText := fmt.Sprintf("Booking confirmed for %s. Total: Rp %.0f", game.Name, totalAmount)
-
-			if err := s.emailRepo.SendEmail(context.Background(), user.Email, subject, plainText, htmlContent); err != nil {
+			if err := s.sendBookingConfirmationEmail(mailCtx, user, game, bookingData); err != nil {
 				logrus.WithError(err).Error("Failed to send booking email")
 			}
 		}()
@@ -134,13 +206,13 @@ func (s *bookingService) Create(userID uint, bookingData *model.Booking) error {
 	return nil
 }
 
-func (s *bookingService) GetUserBookings(userID uint, limit, offset int) ([]*model.Booking, int64, error) {
-	bookings, err := s.bookingRepo.GetUserBookings(userID, limit, offset)
+func (s *bookingService) GetUserBookings(userID uint, status model.BookingStatus, limit, offset int) ([]*model.Booking, int64, error) {
+	bookings, err := s.bookingRepo.GetUserBookings(userID, status, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	count, err := s.bookingRepo.CountUserBookings(userID)
+	count, err := s.bookingRepo.CountUserBookings(userID, status)
 	return bookings, count, err
 }
 
@@ -157,7 +229,55 @@ func (s *bookingService) GetByID(userID uint, bookingID uint) (*model.Booking, e
 	return booking, nil
 }
 
-func (s *bookingService) Cancel(userID uint, bookingID uint) error {
+// GetDepositStatus summarizes the deposit of one of the user's bookings
+func (s *bookingService) GetDepositStatus(userID uint, bookingID uint) (*dto.DepositSummary, error) {
+	booking, err := s.GetByID(userID, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := SummarizeDeposit(booking)
+	return &summary, nil
+}
+
+// SummarizeDeposit derives the deposit state from the booking's rental payment (preloaded
+// as booking.Payment). A capture records whether it released the deposit, and a void always
+// does. Charged deposits stay held until refunded, and a partial
+// refund reports the refunded part, capped at the deposit.
+func SummarizeDeposit(booking *model.Booking) dto.DepositSummary {
+	summary := dto.DepositSummary{Status: model.DepositNone}
+	payment := booking.Payment
+	if booking.SecurityDeposit <= 0 || payment == nil {
+		return summary
+	}
+	summary.Mode = payment.DepositMode
+
+	deposit := booking.SecurityDeposit
+	switch payment.Status {
+	case model.PaymentAuthorized:
+		summary.Status, summary.Amount = model.DepositHeld, deposit
+	case model.PaymentVoided:
+		summary.Status, summary.Amount = model.DepositRefunded, deposit
+	case model.PaymentPaid:
+		switch {
+		case payment.DepositMode != model.DepositHold:
+			summary.Status, summary.Amount = model.DepositHeld, deposit
+		case payment.DepositReleased:
+			summary.Status, summary.Amount = model.DepositRefunded, deposit
+		default:
+			summary.Status, summary.Amount = model.DepositForfeited, deposit
+		}
+	case model.PaymentRefunded:
+		refunded := deposit
+		if payment.RefundAmount != nil && *payment.RefundAmount < deposit {
+			refunded = *payment.RefundAmount
+		}
+		summary.Status, summary.Amount = model.DepositRefunded, refunded
+	}
+	return summary
+}
+
+func (s *bookingService) Cancel(userID uint, bookingID uint, reason string) error {
 	booking, err := s.bookingRepo.GetByID(bookingID)
 	if err != nil {
 		return ErrBookingNotFound
@@ -176,7 +296,103 @@ func (s *bookingService) Cancel(userID uint, bookingID uint) error {
 		return err
 	}
 
-	return s.bookingRepo.UpdateStatus(bookingID, model.BookingCancelled)
+	var reasonPtr *string
+	if reason = strings.TrimSpace(reason); reason != "" {
+		reasonPtr = &reason
+	}
+	return s.bookingRepo.MarkCancelled(bookingID, reasonPtr)
+}
+
+// ExtendBooking moves an active booking's end date out and reprices it at the daily price
+// locked in when it was booked. Only the added days need a free copy, since the current
+// range is already held. The extra cost accumulates in ExtensionAmountDue for a follow-up payment.
+func (s *bookingService) ExtendBooking(userID, bookingID uint, newEndDate time.Time) error {
+	booking, err := s.GetByID(userID, bookingID)
+	if err != nil {
+		return err
+	}
+	if booking.Status != model.BookingActive {
+		return ErrBookingCannotExtend
+	}
+	if !newEndDate.After(booking.EndDate) {
+		return ErrBookingExtendDate
+	}
+	// Extending can't hold a copy further out than a new booking could reserve it
+	if newEndDate.After(s.latestBookableDate()) {
+		return ErrBookingExtendTooFar
+	}
+
+	available, err := s.gameRepo.CheckAvailabilityForRange(booking.GameID, booking.EndDate.AddDate(0, 0, 1), newEndDate)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return ErrGameStockInsufficient
+	}
+
+	previousTotal := booking.TotalAmount
+	booking.EndDate = newEndDate
+	booking.RentalDays = int(newEndDate.Sub(booking.StartDate).Hours()/24) + 1
+	booking.TotalRentalPrice = float64(booking.RentalDays) * booking.DailyPrice
+	booking.TotalAmount = booking.TotalRentalPrice + booking.SecurityDeposit
+	booking.ExtensionAmountDue += booking.TotalAmount - previousTotal
+
+	applied, err := s.bookingRepo.ApplyExtension(booking)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return ErrBookingCannotExtend
+	}
+	return nil
+}
+
+// Quote prices a prospective booking for guests without creating anything or reserving stock
+func (s *bookingService) Quote(gameID uint, startDate, endDate time.Time) (*dto.BookingQuoteResponse, error) {
+	game, err := s.gameRepo.GetByID(gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	if !game.IsActive {
+		return nil, ErrGameNotAvailable
+	}
+
+	cost, err := CalculateCost(game, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	available, err := s.gameRepo.CheckAvailabilityForRange(gameID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.BookingQuoteResponse{
+		GameID:      gameID,
+		StartDate:   startDate.Format("2006-01-02"),
+		EndDate:     endDate.Format("2006-01-02"),
+		BookingCost: *cost,
+		Available:   available,
+		RentalTerms: game.RentalTerms,
+	}, nil
+}
+
+// GetUserBookingStats counts the user's bookings; active covers confirmed and active rentals
+func (s *bookingService) GetUserBookingStats(userID uint) (*dto.UserBookingStats, error) {
+	counts, err := s.bookingRepo.CountUserBookingsByStatus(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &dto.UserBookingStats{
+		ActiveBookings:    counts[model.BookingConfirmed] + counts[model.BookingActive] + counts[model.BookingOverdue],
+		CompletedBookings: counts[model.BookingCompleted],
+	}
+	for _, count := range counts {
+		stats.TotalBookings += count
+	}
+	return stats, nil
 }
 
 func (s *bookingService) GetAll(requestorRole model.UserRole, limit, offset int) ([]*model.Booking, int64, error) {
@@ -193,7 +409,95 @@ func (s *bookingService) GetAll(requestorRole model.UserRole, limit, offset int)
 	return bookings, count, err
 }
 
-func (s *bookingService) UpdateStatus(requestorRole model.UserRole, bookingID uint, status model.BookingStatus) error {
+func (s *bookingService) Search(requestorRole model.UserRole, query string, limit, offset int) ([]*model.Booking, int64, error) {
+	if !s.canManageBookings(requestorRole) {
+		return nil, 0, ErrInsufficientPermission
+	}
+
+	query = strings.TrimSpace(query)
+
+	bookings, err := s.bookingRepo.Search(query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := s.bookingRepo.CountSearch(query)
+	return bookings, count, err
+}
+
+// SoftDelete hides a booking from every list. A booking still holding stock is
+// cancelled first, so restoring it later never double-books a copy.
+func (s *bookingService) SoftDelete(requestorID uint, requestorRole model.UserRole, bookingID uint) error {
+	if !s.canManageBookings(requestorRole) {
+		return ErrInsufficientPermission
+	}
+
+	booking, err := s.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return ErrBookingNotFound
+	}
+
+	// The copy is physically with the customer; hiding the booking would free it for others
+	if booking.Status == model.BookingActive || booking.Status == model.BookingOverdue {
+		return ErrBookingCannotDelete
+	}
+
+	changes := model.FieldChanges{}
+	if booking.Status == model.BookingPending || booking.Status == model.BookingConfirmed {
+		// Only the call that wins the transition releases the copy, so a concurrent cancel
+		// or payment failure can't release it twice
+		cancelled, err := s.bookingRepo.TransitionStatus(bookingID, booking.Status, model.BookingCancelled)
+		if err != nil {
+			return err
+		}
+		if !cancelled {
+			return ErrBookingStatusChanged
+		}
+		if err := s.gameRepo.ReleaseStock(booking.GameID); err != nil {
+			return err
+		}
+		reason := "deleted by admin"
+		if err := s.bookingRepo.MarkCancelled(bookingID, &reason); err != nil {
+			return err
+		}
+		changes["status"] = model.FieldChange{Old: booking.Status, New: model.BookingCancelled}
+	}
+
+	if err := s.bookingRepo.SoftDelete(bookingID); err != nil {
+		return err
+	}
+
+	changes["deleted_at"] = model.FieldChange{Old: nil, New: time.Now()}
+	s.recordAudit(bookingID, requestorID, model.AuditDeleted, changes)
+	return nil
+}
+
+// Restore brings back a soft-deleted booking within bookingRestoreWindow
+func (s *bookingService) Restore(requestorID uint, requestorRole model.UserRole, bookingID uint) error {
+	if !s.canManageBookings(requestorRole) {
+		return ErrInsufficientPermission
+	}
+
+	booking, err := s.bookingRepo.GetDeletedByID(bookingID)
+	if err != nil {
+		return ErrBookingNotFound
+	}
+
+	if time.Since(booking.DeletedAt.Time) > bookingRestoreWindow {
+		return ErrBookingRestoreExpired
+	}
+
+	if err := s.bookingRepo.Restore(bookingID); err != nil {
+		return err
+	}
+
+	s.recordAudit(bookingID, requestorID, model.AuditRestored, model.FieldChanges{
+		"deleted_at": {Old: booking.DeletedAt.Time, New: nil},
+	})
+	return nil
+}
+
+func (s *bookingService) UpdateStatus(ctx context.Context, requestorID uint, requestorRole model.UserRole, bookingID uint, status model.BookingStatus) error {
 	if !s.canManageBookings(requestorRole) {
 		return ErrInsufficientPermission
 	}
@@ -207,10 +511,20 @@ func (s *bookingService) UpdateStatus(requestorRole model.UserRole, bookingID ui
 		return err
 	}
 
+	if booking.Status != status {
+		s.recordAudit(bookingID, requestorID, model.AuditStatusUpdated, model.FieldChanges{
+			"status": {Old: booking.Status, New: status},
+		})
+	}
+
+	s.notifier.Notify(booking.UserID, model.NotificationBookingStatus, "Booking status updated",
+		fmt.Sprintf("Your booking for %s is now %s.", booking.Game.Name, status), &booking.ID)
+
 	// SEND EMAIL: Status update
 	user, _ := s.userRepo.GetByID(booking.UserID)
 	game, _ := s.gameRepo.GetByID(booking.GameID)
 	if user != nil && game != nil {
+		mailCtx := context.WithoutCancel(ctx)
 		go func() {
 			subject := "Booking Status Updated - Game Rental"
 			statusMsg := ""
@@ -231,7 +545,7 @@ func (s *bookingService) UpdateStatus(requestorRole model.UserRole, bookingID ui
 
 			plainText := fmt.Sprintf("Booking status: %s for %s", status, game.Name)
 
-			if err := s.emailRepo.SendEmail(context.Background(), user.Email, subject, plainText, htmlContent); err != nil {
+			if err := s.emailRepo.SendEmail(mailCtx, user.Email, subject, plainText, htmlContent); err != nil {
 				logrus.WithError(err).Error("Failed to send status update email")
 			}
 		}()
@@ -240,47 +554,73 @@ func (s *bookingService) UpdateStatus(requestorRole model.UserRole, bookingID ui
 	return nil
 }
 
-func (s *bookingService) ConfirmPayment(bookingID uint) error {
+func (s *bookingService) ConfirmPayment(ctx context.Context, bookingID uint) error {
 	booking, err := s.bookingRepo.GetByID(bookingID)
 	if err != nil {
 		return ErrBookingNotFound
 	}
 
 	if booking.Status != model.BookingPending {
-		return errors.New("booking is not in pending status")
+		return ErrBookingNotPending
 	}
 
 	if err := s.bookingRepo.UpdateStatus(bookingID, model.BookingConfirmed); err != nil {
 		return err
 	}
 
+	s.notifier.Notify(booking.UserID, model.NotificationPaymentConfirmed, "Payment received",
+		fmt.Sprintf("Payment of Rp %.0f for %s is confirmed.", booking.TotalAmount, booking.Game.Name), &booking.ID)
+
 	// SEND EMAIL: Payment confirmed
 	user, _ := s.userRepo.GetByID(booking.UserID)
 	game, _ := s.gameRepo.GetByID(booking.GameID)
 	if user != nil && game != nil {
+		mailCtx := context.WithoutCancel(ctx)
 		go func() {
-			subject := "Payment Confirmed - Game Rental"
-			platform := "Unknown"
-			if game.Platform != nil {
-				platform = *game.Platform
+			if err := s.sendPaymentConfirmedEmail(mailCtx, user, game, booking); err != nil {
+				logrus.WithError(err).Error("Failed to send payment confirmation email")
 			}
+		}()
+	}
+
+	return nil
+}
+
+func (s *bookingService) FailPayment(bookingID uint) error {
+	_, err := s.cancelUnpaid(bookingID)
+	return err
+}
+
+// ExpirePayment cancels a booking whose gateway charge expired and invites the customer to re-book
+func (s *bookingService) ExpirePayment(bookingID uint) error {
+	booking, err := s.cancelUnpaid(bookingID)
+	if err != nil || booking == nil {
+		return err
+	}
+
+	s.notifier.Notify(booking.UserID, model.NotificationPaymentExpired, "Payment expired",
+		fmt.Sprintf("Your payment window for %s has closed. Create a new booking to rent it again.", booking.Game.Name), &booking.ID)
+
+	// SEND EMAIL: Payment expired
+	user, _ := s.userRepo.GetByID(booking.UserID)
+	if user != nil {
+		go func() {
+			subject := "Payment Expired - Game Rental"
 			htmlContent := fmt.Sprintf(`
-				<h1>Payment Successful!</h1>
+				<h1>Payment Expired</h1>
 				<p>Hi %s,</p>
-				<p>Your payment has been confirmed!</p>
-				<h3>Details:</h3>
+				<p>We didn't receive payment in time, so your booking has been cancelled.</p>
 				<ul>
 					<li><strong>Game:</strong> %s</li>
-					<li><strong>Platform:</strong> %s</li>
 					<li><strong>Period:</strong> %s to %s</li>
-					<li><strong>Amount:</strong> Rp %.0f</li>
 				</ul>
-			`, user.FullName, game.Name, platform, booking.StartDate.Format("2006-01-02"), booking.EndDate.Format("2006-01-02"), booking.TotalAmount)
+				<p><strong>Still want it?</strong> Create a new booking for %s and complete the payment to secure your copy.</p>
+			`, user.FullName, booking.Game.Name, booking.StartDate.Format("2006-01-02"), booking.EndDate.Format("2006-01-02"), booking.Game.Name)
 
-			plainText := fmt.Sprintf("Payment confirmed for %s", game.Name)
+			plainText := fmt.Sprintf("Payment for %s expired and the booking was cancelled. Create a new booking to rent it again.", booking.Game.Name)
 
 			if err := s.emailRepo.SendEmail(context.Background(), user.Email, subject, plainText, htmlContent); err != nil {
-				logrus.WithError(err).Error("Failed to send payment confirmation email")
+				logrus.WithError(err).Error("Failed to send payment expired email")
 			}
 		}()
 	}
@@ -288,20 +628,331 @@ func (s *bookingService) ConfirmPayment(bookingID uint) error {
 	return nil
 }
 
-func (s *bookingService) FailPayment(bookingID uint) error {
+// CalculateLateFee charges perDay for every calendar day between endDate and returnedAt.
+// A return any time on the end date itself is on time. Each time counts on its own calendar
+// date, so a 01:00 WIB return isn't pulled back to the previous day as UTC truncation would.
+func CalculateLateFee(endDate, returnedAt time.Time, perDay float64) (int, float64) {
+	lateDays := int(calendarDay(returnedAt).Sub(calendarDay(endDate)).Hours() / 24)
+	if lateDays <= 0 {
+		return 0, 0
+	}
+	return lateDays, float64(lateDays) * perDay
+}
+
+// calendarDay is midnight UTC of t's date in t's own location, so dates from different zones compare
+func calendarDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// ConfirmReturn completes an active or overdue booking, puts the copy back in stock and
+// charges the game's late fee for each day past EndDate as a pending late_fee payment
+func (s *bookingService) ConfirmReturn(requestorID uint, requestorRole model.UserRole, bookingID uint, returnedAt time.Time) (*model.Booking, error) {
+	if !s.canManageBookings(requestorRole) {
+		return nil, ErrInsufficientPermission
+	}
+
 	booking, err := s.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return ErrBookingNotFound
+		return nil, ErrBookingNotFound
+	}
+	if booking.Status != model.BookingActive && booking.Status != model.BookingOverdue {
+		return nil, ErrBookingNotReturnable
 	}
 
-	err = s.gameRepo.ReleaseStock(booking.GameID)
+	lateDays, lateFee := CalculateLateFee(booking.EndDate, returnedAt, booking.Game.LateFeePerDay)
+	changes := model.FieldChanges{
+		"status":      {Old: booking.Status, New: model.BookingCompleted},
+		"returned_at": {Old: booking.ReturnedAt, New: returnedAt},
+		"late_fee":    {Old: booking.LateFee, New: lateFee},
+	}
+	booking.ReturnedAt = &returnedAt
+	booking.LateFee = lateFee
+
+	var lateFeePayment *model.Payment
+	if lateFee > 0 {
+		provider := model.ProviderMidtrans
+		if booking.Payment != nil {
+			provider = booking.Payment.Provider
+		}
+		lateFeePayment = &model.Payment{
+			BookingID: booking.ID,
+			Provider:  provider,
+			Amount:    lateFee,
+			Status:    model.PaymentPending,
+			Purpose:   model.PaymentPurposeLateFee,
+		}
+	}
+
+	// Written in the return's transaction so a completed booking always has its audit entry
+	audit := newAuditEntry(bookingID, requestorID, model.AuditReturnConfirmed, changes)
+	completed, err := s.bookingRepo.CompleteReturn(booking, lateFeePayment, audit)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if !completed {
+		// Returned or changed by someone else since it was read
+		return nil, ErrBookingNotReturnable
+	}
+	booking.Status = model.BookingCompleted
+
+	message := fmt.Sprintf("Thanks for returning %s.", booking.Game.Name)
+	if lateFee > 0 {
+		message = fmt.Sprintf("%s returned %d day(s) late. A late fee of Rp %.0f is due.", booking.Game.Name, lateDays, lateFee)
 	}
+	s.notifier.Notify(booking.UserID, model.NotificationBookingStatus, "Return confirmed", message, &booking.ID)
+
+	logrus.WithFields(logrus.Fields{
+		"booking_id": booking.ID,
+		"late_days":  lateDays,
+		"late_fee":   lateFee,
+	}).Info("Booking return confirmed")
+
+	return booking, nil
+}
+
+// ProcessOverdueBookings marks active bookings whose end date has passed as overdue and tells
+// each customer. Returns how many bookings it moved; ones already moved elsewhere are skipped.
+func (s *bookingService) ProcessOverdueBookings() (int, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	bookings, err := s.bookingRepo.GetOverdueBookings(today)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, booking := range bookings {
+		moved, err := s.bookingRepo.TransitionStatus(booking.ID, model.BookingActive, model.BookingOverdue)
+		if err != nil {
+			logrus.WithError(err).WithField("booking_id", booking.ID).Error("Failed to mark booking overdue")
+			continue
+		}
+		if !moved {
+			continue
+		}
+		processed++
+		s.recordAudit(booking.ID, 0, model.AuditMarkedOverdue, model.FieldChanges{
+			"status": {Old: model.BookingActive, New: model.BookingOverdue},
+		})
+
+		s.notifier.Notify(booking.UserID, model.NotificationBookingStatus, "Booking overdue",
+			fmt.Sprintf("Your rental of %s ended on %s. Please return it as soon as possible.",
+				booking.Game.Name, booking.EndDate.Format("2006-01-02")), &booking.ID)
+
+		// SEND EMAIL: Overdue; sent inline since this runs as a batch, not on a customer request
+		subject := "Rental Overdue - Game Rental"
+		htmlContent := fmt.Sprintf(`
+			<h1>Rental Overdue</h1>
+			<p>Hi %s,</p>
+			<p>Your rental period has ended and we haven't received the game back yet.</p>
+			<ul>
+				<li><strong>Game:</strong> %s</li>
+				<li><strong>Due back:</strong> %s</li>
+			</ul>
+			<p>Please return it as soon as possible.</p>
+		`, booking.User.FullName, booking.Game.Name, booking.EndDate.Format("2006-01-02"))
+
+		plainText := fmt.Sprintf("Your rental of %s was due back on %s. Please return it as soon as possible.",
+			booking.Game.Name, booking.EndDate.Format("2006-01-02"))
+
+		if err := s.emailRepo.SendEmail(context.Background(), booking.User.Email, subject, plainText, htmlContent); err != nil {
+			logrus.WithError(err).WithField("booking_id", booking.ID).Error("Failed to send overdue email")
+		}
+	}
+
+	if processed > 0 {
+		logrus.WithField("count", processed).Info("Marked bookings overdue")
+	}
+	return processed, nil
+}
+
+// GetAuditLog lists the recorded changes to a booking, newest first
+func (s *bookingService) GetAuditLog(requestorRole model.UserRole, bookingID uint, limit, offset int) ([]*model.BookingAudit, int64, error) {
+	if !s.canManageBookings(requestorRole) {
+		return nil, 0, ErrInsufficientPermission
+	}
+
+	entries, err := s.auditRepo.GetByBooking(bookingID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.auditRepo.CountByBooking(bookingID)
+	return entries, total, err
+}
+
+// recordAudit stores who changed what on a booking; actorID 0 means a scheduled job
+func (s *bookingService) recordAudit(bookingID, actorID uint, action model.BookingAuditAction, changes model.FieldChanges) {
+	writeAudit(s.auditRepo, newAuditEntry(bookingID, actorID, action, changes))
+}
+
+func newAuditEntry(bookingID, actorID uint, action model.BookingAuditAction, changes model.FieldChanges) *model.BookingAudit {
+	entry := &model.BookingAudit{BookingID: bookingID, Action: action, Changes: changes}
+	if actorID != 0 {
+		entry.ActorID = &actorID
+	}
+	return entry
+}
+
+// writeAudit saves entry after the change it describes. That change has already been made,
+// so a failed write is logged rather than returned.
+func writeAudit(repo repository.BookingAuditRepository, entry *model.BookingAudit) {
+	if err := repo.Create(entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"booking_id": entry.BookingID,
+			"action":     entry.Action,
+		}).Error("Failed to record booking audit")
+	}
+}
+
+// cancelUnpaid cancels a pending booking and releases its stock. Returns a nil booking when
+// the booking was no longer pending, so repeated gateway callbacks release stock only once.
+func (s *bookingService) cancelUnpaid(bookingID uint) (*model.Booking, error) {
+	booking, err := s.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, ErrBookingNotFound
+	}
+
+	cancelled, err := s.bookingRepo.TransitionStatus(bookingID, model.BookingPending, model.BookingCancelled)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, nil
+	}
+
+	if err := s.gameRepo.ReleaseStock(booking.GameID); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// ResendConfirmation re-sends the email matching the booking's status: the booking confirmation
+// while it awaits payment, the payment confirmation once paid. Returns which email was sent.
+func (s *bookingService) ResendConfirmation(ctx context.Context, requestorID uint, requestorRole model.UserRole, bookingID uint) (string, error) {
+	if !s.canManageBookings(requestorRole) {
+		return "", ErrInsufficientPermission
+	}
+
+	booking, err := s.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return "", ErrBookingNotFound
+	}
+
+	var kind string
+	var send func(context.Context, *model.User, *model.Game, *model.Booking) error
+	switch booking.Status {
+	case model.BookingPending:
+		kind, send = "booking_confirmation", s.sendBookingConfirmationEmail
+	case model.BookingConfirmed, model.BookingActive, model.BookingOverdue, model.BookingCompleted:
+		kind, send = "payment_confirmed", s.sendPaymentConfirmedEmail
+	default:
+		return "", ErrBookingNoConfirmation
+	}
+
+	if !s.reserveResend(bookingID) {
+		return "", ErrResendTooSoon
+	}
+
+	if err := send(ctx, &booking.User, &booking.Game, booking); err != nil {
+		s.releaseResend(bookingID)
+		logrus.WithError(err).WithField("booking_id", bookingID).Error("Failed to resend confirmation email")
+		return "", ErrEmailSendFailed
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"booking_id": bookingID,
+		"admin_id":   requestorID,
+		"email":      kind,
+		"to":         utils.RedactEmail(booking.User.Email),
+	}).Info("Booking confirmation email resent")
+	s.recordAudit(bookingID, requestorID, model.AuditEmailResent, model.FieldChanges{
+		"email": {Old: nil, New: kind},
+	})
+
+	return kind, nil
+}
+
+// reserveResend claims the booking's resend slot, reporting false while the cooldown is running.
+// Expired slots are dropped on the way so the map only holds bookings still cooling down.
+func (s *bookingService) reserveResend(bookingID uint) bool {
+	s.resendMu.Lock()
+	defer s.resendMu.Unlock()
+
+	for id, last := range s.lastResent {
+		if time.Since(last) >= resendConfirmationCooldown {
+			delete(s.lastResent, id)
+		}
+	}
+	if last, ok := s.lastResent[bookingID]; ok && time.Since(last) < resendConfirmationCooldown {
+		return false
+	}
+	s.lastResent[bookingID] = time.Now()
+	return true
+}
+
+// releaseResend frees the slot after a failed send so the admin can retry straight away
+func (s *bookingService) releaseResend(bookingID uint) {
+	s.resendMu.Lock()
+	defer s.resendMu.Unlock()
+	delete(s.lastResent, bookingID)
+}
+
+func (s *bookingService) sendBookingConfirmationEmail(ctx context.Context, user *model.User, game *model.Game, booking *model.Booking) error {
+	subject := "Booking Confirmation - Game Rental"
+	platform := "Unknown"
+	if game.Platform != nil {
+		platform = *game.Platform
+	}
+	htmlContent := fmt.Sprintf(`
+		<h1>Booking Confirmation</h1>
+		<p>Hi %s,</p>
+		<p>Your booking has been created successfully!</p>
+		<h3>Details:</h3>
+		<ul>
+			<li><strong>Game:</strong> %s</li>
+			<li><strong>Platform:</strong> %s</li>
+			<li><strong>Period:</strong> %s to %s (%d days)</li>
+			<li><strong>Total:</strong> Rp %.0f</li>
+		</ul>
+		<p><strong>Next:</strong> Please complete the payment.</p>
+	`, user.FullName, game.Name, platform, booking.StartDate.Format("2006-01-02"), booking.EndDate.Format("2006-01-02"), booking.RentalDays, booking.TotalAmount)
+
+	plainText := fmt.Sprintf("Booking confirmed for %s. Total: Rp %.0f", game.Name, booking.TotalAmount)
+
+	return s.emailRepo.SendEmail(ctx, user.Email, subject, plainText, htmlContent)
+}
+
+func (s *bookingService) sendPaymentConfirmedEmail(ctx context.Context, user *model.User, game *model.Game, booking *model.Booking) error {
+	subject := "Payment Confirmed - Game Rental"
+	platform := "Unknown"
+	if game.Platform != nil {
+		platform = *game.Platform
+	}
+	htmlContent := fmt.Sprintf(`
+		<h1>Payment Successful!</h1>
+		<p>Hi %s,</p>
+		<p>Your payment has been confirmed!</p>
+		<h3>Details:</h3>
+		<ul>
+			<li><strong>Game:</strong> %s</li>
+			<li><strong>Platform:</strong> %s</li>
+			<li><strong>Period:</strong> %s to %s</li>
+			<li><strong>Amount:</strong> Rp %.0f</li>
+		</ul>
+	`, user.FullName, game.Name, platform, booking.StartDate.Format("2006-01-02"), booking.EndDate.Format("2006-01-02"), booking.TotalAmount)
+
+	plainText := fmt.Sprintf("Payment confirmed for %s", game.Name)
+
+	return s.emailRepo.SendEmail(ctx, user.Email, subject, plainText, htmlContent)
+}
 
-	return s.bookingRepo.UpdateStatus(bookingID, model.BookingCancelled)
+// latestBookableDate is the last day a booking may reach, maxAdvanceDays from today
+func (s *bookingService) latestBookableDate() time.Time {
+	return time.Now().Truncate(24*time.Hour).AddDate(0, 0, s.maxAdvanceDays)
 }
 
 func (s *bookingService) canManageBookings(role model.UserRole) bool {
-	return role == model.RoleAdmin || role == model.RoleSuperAdmin
+	return role.Permissions().CanManageBookings
 }
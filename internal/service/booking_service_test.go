@@ -0,0 +1,598 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/repository/email"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+)
+
+// stubGameRepository serves a single game; any other method panics via the nil embedded interface
+type stubGameRepository struct {
+	repository.GameRepository
+	game *model.Game
+}
+
+func (s *stubGameRepository) GetByID(id uint) (*model.Game, error) {
+	return s.game, nil
+}
+
+// ============= TEST CREATE MAX ADVANCE WINDOW =============
+func TestCreate_MaxAdvanceWindowBoundary(t *testing.T) {
+	// An inactive game stops Create right after the window check, so no other repositories are needed
+	gameRepo := &stubGameRepository{game: &model.Game{ID: 1, IsActive: false}}
+	svc := NewBookingService(nil, gameRepo, nil, nil, nil, nil, 90)
+	today := time.Now().Truncate(24 * time.Hour)
+
+	t.Run("on the last allowed day", func(t *testing.T) {
+		start := today.AddDate(0, 0, 90)
+		err := svc.Create(context.Background(), 1, &model.Booking{GameID: 1, StartDate: start, EndDate: start})
+		assert.ErrorIs(t, err, ErrGameNotAvailable)
+	})
+
+	t.Run("one day past the window", func(t *testing.T) {
+		start := today.AddDate(0, 0, 91)
+		err := svc.Create(context.Background(), 1, &model.Booking{GameID: 1, StartDate: start, EndDate: start})
+		assert.ErrorIs(t, err, ErrBookingTooFarAhead)
+	})
+}
+
+// stubBookingRepository serves a single booking; any other method panics via the nil embedded interface
+type stubBookingRepository struct {
+	repository.BookingRepository
+	booking *model.Booking
+}
+
+func (s *stubBookingRepository) GetByID(id uint) (*model.Booking, error) {
+	return s.booking, nil
+}
+
+// ============= TEST RESEND CONFIRMATION =============
+func TestResendConfirmation(t *testing.T) {
+	cases := []struct {
+		status      model.BookingStatus
+		wantKind    string
+		wantSubject string
+		wantErr     error
+	}{
+		{model.BookingPending, "booking_confirmation", "Booking Confirmation - Game Rental", nil},
+		{model.BookingConfirmed, "payment_confirmed", "Payment Confirmed - Game Rental", nil},
+		{model.BookingCompleted, "payment_confirmed", "Payment Confirmed - Game Rental", nil},
+		{model.BookingCancelled, "", "", ErrBookingNoConfirmation},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.status), func(t *testing.T) {
+			booking := &model.Booking{
+				ID: 5, Status: tc.status, StartDate: time.Now(), EndDate: time.Now().AddDate(0, 0, 2),
+				User: model.User{Email: "customer@example.com", FullName: "Customer"},
+				Game: model.Game{Name: "Elden Ring"},
+			}
+			emailRepo := &email.MockEmailRepository{}
+			auditRepo := &memoryBookingAuditRepository{}
+			svc := NewBookingService(&stubBookingRepository{booking: booking}, nil, nil, emailRepo, nil, auditRepo, 90)
+
+			kind, err := svc.ResendConfirmation(context.Background(), 9, model.RoleAdmin, booking.ID)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, emailRepo.SentEmails)
+				assert.Empty(t, auditRepo.entries)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantKind, kind)
+			if assert.Len(t, emailRepo.SentEmails, 1) {
+				assert.Equal(t, "customer@example.com", emailRepo.SentEmails[0].To)
+				assert.Equal(t, tc.wantSubject, emailRepo.SentEmails[0].Subject)
+			}
+			if assert.Len(t, auditRepo.entries, 1) {
+				assert.Equal(t, model.AuditEmailResent, auditRepo.entries[0].Action)
+				assert.Equal(t, uint(9), *auditRepo.entries[0].ActorID)
+				assert.Equal(t, tc.wantKind, auditRepo.entries[0].Changes["email"].New)
+			}
+		})
+	}
+}
+
+func TestResendConfirmation_Cooldown(t *testing.T) {
+	booking := &model.Booking{ID: 5, Status: model.BookingConfirmed, User: model.User{Email: "customer@example.com"}}
+	emailRepo := &email.MockEmailRepository{}
+	svc := NewBookingService(&stubBookingRepository{booking: booking}, nil, nil, emailRepo, nil, &memoryBookingAuditRepository{}, 90)
+
+	_, err := svc.ResendConfirmation(context.Background(), 9, model.RoleAdmin, booking.ID)
+	assert.NoError(t, err)
+
+	_, err = svc.ResendConfirmation(context.Background(), 9, model.RoleAdmin, booking.ID)
+	assert.ErrorIs(t, err, ErrResendTooSoon)
+	assert.Len(t, emailRepo.SentEmails, 1)
+}
+
+func TestResendConfirmation_KeepsRequestID(t *testing.T) {
+	booking := &model.Booking{ID: 5, Status: model.BookingConfirmed, User: model.User{Email: "customer@example.com"}}
+	emailRepo := &email.MockEmailRepository{}
+	svc := NewBookingService(&stubBookingRepository{booking: booking}, nil, nil, emailRepo, nil, &memoryBookingAuditRepository{}, 90)
+
+	_, err := svc.ResendConfirmation(utils.WithRequestID(context.Background(), "req-123"), 9, model.RoleAdmin, booking.ID)
+
+	assert.NoError(t, err)
+	if assert.Len(t, emailRepo.SentEmails, 1) {
+		assert.Equal(t, "req-123", emailRepo.SentEmails[0].RequestID)
+	}
+}
+
+func TestReserveResend_DropsExpiredSlots(t *testing.T) {
+	svc := NewBookingService(nil, nil, nil, nil, nil, nil, 90).(*bookingService)
+	svc.lastResent[1] = time.Now().Add(-resendConfirmationCooldown)
+	svc.lastResent[2] = time.Now()
+
+	assert.True(t, svc.reserveResend(3))
+	assert.NotContains(t, svc.lastResent, uint(1))
+	assert.Contains(t, svc.lastResent, uint(2))
+	assert.Contains(t, svc.lastResent, uint(3))
+}
+
+// extendBookingRepository serves one booking and records the applied extension
+type extendBookingRepository struct {
+	stubBookingRepository
+	applied *model.Booking
+}
+
+func (r *extendBookingRepository) ApplyExtension(booking *model.Booking) (bool, error) {
+	r.applied = booking
+	return true, nil
+}
+
+// rangeGameRepository answers availability checks and records the range asked about
+type rangeGameRepository struct {
+	repository.GameRepository
+	available  bool
+	start, end time.Time
+}
+
+func (r *rangeGameRepository) CheckAvailabilityForRange(gameID uint, start, end time.Time) (bool, error) {
+	r.start, r.end = start, end
+	return r.available, nil
+}
+
+func activeBooking() *model.Booking {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	return &model.Booking{
+		ID: 3, UserID: 1, GameID: 8, Status: model.BookingActive,
+		StartDate: start, EndDate: start.AddDate(0, 0, 2),
+		RentalDays: 3, DailyPrice: 20000, TotalRentalPrice: 60000, SecurityDeposit: 50000, TotalAmount: 110000,
+	}
+}
+
+// ============= TEST EXTEND BOOKING =============
+func TestExtendBooking_RecalculatesPricing(t *testing.T) {
+	booking := activeBooking()
+	bookingRepo := &extendBookingRepository{stubBookingRepository: stubBookingRepository{booking: booking}}
+	gameRepo := &rangeGameRepository{available: true}
+	svc := NewBookingService(bookingRepo, gameRepo, nil, nil, nil, nil, 90)
+
+	newEnd := booking.StartDate.AddDate(0, 0, 4)
+	err := svc.ExtendBooking(1, 3, newEnd)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, bookingRepo.applied) {
+		assert.Equal(t, newEnd, bookingRepo.applied.EndDate)
+		assert.Equal(t, 5, bookingRepo.applied.RentalDays)
+		assert.Equal(t, 100000.0, bookingRepo.applied.TotalRentalPrice)
+		assert.Equal(t, 150000.0, bookingRepo.applied.TotalAmount)
+		assert.Equal(t, 40000.0, bookingRepo.applied.ExtensionAmountDue)
+	}
+	// Only the added days are checked, so the booking doesn't collide with itself
+	assert.Equal(t, booking.StartDate.AddDate(0, 0, 3), gameRepo.start)
+	assert.Equal(t, newEnd, gameRepo.end)
+}
+
+func TestExtendBooking_AccumulatesAmountDue(t *testing.T) {
+	booking := activeBooking()
+	booking.ExtensionAmountDue = 20000
+	bookingRepo := &extendBookingRepository{stubBookingRepository: stubBookingRepository{booking: booking}}
+	svc := NewBookingService(bookingRepo, &rangeGameRepository{available: true}, nil, nil, nil, nil, 90)
+
+	assert.NoError(t, svc.ExtendBooking(1, 3, booking.EndDate.AddDate(0, 0, 1)))
+	assert.Equal(t, 40000.0, bookingRepo.applied.ExtensionAmountDue)
+}
+
+func TestExtendBooking_Rejections(t *testing.T) {
+	cases := []struct {
+		name      string
+		mutate    func(b *model.Booking)
+		userID    uint
+		extraDays int
+		available bool
+		wantErr   error
+	}{
+		{"not owned", func(b *model.Booking) {}, 2, 1, true, ErrBookingNotOwned},
+		{"not active", func(b *model.Booking) { b.Status = model.BookingConfirmed }, 1, 1, true, ErrBookingCannotExtend},
+		{"end date not later", func(b *model.Booking) {}, 1, 0, true, ErrBookingExtendDate},
+		{"range booked by someone else", func(b *model.Booking) {}, 1, 2, false, ErrGameStockInsufficient},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			booking := activeBooking()
+			tc.mutate(booking)
+			bookingRepo := &extendBookingRepository{stubBookingRepository: stubBookingRepository{booking: booking}}
+			svc := NewBookingService(bookingRepo, &rangeGameRepository{available: tc.available}, nil, nil, nil, nil, 90)
+
+			err := svc.ExtendBooking(tc.userID, 3, booking.EndDate.AddDate(0, 0, tc.extraDays))
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Nil(t, bookingRepo.applied)
+		})
+	}
+}
+
+func TestExtendBooking_RespectsMaxAdvanceDays(t *testing.T) {
+	today := time.Now().Truncate(24 * time.Hour)
+	booking := activeBooking()
+	booking.StartDate, booking.EndDate = today, today.AddDate(0, 0, 2)
+	bookingRepo := &extendBookingRepository{stubBookingRepository: stubBookingRepository{booking: booking}}
+	svc := NewBookingService(bookingRepo, &rangeGameRepository{available: true}, nil, nil, nil, nil, 90)
+
+	err := svc.ExtendBooking(1, 3, today.AddDate(0, 0, 91))
+	assert.ErrorIs(t, err, ErrBookingExtendTooFar)
+	assert.Nil(t, bookingRepo.applied)
+
+	assert.NoError(t, svc.ExtendBooking(1, 3, today.AddDate(0, 0, 90)))
+}
+
+// ============= TEST RENTAL TERMS =============
+func TestCreate_RequiresAcceptedRentalTerms(t *testing.T) {
+	terms := "No refunds within 24h"
+	gameRepo := &stubGameRepository{game: &model.Game{ID: 1, IsActive: true, RentalTerms: &terms}}
+	svc := NewBookingService(nil, gameRepo, nil, nil, nil, nil, 90)
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	t.Run("not accepted", func(t *testing.T) {
+		err := svc.Create(context.Background(), 1, &model.Booking{GameID: 1, StartDate: yesterday, EndDate: yesterday})
+		assert.ErrorIs(t, err, ErrBookingTermsRequired)
+	})
+
+	t.Run("accepted", func(t *testing.T) {
+		// Passing the terms check reaches the date validation
+		now := time.Now()
+		err := svc.Create(context.Background(), 1, &model.Booking{GameID: 1, StartDate: yesterday, EndDate: yesterday, TermsAcceptedAt: &now})
+		assert.ErrorIs(t, err, ErrBookingInvalidDate)
+	})
+}
+
+// overdueBookingRepository filters its seeded bookings like GetOverdueBookings and records transitions
+type overdueBookingRepository struct {
+	repository.BookingRepository
+	bookings []*model.Booking
+	moved    []uint
+}
+
+func (r *overdueBookingRepository) GetOverdueBookings(before time.Time) ([]*model.Booking, error) {
+	var overdue []*model.Booking
+	for _, booking := range r.bookings {
+		if booking.Status == model.BookingActive && booking.EndDate.Before(before) {
+			overdue = append(overdue, booking)
+		}
+	}
+	return overdue, nil
+}
+
+func (r *overdueBookingRepository) TransitionStatus(bookingID uint, from, to model.BookingStatus) (bool, error) {
+	for _, booking := range r.bookings {
+		if booking.ID == bookingID && booking.Status == from {
+			booking.Status = to
+			r.moved = append(r.moved, bookingID)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteBookingRepository serves one booking whose stored status can move under the service,
+// as a concurrent cancel would
+type deleteBookingRepository struct {
+	stubBookingRepository
+	storedStatus model.BookingStatus
+	deleted      bool
+}
+
+func (r *deleteBookingRepository) TransitionStatus(bookingID uint, from, to model.BookingStatus) (bool, error) {
+	if r.storedStatus != from {
+		return false, nil
+	}
+	r.storedStatus = to
+	return true, nil
+}
+
+func (r *deleteBookingRepository) MarkCancelled(bookingID uint, reason *string) error {
+	r.storedStatus = model.BookingCancelled
+	return nil
+}
+
+func (r *deleteBookingRepository) SoftDelete(bookingID uint) error {
+	r.deleted = true
+	return nil
+}
+
+// releaseGameRepository counts released copies
+type releaseGameRepository struct {
+	repository.GameRepository
+	released int
+}
+
+func (r *releaseGameRepository) ReleaseStock(gameID uint) error {
+	r.released++
+	return nil
+}
+
+// ============= TEST SOFT DELETE =============
+func TestSoftDelete_ReleasesStockOnlyWhenItCancels(t *testing.T) {
+	cases := []struct {
+		name         string
+		storedStatus model.BookingStatus
+		wantErr      error
+		wantReleased int
+	}{
+		{"pending", model.BookingPending, nil, 1},
+		{"cancelled meanwhile", model.BookingCancelled, ErrBookingStatusChanged, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			bookingRepo := &deleteBookingRepository{
+				stubBookingRepository: stubBookingRepository{booking: &model.Booking{ID: 1, GameID: 2, Status: model.BookingPending}},
+				storedStatus:          tc.storedStatus,
+			}
+			gameRepo := &releaseGameRepository{}
+			svc := NewBookingService(bookingRepo, gameRepo, nil, nil, nil, &memoryBookingAuditRepository{}, 90)
+
+			err := svc.SoftDelete(9, model.RoleAdmin, 1)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantReleased, gameRepo.released)
+			assert.Equal(t, tc.wantErr == nil, bookingRepo.deleted)
+		})
+	}
+}
+
+// silentNotifier drops in-app notifications
+type silentNotifier struct {
+	NotificationService
+}
+
+func (silentNotifier) Notify(userID uint, notificationType model.NotificationType, title, message string, bookingID *uint) {
+}
+
+// ============= TEST PROCESS OVERDUE BOOKINGS =============
+func TestProcessOverdueBookings_FlagsOnlyPastDue(t *testing.T) {
+	today := time.Now().Truncate(24 * time.Hour)
+	overdue := &model.Booking{ID: 1, Status: model.BookingActive, EndDate: today.AddDate(0, 0, -2),
+		User: model.User{Email: "late@example.com"}, Game: model.Game{Name: "Elden Ring"}}
+	notYetDue := &model.Booking{ID: 2, Status: model.BookingActive, EndDate: today,
+		User: model.User{Email: "ontime@example.com"}, Game: model.Game{Name: "Hades"}}
+	bookingRepo := &overdueBookingRepository{bookings: []*model.Booking{overdue, notYetDue}}
+	emailRepo := &email.MockEmailRepository{}
+	auditRepo := &memoryBookingAuditRepository{}
+	svc := NewBookingService(bookingRepo, nil, nil, emailRepo, silentNotifier{}, auditRepo, 90)
+
+	processed, err := svc.ProcessOverdueBookings()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, []uint{1}, bookingRepo.moved)
+	assert.Equal(t, model.BookingOverdue, overdue.Status)
+	assert.Equal(t, model.BookingActive, notYetDue.Status)
+	if assert.Len(t, auditRepo.entries, 1) {
+		assert.Equal(t, model.AuditMarkedOverdue, auditRepo.entries[0].Action)
+		assert.Nil(t, auditRepo.entries[0].ActorID)
+	}
+	if assert.Len(t, emailRepo.SentEmails, 1) {
+		assert.Equal(t, "late@example.com", emailRepo.SentEmails[0].To)
+	}
+
+	// A second run finds nothing left to move
+	processed, err = svc.ProcessOverdueBookings()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, processed)
+}
+
+// returnBookingRepository serves one booking and records what CompleteReturn was given
+type returnBookingRepository struct {
+	repository.BookingRepository
+	booking        *model.Booking
+	lateFeePayment *model.Payment
+	audit          *model.BookingAudit
+	completed      bool
+}
+
+func (r *returnBookingRepository) GetByID(id uint) (*model.Booking, error) {
+	return r.booking, nil
+}
+
+func (r *returnBookingRepository) CompleteReturn(booking *model.Booking, lateFeePayment *model.Payment, audit *model.BookingAudit) (bool, error) {
+	r.completed = true
+	r.lateFeePayment = lateFeePayment
+	r.audit = audit
+	return true, nil
+}
+
+// ============= TEST CONFIRM RETURN LATE FEE =============
+func TestCalculateLateFee_UsesLocalCalendarDate(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*60*60)
+	endDate := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC) // DATE columns scan as UTC midnight
+
+	cases := []struct {
+		name       string
+		returnedAt time.Time
+		wantDays   int
+	}{
+		{"late on the end date", time.Date(2026, time.March, 10, 23, 30, 0, 0, wib), 0},
+		{"early next morning", time.Date(2026, time.March, 11, 1, 0, 0, 0, wib), 1},
+		{"two days later before 07:00", time.Date(2026, time.March, 12, 6, 59, 0, 0, wib), 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			days, fee := CalculateLateFee(endDate, tc.returnedAt, 5000)
+
+			assert.Equal(t, tc.wantDays, days)
+			assert.Equal(t, float64(tc.wantDays)*5000, fee)
+		})
+	}
+}
+
+func TestConfirmReturn_LateFee(t *testing.T) {
+	endDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		returnedAt time.Time
+		wantFee    float64
+	}{
+		{"on time, last day", endDate.Add(20 * time.Hour), 0},
+		{"early", endDate.AddDate(0, 0, -1), 0},
+		{"one day late", endDate.AddDate(0, 0, 1).Add(9 * time.Hour), 5000},
+		{"three days late", endDate.AddDate(0, 0, 3).Add(time.Hour), 15000},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			paymentID := uint(7)
+			bookingRepo := &returnBookingRepository{booking: &model.Booking{
+				ID: 1, UserID: 2, GameID: 3, Status: model.BookingOverdue, EndDate: endDate,
+				Game:    model.Game{ID: 3, Name: "Hades", LateFeePerDay: 5000},
+				Payment: &model.Payment{ID: paymentID, Provider: model.ProviderStripe},
+			}}
+			svc := NewBookingService(bookingRepo, nil, nil, nil, silentNotifier{}, &memoryBookingAuditRepository{}, 90)
+
+			booking, err := svc.ConfirmReturn(9, model.RoleAdmin, 1, tc.returnedAt)
+
+			assert.NoError(t, err)
+			assert.True(t, bookingRepo.completed)
+			assert.Equal(t, model.BookingCompleted, booking.Status)
+			assert.Equal(t, tc.returnedAt, *booking.ReturnedAt)
+			assert.Equal(t, tc.wantFee, booking.LateFee)
+			if tc.wantFee == 0 {
+				assert.Nil(t, bookingRepo.lateFeePayment)
+				return
+			}
+			if assert.NotNil(t, bookingRepo.lateFeePayment) {
+				assert.Equal(t, tc.wantFee, bookingRepo.lateFeePayment.Amount)
+				assert.Equal(t, model.PaymentPurposeLateFee, bookingRepo.lateFeePayment.Purpose)
+				assert.Equal(t, model.PaymentPending, bookingRepo.lateFeePayment.Status)
+				assert.Equal(t, model.ProviderStripe, bookingRepo.lateFeePayment.Provider)
+			}
+		})
+	}
+}
+
+func TestConfirmReturn_NotOut(t *testing.T) {
+	bookingRepo := &returnBookingRepository{booking: &model.Booking{ID: 1, Status: model.BookingConfirmed}}
+	auditRepo := &memoryBookingAuditRepository{}
+	svc := NewBookingService(bookingRepo, nil, nil, nil, silentNotifier{}, auditRepo, 90)
+
+	_, err := svc.ConfirmReturn(9, model.RoleAdmin, 1, time.Now())
+
+	assert.ErrorIs(t, err, ErrBookingNotReturnable)
+	assert.False(t, bookingRepo.completed)
+	assert.Empty(t, auditRepo.entries)
+}
+
+// memoryBookingAuditRepository keeps audit entries in a slice
+type memoryBookingAuditRepository struct {
+	repository.BookingAuditRepository
+	entries []*model.BookingAudit
+}
+
+func (r *memoryBookingAuditRepository) Create(entry *model.BookingAudit) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// ============= TEST BOOKING AUDIT =============
+func TestConfirmReturn_RecordsAudit(t *testing.T) {
+	endDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	returnedAt := endDate.AddDate(0, 0, 2).Add(time.Hour)
+	bookingRepo := &returnBookingRepository{booking: &model.Booking{
+		ID: 1, UserID: 2, GameID: 3, Status: model.BookingOverdue, EndDate: endDate,
+		Game: model.Game{ID: 3, Name: "Hades", LateFeePerDay: 5000},
+	}}
+	auditRepo := &memoryBookingAuditRepository{}
+	svc := NewBookingService(bookingRepo, nil, nil, nil, silentNotifier{}, auditRepo, 90)
+
+	_, err := svc.ConfirmReturn(9, model.RoleAdmin, 1, returnedAt)
+
+	assert.NoError(t, err)
+	// Saved by CompleteReturn in the return's transaction, not separately afterwards
+	assert.Empty(t, auditRepo.entries)
+	if entry := bookingRepo.audit; assert.NotNil(t, entry) {
+		assert.Equal(t, uint(1), entry.BookingID)
+		assert.Equal(t, model.AuditReturnConfirmed, entry.Action)
+		if assert.NotNil(t, entry.ActorID) {
+			assert.Equal(t, uint(9), *entry.ActorID)
+		}
+		assert.Equal(t, model.FieldChange{Old: model.BookingOverdue, New: model.BookingCompleted}, entry.Changes["status"])
+		assert.Equal(t, model.FieldChange{Old: float64(0), New: float64(10000)}, entry.Changes["late_fee"])
+		assert.Equal(t, returnedAt, entry.Changes["returned_at"].New)
+	}
+}
+
+func TestGetAuditLog_RequiresManageBookings(t *testing.T) {
+	svc := NewBookingService(nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 90)
+
+	_, _, err := svc.GetAuditLog(model.RoleCustomer, 1, 10, 0)
+
+	assert.ErrorIs(t, err, ErrInsufficientPermission)
+}
+
+// ============= TEST SUMMARIZE DEPOSIT =============
+func TestSummarizeDeposit(t *testing.T) {
+	partialRefund := 20000.0
+	fullRefund := 150000.0
+	cases := []struct {
+		name       string
+		payment    *model.Payment
+		wantStatus model.DepositStatus
+		wantAmount float64
+	}{
+		{"unpaid", nil, model.DepositNone, 0},
+		{"pending payment", &model.Payment{Status: model.PaymentPending, DepositMode: model.DepositCharge}, model.DepositNone, 0},
+		{"charged", &model.Payment{Status: model.PaymentPaid, DepositMode: model.DepositCharge, Amount: 150000}, model.DepositHeld, 50000},
+		{"charged then partly refunded", &model.Payment{Status: model.PaymentRefunded, DepositMode: model.DepositCharge, RefundAmount: &partialRefund}, model.DepositRefunded, 20000},
+		{"charged then fully refunded", &model.Payment{Status: model.PaymentRefunded, DepositMode: model.DepositCharge, RefundAmount: &fullRefund}, model.DepositRefunded, 50000},
+		{"hold authorized", &model.Payment{Status: model.PaymentAuthorized, DepositMode: model.DepositHold, Amount: 150000}, model.DepositHeld, 50000},
+		{"hold voided", &model.Payment{Status: model.PaymentVoided, DepositMode: model.DepositHold, Amount: 150000}, model.DepositRefunded, 50000},
+		{"hold captured without deposit", &model.Payment{Status: model.PaymentPaid, DepositMode: model.DepositHold, Amount: 100000, DepositReleased: true}, model.DepositRefunded, 50000},
+		{"hold captured with deposit", &model.Payment{Status: model.PaymentPaid, DepositMode: model.DepositHold, Amount: 150000}, model.DepositForfeited, 50000},
+		{"hold captured with deposit, booking extended since", &model.Payment{Status: model.PaymentPaid, DepositMode: model.DepositHold, Amount: 120000}, model.DepositForfeited, 50000},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			booking := &model.Booking{SecurityDeposit: 50000, TotalAmount: 150000, Payment: tc.payment}
+
+			summary := SummarizeDeposit(booking)
+
+			assert.Equal(t, tc.wantStatus, summary.Status)
+			assert.Equal(t, tc.wantAmount, summary.Amount)
+		})
+	}
+}
+
+func TestGetDepositStatus_NotOwned(t *testing.T) {
+	booking := &model.Booking{ID: 1, UserID: 2, SecurityDeposit: 50000}
+	svc := NewBookingService(&stubBookingRepository{booking: booking}, nil, nil, nil, nil, nil, 90)
+
+	_, err := svc.GetDepositStatus(3, 1)
+
+	assert.ErrorIs(t, err, ErrBookingNotOwned)
+}
@@ -1,15 +1,16 @@
 package service
 
 import (
-	"errors"
-
+	"github.com/yoockh/go-game-rental-api/internal/dto"
 	"github.com/yoockh/go-game-rental-api/internal/model"
 	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
 
 var (
-	ErrCategoryNotFound = errors.New("category not found")
-	ErrCategoryHasGames = errors.New("cannot delete category with existing games")
+	ErrCategoryNotFound      = utils.NewNotFoundError("category_not_found", "category not found")
+	ErrCategoryHasGames      = utils.NewConflictError("category_has_games", "cannot delete category with existing games")
+	ErrCategoryInvalidTarget = utils.NewBadRequestError("category_invalid_target", "target category must be a different, active category")
 )
 
 type CategoryService interface {
@@ -19,9 +20,12 @@ type CategoryService interface {
 	GetCategoryByID(id uint) (*model.Category, error)
 
 	// Admin methods
+	GetAllCategoriesWithGameCount(requestorRole model.UserRole) ([]*dto.AdminCategoryDTO, error)
+	GetCategoryWithGameCount(requestorRole model.UserRole, id uint) (*dto.AdminCategoryDTO, error)
 	CreateCategory(requestorRole model.UserRole, categoryData *model.Category) error
 	UpdateCategory(requestorRole model.UserRole, categoryID uint, updateData *model.Category) error
 	DeleteCategory(requestorRole model.UserRole, categoryID uint) error
+	ReassignAndDeleteCategory(requestorRole model.UserRole, categoryID, targetCategoryID uint) error
 	ToggleCategoryStatus(requestorRole model.UserRole, categoryID uint) error
 }
 
@@ -45,6 +49,46 @@ func (s *categoryService) GetCategoryByID(id uint) (*model.Category, error) {
 	return s.categoryRepo.GetByID(id)
 }
 
+func (s *categoryService) GetAllCategoriesWithGameCount(requestorRole model.UserRole) ([]*dto.AdminCategoryDTO, error) {
+	if !s.canManageCategories(requestorRole) {
+		return nil, ErrInsufficientPermission
+	}
+
+	categories, err := s.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.categoryRepo.CountGamesByCategory()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.AdminCategoryDTO, len(categories))
+	for i, category := range categories {
+		result[i] = dto.ToAdminCategoryDTO(category, counts[category.ID])
+	}
+	return result, nil
+}
+
+func (s *categoryService) GetCategoryWithGameCount(requestorRole model.UserRole, id uint) (*dto.AdminCategoryDTO, error) {
+	if !s.canManageCategories(requestorRole) {
+		return nil, ErrInsufficientPermission
+	}
+
+	category, err := s.categoryRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	count, err := s.categoryRepo.CountGamesInCategory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ToAdminCategoryDTO(category, count), nil
+}
+
 func (s *categoryService) CreateCategory(requestorRole model.UserRole, categoryData *model.Category) error {
 	if !s.canManageCategories(requestorRole) {
 		return ErrInsufficientPermission
@@ -87,6 +131,26 @@ func (s *categoryService) DeleteCategory(requestorRole model.UserRole, categoryI
 	return s.categoryRepo.Delete(categoryID)
 }
 
+func (s *categoryService) ReassignAndDeleteCategory(requestorRole model.UserRole, categoryID, targetCategoryID uint) error {
+	if !s.canManageCategories(requestorRole) {
+		return ErrInsufficientPermission
+	}
+
+	if _, err := s.categoryRepo.GetByID(categoryID); err != nil {
+		return ErrCategoryNotFound
+	}
+
+	if targetCategoryID == categoryID {
+		return ErrCategoryInvalidTarget
+	}
+	target, err := s.categoryRepo.GetByID(targetCategoryID)
+	if err != nil || !target.IsActive {
+		return ErrCategoryInvalidTarget
+	}
+
+	return s.categoryRepo.ReassignGamesAndDelete(categoryID, targetCategoryID)
+}
+
 func (s *categoryService) ToggleCategoryStatus(requestorRole model.UserRole, categoryID uint) error {
 	if !s.canManageCategories(requestorRole) {
 		return ErrInsufficientPermission
@@ -101,5 +165,5 @@ func (s *categoryService) ToggleCategoryStatus(requestorRole model.UserRole, cat
 }
 
 func (s *categoryService) canManageCategories(role model.UserRole) bool {
-	return role == model.RoleAdmin || role == model.RoleSuperAdmin
+	return role.Permissions().CanManageCategories
 }
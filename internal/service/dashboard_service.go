@@ -0,0 +1,98 @@
+package service
+
+import (
+	"github.com/yoockh/go-game-rental-api/internal/dto"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+)
+
+type DashboardService interface {
+	// Admin methods
+	GetCounts(requestorRole model.UserRole) (map[string]int64, error)
+	GetStats(requestorRole model.UserRole) (*dto.AdminStatsResponse, error)
+}
+
+type dashboardService struct {
+	userRepo    repository.UserRepository
+	gameRepo    repository.GameRepository
+	bookingRepo repository.BookingRepository
+	paymentRepo repository.PaymentRepository
+}
+
+func NewDashboardService(
+	userRepo repository.UserRepository,
+	gameRepo repository.GameRepository,
+	bookingRepo repository.BookingRepository,
+	paymentRepo repository.PaymentRepository,
+) DashboardService {
+	return &dashboardService{
+		userRepo:    userRepo,
+		gameRepo:    gameRepo,
+		bookingRepo: bookingRepo,
+		paymentRepo: paymentRepo,
+	}
+}
+
+// GetCounts gathers the admin home screen totals in one call using the existing count queries
+func (s *dashboardService) GetCounts(requestorRole model.UserRole) (map[string]int64, error) {
+	if !requestorRole.Permissions().CanViewDashboard {
+		return nil, ErrInsufficientPermission
+	}
+
+	counters := []struct {
+		key   string
+		count func() (int64, error)
+	}{
+		{"users", func() (int64, error) { return s.userRepo.Count(repository.UserFilter{}) }},
+		{"active_games", s.gameRepo.Count},
+		{"bookings", s.bookingRepo.Count},
+		{"payments", s.paymentRepo.CountAllPayments},
+		{"pending_payments", func() (int64, error) { return s.paymentRepo.CountByStatus(model.PaymentPending) }},
+	}
+
+	counts := make(map[string]int64, len(counters))
+	for _, c := range counters {
+		n, err := c.count()
+		if err != nil {
+			return nil, err
+		}
+		counts[c.key] = n
+	}
+	return counts, nil
+}
+
+// GetStats builds the admin summary from count and sum queries, never loading rows
+func (s *dashboardService) GetStats(requestorRole model.UserRole) (*dto.AdminStatsResponse, error) {
+	if !requestorRole.Permissions().CanViewDashboard {
+		return nil, ErrInsufficientPermission
+	}
+
+	totalUsers, err := s.userRepo.Count(repository.UserFilter{})
+	if err != nil {
+		return nil, err
+	}
+	activeGames, err := s.gameRepo.Count()
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.bookingRepo.CountByStatus()
+	if err != nil {
+		return nil, err
+	}
+	revenue, err := s.paymentRepo.SumPaidAmount()
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus := make(map[model.BookingStatus]int64, len(model.BookingStatuses))
+	for _, status := range model.BookingStatuses {
+		byStatus[status] = counts[status]
+	}
+
+	return &dto.AdminStatsResponse{
+		TotalUsers:       totalUsers,
+		ActiveGames:      activeGames,
+		BookingsByStatus: byStatus,
+		TotalRevenue:     revenue,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+)
+
+type countUserRepository struct {
+	repository.UserRepository
+	total int64
+}
+
+func (r *countUserRepository) Count(filter repository.UserFilter) (int64, error) {
+	return r.total, nil
+}
+
+type countGameRepository struct {
+	repository.GameRepository
+	active int64
+}
+
+func (r *countGameRepository) Count() (int64, error) {
+	return r.active, nil
+}
+
+type countBookingRepository struct {
+	repository.BookingRepository
+	byStatus map[model.BookingStatus]int64
+}
+
+func (r *countBookingRepository) CountByStatus() (map[model.BookingStatus]int64, error) {
+	return r.byStatus, nil
+}
+
+type revenuePaymentRepository struct {
+	repository.PaymentRepository
+	revenue float64
+}
+
+func (r *revenuePaymentRepository) SumPaidAmount() (float64, error) {
+	return r.revenue, nil
+}
+
+// ============= TEST GET STATS =============
+func TestGetStats_AggregatesCounts(t *testing.T) {
+	svc := NewDashboardService(
+		&countUserRepository{total: 42},
+		&countGameRepository{active: 17},
+		&countBookingRepository{byStatus: map[model.BookingStatus]int64{
+			model.BookingPending:   3,
+			model.BookingActive:    5,
+			model.BookingCompleted: 20,
+		}},
+		&revenuePaymentRepository{revenue: 3450000},
+	)
+
+	stats, err := svc.GetStats(model.RoleAdmin)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), stats.TotalUsers)
+	assert.Equal(t, int64(17), stats.ActiveGames)
+	assert.Equal(t, 3450000.0, stats.TotalRevenue)
+	assert.Equal(t, map[model.BookingStatus]int64{
+		model.BookingPending:   3,
+		model.BookingConfirmed: 0,
+		model.BookingActive:    5,
+		model.BookingOverdue:   0,
+		model.BookingCompleted: 20,
+		model.BookingCancelled: 0,
+	}, stats.BookingsByStatus)
+}
+
+func TestGetStats_CustomerForbidden(t *testing.T) {
+	svc := NewDashboardService(nil, nil, nil, nil)
+
+	_, err := svc.GetStats(model.RoleCustomer)
+
+	assert.ErrorIs(t, err, ErrInsufficientPermission)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+)
+
+var (
+	ErrGameReportReasonRequired = utils.NewBadRequestError("game_report_reason_required", "a reason is required")
+)
+
+type GameReportService interface {
+	// Customer
+	ReportGame(userID, gameID uint, reason string) error
+
+	// Admin
+	GetSummaries(requestorRole model.UserRole, flaggedOnly bool, limit, offset int) ([]*repository.GameReportSummary, int64, error)
+	GetGameReports(requestorRole model.UserRole, gameID uint, limit, offset int) ([]*model.GameReport, int64, error)
+}
+
+type gameReportService struct {
+	reportRepo repository.GameReportRepository
+	gameRepo   repository.GameRepository
+	// flagThreshold is the report count at which a game is flagged for admin review
+	flagThreshold int64
+}
+
+func NewGameReportService(reportRepo repository.GameReportRepository, gameRepo repository.GameRepository, flagThreshold int) GameReportService {
+	return &gameReportService{
+		reportRepo:    reportRepo,
+		gameRepo:      gameRepo,
+		flagThreshold: int64(flagThreshold),
+	}
+}
+
+// ReportGame records the complaint and logs once when the game reaches the flag threshold
+func (s *gameReportService) ReportGame(userID, gameID uint, reason string) error {
+	if reason = strings.TrimSpace(reason); reason == "" {
+		return ErrGameReportReasonRequired
+	}
+
+	if _, err := s.gameRepo.GetByID(gameID); err != nil {
+		return ErrGameNotFound
+	}
+
+	if err := s.reportRepo.Create(&model.GameReport{GameID: gameID, UserID: userID, Reason: reason}); err != nil {
+		return err
+	}
+
+	count, err := s.reportRepo.CountByGame(gameID)
+	if err != nil {
+		logrus.WithError(err).WithField("game_id", gameID).Error("Failed to count game reports")
+		return nil
+	}
+	if count == s.flagThreshold {
+		logrus.WithFields(logrus.Fields{
+			"game_id":      gameID,
+			"report_count": count,
+		}).Warn("Game flagged for review after repeated reports")
+	}
+	return nil
+}
+
+// GetSummaries lists reported games with their counts; flaggedOnly keeps those at or above the threshold
+func (s *gameReportService) GetSummaries(requestorRole model.UserRole, flaggedOnly bool, limit, offset int) ([]*repository.GameReportSummary, int64, error) {
+	if !requestorRole.Permissions().CanManageGames {
+		return nil, 0, ErrGameInsufficientPermission
+	}
+
+	var minReports int64
+	if flaggedOnly {
+		minReports = s.flagThreshold
+	}
+
+	summaries, err := s.reportRepo.Summaries(minReports, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, summary := range summaries {
+		summary.Flagged = summary.ReportCount >= s.flagThreshold
+	}
+
+	total, err := s.reportRepo.CountSummaries(minReports)
+	return summaries, total, err
+}
+
+func (s *gameReportService) GetGameReports(requestorRole model.UserRole, gameID uint, limit, offset int) ([]*model.GameReport, int64, error) {
+	if !requestorRole.Permissions().CanManageGames {
+		return nil, 0, ErrGameInsufficientPermission
+	}
+
+	reports, err := s.reportRepo.GetByGame(gameID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.reportRepo.CountByGame(gameID)
+	return reports, total, err
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+)
+
+// memoryGameReportRepository keeps reports in a slice and serves canned summaries
+type memoryGameReportRepository struct {
+	repository.GameReportRepository
+	reports    []*model.GameReport
+	summaries  []*repository.GameReportSummary
+	minReports int64
+}
+
+func (r *memoryGameReportRepository) Create(report *model.GameReport) error {
+	for _, existing := range r.reports {
+		if existing.GameID == report.GameID && existing.UserID == report.UserID {
+			return repository.ErrGameAlreadyReported
+		}
+	}
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func (r *memoryGameReportRepository) CountByGame(gameID uint) (int64, error) {
+	var count int64
+	for _, report := range r.reports {
+		if report.GameID == gameID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryGameReportRepository) Summaries(minReports int64, limit, offset int) ([]*repository.GameReportSummary, error) {
+	r.minReports = minReports
+	return r.summaries, nil
+}
+
+func (r *memoryGameReportRepository) CountSummaries(minReports int64) (int64, error) {
+	return int64(len(r.summaries)), nil
+}
+
+// ============= TEST REPORT GAME =============
+func TestReportGame_Success(t *testing.T) {
+	repo := &memoryGameReportRepository{}
+	svc := NewGameReportService(repo, &stubGameRepository{game: &model.Game{ID: 3}}, 3)
+
+	err := svc.ReportGame(1, 3, "  Photos show a different game  ")
+
+	assert.NoError(t, err)
+	if assert.Len(t, repo.reports, 1) {
+		assert.Equal(t, "Photos show a different game", repo.reports[0].Reason)
+	}
+}
+
+func TestReportGame_ReasonRequired(t *testing.T) {
+	repo := &memoryGameReportRepository{}
+	svc := NewGameReportService(repo, &stubGameRepository{game: &model.Game{ID: 3}}, 3)
+
+	err := svc.ReportGame(1, 3, "   ")
+
+	assert.ErrorIs(t, err, ErrGameReportReasonRequired)
+	assert.Empty(t, repo.reports)
+}
+
+func TestReportGame_AlreadyReported(t *testing.T) {
+	repo := &memoryGameReportRepository{}
+	svc := NewGameReportService(repo, &stubGameRepository{game: &model.Game{ID: 3}}, 3)
+
+	assert.NoError(t, svc.ReportGame(1, 3, "Offensive description"))
+	err := svc.ReportGame(1, 3, "Still offensive")
+
+	assert.ErrorIs(t, err, repository.ErrGameAlreadyReported)
+	assert.Len(t, repo.reports, 1)
+}
+
+// ============= TEST GET SUMMARIES =============
+func TestGetSummaries_MarksFlagged(t *testing.T) {
+	repo := &memoryGameReportRepository{summaries: []*repository.GameReportSummary{
+		{GameID: 1, ReportCount: 5},
+		{GameID: 2, ReportCount: 3},
+		{GameID: 3, ReportCount: 1},
+	}}
+	svc := NewGameReportService(repo, nil, 3)
+
+	summaries, total, err := svc.GetSummaries(model.RoleAdmin, false, 10, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, int64(0), repo.minReports)
+	assert.True(t, summaries[0].Flagged)
+	assert.True(t, summaries[1].Flagged)
+	assert.False(t, summaries[2].Flagged)
+}
+
+func TestGetSummaries_FlaggedOnlyUsesThreshold(t *testing.T) {
+	repo := &memoryGameReportRepository{}
+	svc := NewGameReportService(repo, nil, 4)
+
+	_, _, err := svc.GetSummaries(model.RoleSuperAdmin, true, 10, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), repo.minReports)
+}
+
+func TestGetSummaries_CustomerForbidden(t *testing.T) {
+	svc := NewGameReportService(&memoryGameReportRepository{}, nil, 3)
+
+	_, _, err := svc.GetSummaries(model.RoleCustomer, false, 10, 0)
+
+	assert.ErrorIs(t, err, ErrGameInsufficientPermission)
+}
@@ -1,62 +1,137 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/sirupsen/logrus"
+	"github.com/yoockh/go-game-rental-api/internal/dto"
 	"github.com/yoockh/go-game-rental-api/internal/model"
 	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/repository/storage"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
 
+// maxGameImages matches the limit on the images field when creating or updating a game
+const maxGameImages = 10
+
+// gameImageExtensions lists the accepted upload types, keyed by sniffed content type
+var gameImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
 var (
-	ErrGameNotFound               = errors.New("game not found")
-	ErrGameInsufficientPermission = errors.New("insufficient permission")
-	ErrGameNotOwned               = errors.New("you don't own this game")
+	ErrGameNotFound               = utils.NewNotFoundError("game_not_found", "game not found")
+	ErrGameInsufficientPermission = utils.NewForbiddenError("insufficient_permission", "insufficient permission")
+	ErrGameNotOwned               = utils.NewForbiddenError("game_not_owned", "you don't own this game")
+	ErrGameImageType              = utils.NewServiceError("game_image_type", http.StatusUnsupportedMediaType, "image must be jpeg, png or webp")
+	ErrGameImageTooLarge          = utils.NewServiceError("game_image_too_large", http.StatusRequestEntityTooLarge, fmt.Sprintf("image must be at most %dMB", storage.MaxFileSize/(1024*1024)))
+	ErrGameImageEmpty             = utils.NewBadRequestError("game_image_empty", "image file is empty")
+	ErrGameImageLimit             = utils.NewBadRequestError("game_image_limit", fmt.Sprintf("a game can have at most %d images", maxGameImages))
+	ErrGameImageNotFound          = utils.NewNotFoundError("game_image_not_found", "image not found on this game")
 )
 
 type GameService interface {
 	// Public
-	GetAll(limit, offset int) ([]*model.Game, int64, error)
-	Search(query string, limit, offset int) ([]*model.Game, error)
+	GetAll(filter repository.GameFilter, limit, offset int) ([]*model.Game, int64, error)
+	Search(query string, limit, offset int) ([]*model.Game, int64, error)
 	GetByID(gameID uint) (*model.Game, error)
+	GetBySlug(slug string) (*model.Game, error)
+	CheckAvailabilityForRange(gameID uint, start, end time.Time) (bool, error)
 
 	// Admin
 	Create(adminID uint, requestorRole model.UserRole, gameData *model.Game) error
 	Update(adminID uint, requestorRole model.UserRole, gameID uint, updateData *model.Game) error
 	Delete(requestorRole model.UserRole, gameID uint) error
+	BulkSetActive(adminID uint, requestorRole model.UserRole, gameIDs []uint, isActive bool) ([]dto.BulkGameStatusResult, error)
+	AddImage(ctx context.Context, adminID uint, requestorRole model.UserRole, gameID uint, data []byte) (*model.Game, error)
+	RemoveImage(ctx context.Context, adminID uint, requestorRole model.UserRole, gameID uint, imageURL string) (*model.Game, error)
+
+	// Super admin
+	GetAllForAdmin(requestorRole model.UserRole, filter repository.GameFilter, limit, offset int) ([]*model.Game, int64, error)
 }
 
 type gameService struct {
-	gameRepo repository.GameRepository
+	gameRepo    repository.GameRepository
+	storageRepo storage.StorageRepository
+}
+
+func NewGameService(gameRepo repository.GameRepository, storageRepo storage.StorageRepository) GameService {
+	return &gameService{gameRepo: gameRepo, storageRepo: storageRepo}
 }
 
-func NewGameService(gameRepo repository.GameRepository) GameService {
-	return &gameService{gameRepo: gameRepo}
+func (s *gameService) GetAll(filter repository.GameFilter, limit, offset int) ([]*model.Game, int64, error) {
+	return s.gameRepo.Filter(filter, limit, offset)
 }
 
-func (s *gameService) GetAll(limit, offset int) ([]*model.Game, int64, error) {
-	games, err := s.gameRepo.GetAll(limit, offset)
+// GetAllForAdmin lists every game, active or not, so super admins can review each admin's listings
+func (s *gameService) GetAllForAdmin(requestorRole model.UserRole, filter repository.GameFilter, limit, offset int) ([]*model.Game, int64, error) {
+	if !requestorRole.Permissions().CanViewAllGames {
+		return nil, 0, ErrGameInsufficientPermission
+	}
+
+	games, err := s.gameRepo.ListForAdmin(filter, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	count, err := s.gameRepo.Count()
+	count, err := s.gameRepo.CountForAdmin(filter)
 	return games, count, err
 }
 
-func (s *gameService) Search(query string, limit, offset int) ([]*model.Game, error) {
-	return s.gameRepo.Search(query, limit, offset)
+func (s *gameService) Search(query string, limit, offset int) ([]*model.Game, int64, error) {
+	games, err := s.gameRepo.Search(query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.gameRepo.CountSearch(query)
+	return games, total, err
 }
 
 func (s *gameService) GetByID(gameID uint) (*model.Game, error) {
 	return s.gameRepo.GetByID(gameID)
 }
 
+// GetBySlug also accepts slugs a game had before being renamed; callers compare the returned
+// game's Slug to spot those and point clients at the current one
+func (s *gameService) GetBySlug(slug string) (*model.Game, error) {
+	if game, err := s.gameRepo.GetBySlug(slug); err == nil {
+		return game, nil
+	}
+
+	gameID, err := s.gameRepo.GetGameIDBySlugRedirect(slug)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+	game, err := s.gameRepo.GetByID(gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+	return game, nil
+}
+
+func (s *gameService) CheckAvailabilityForRange(gameID uint, start, end time.Time) (bool, error) {
+	return s.gameRepo.CheckAvailabilityForRange(gameID, start, end)
+}
+
 func (s *gameService) Create(adminID uint, requestorRole model.UserRole, gameData *model.Game) error {
 	if !s.canManageGames(requestorRole) {
 		return ErrGameInsufficientPermission
 	}
 
+	slug, err := s.uniqueSlug(gameData.Name, 0)
+	if err != nil {
+		return err
+	}
+
 	gameData.AdminID = adminID
+	gameData.Slug = slug
 	gameData.IsActive = true
 	gameData.AvailableStock = gameData.Stock
 
@@ -64,18 +139,16 @@ func (s *gameService) Create(adminID uint, requestorRole model.UserRole, gameDat
 }
 
 func (s *gameService) Update(adminID uint, requestorRole model.UserRole, gameID uint, updateData *model.Game) error {
-	if !s.canManageGames(requestorRole) {
-		return ErrGameInsufficientPermission
-	}
-
-	game, err := s.gameRepo.GetByID(gameID)
+	game, err := s.getOwnedGame(adminID, requestorRole, gameID)
 	if err != nil {
-		return ErrGameNotFound
+		return err
 	}
 
-	// Admin can only edit their own games (super_admin can edit all)
-	if requestorRole != model.RoleSuperAdmin && game.AdminID != adminID {
-		return ErrGameNotOwned
+	oldSlug := game.Slug
+	if updateData.Name != game.Name || oldSlug == "" {
+		if game.Slug, err = s.uniqueSlug(updateData.Name, game.ID); err != nil {
+			return err
+		}
 	}
 
 	game.Name = updateData.Name
@@ -84,9 +157,16 @@ func (s *gameService) Update(adminID uint, requestorRole model.UserRole, gameID
 	game.Stock = updateData.Stock
 	game.RentalPricePerDay = updateData.RentalPricePerDay
 	game.SecurityDeposit = updateData.SecurityDeposit
+	game.LateFeePerDay = updateData.LateFeePerDay
 	game.Condition = updateData.Condition
 	game.CategoryID = updateData.CategoryID
+	game.Images = updateData.Images
+	game.RentalTerms = updateData.RentalTerms
+	game.DepositMode = updateData.DepositMode
 
+	if oldSlug != "" && game.Slug != oldSlug {
+		return s.gameRepo.UpdateWithSlugRedirect(game, oldSlug)
+	}
 	return s.gameRepo.Update(game)
 }
 
@@ -98,6 +178,160 @@ func (s *gameService) Delete(requestorRole model.UserRole, gameID uint) error {
 	return s.gameRepo.Delete(gameID)
 }
 
+// BulkSetActive only changes catalog visibility; existing bookings are left untouched.
+// Missing or foreign games are reported per ID while the rest of the batch is applied.
+func (s *gameService) BulkSetActive(adminID uint, requestorRole model.UserRole, gameIDs []uint, isActive bool) ([]dto.BulkGameStatusResult, error) {
+	if !s.canManageGames(requestorRole) {
+		return nil, ErrGameInsufficientPermission
+	}
+
+	games, err := s.gameRepo.GetByIDs(gameIDs)
+	if err != nil {
+		return nil, err
+	}
+	gamesByID := make(map[uint]*model.Game, len(games))
+	for _, game := range games {
+		gamesByID[game.ID] = game
+	}
+
+	results := make([]dto.BulkGameStatusResult, 0, len(gameIDs))
+	allowed := make([]uint, 0, len(gameIDs))
+	seen := make(map[uint]bool, len(gameIDs))
+	for _, id := range gameIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		game, ok := gamesByID[id]
+		switch {
+		case !ok:
+			results = append(results, dto.BulkGameStatusResult{GameID: id, Error: ErrGameNotFound.Error()})
+		case !requestorRole.Permissions().CanManageAnyGame && game.AdminID != adminID:
+			results = append(results, dto.BulkGameStatusResult{GameID: id, Error: ErrGameNotOwned.Error()})
+		default:
+			results = append(results, dto.BulkGameStatusResult{GameID: id, Success: true})
+			allowed = append(allowed, id)
+		}
+	}
+
+	if len(allowed) > 0 {
+		if err := s.gameRepo.BulkSetActive(allowed, isActive); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// AddImage stores data under games/{id}/ and appends its public URL to the game.
+// The type is sniffed from the bytes, so a mislabelled upload is still rejected.
+func (s *gameService) AddImage(ctx context.Context, adminID uint, requestorRole model.UserRole, gameID uint, data []byte) (*model.Game, error) {
+	if len(data) == 0 {
+		return nil, ErrGameImageEmpty
+	}
+	if len(data) > storage.MaxFileSize {
+		return nil, ErrGameImageTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := gameImageExtensions[contentType]
+	if !ok {
+		return nil, ErrGameImageType
+	}
+
+	game, err := s.getOwnedGame(adminID, requestorRole, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if len(game.Images) >= maxGameImages {
+		return nil, ErrGameImageLimit
+	}
+
+	name, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	fileName := name[:32] + ext
+	url, err := s.storageRepo.UploadFile(ctx, fmt.Sprintf("games/%d/%s", game.ID, fileName), fileName, contentType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	game.Images = append(game.Images, url)
+	if err := s.gameRepo.Update(game); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// RemoveImage strips imageURL from the game and deletes the object when it is one of this game's
+// uploads (games/<id>/ in our bucket). Any other URL, added by hand through create/update, is only
+// removed from the list, so an admin can't delete another game's files through their own game.
+func (s *gameService) RemoveImage(ctx context.Context, adminID uint, requestorRole model.UserRole, gameID uint, imageURL string) (*model.Game, error) {
+	game, err := s.getOwnedGame(adminID, requestorRole, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make(model.StringList, 0, len(game.Images))
+	for _, img := range game.Images {
+		if img != imageURL {
+			remaining = append(remaining, img)
+		}
+	}
+	if len(remaining) == len(game.Images) {
+		return nil, ErrGameImageNotFound
+	}
+
+	game.Images = remaining
+	if err := s.gameRepo.Update(game); err != nil {
+		return nil, err
+	}
+
+	// Deleted after the update: a failed delete only leaves an orphaned file, never a broken URL
+	path, ok := strings.CutPrefix(imageURL, s.storageRepo.GetPublicURL(""))
+	if ok && strings.HasPrefix(path, fmt.Sprintf("games/%d/", gameID)) && !strings.Contains(path, "..") {
+		if err := s.storageRepo.DeleteFile(ctx, path); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{"game_id": gameID, "path": path}).Error("Failed to delete game image")
+		}
+	}
+	return game, nil
+}
+
+// getOwnedGame loads a game the requestor may edit: their own, or any game for super admins
+func (s *gameService) getOwnedGame(adminID uint, requestorRole model.UserRole, gameID uint) (*model.Game, error) {
+	if !s.canManageGames(requestorRole) {
+		return nil, ErrGameInsufficientPermission
+	}
+
+	game, err := s.gameRepo.GetByID(gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+	if !requestorRole.Permissions().CanManageAnyGame && game.AdminID != adminID {
+		return nil, ErrGameNotOwned
+	}
+	return game, nil
+}
+
+// uniqueSlug slugifies name and appends -2, -3, ... until no other game holds the result
+func (s *gameService) uniqueSlug(name string, gameID uint) (string, error) {
+	base := utils.Slugify(name)
+	for n := 1; ; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+		inUse, err := s.gameRepo.SlugInUse(candidate, gameID)
+		if err != nil {
+			return "", err
+		}
+		if !inUse {
+			return candidate, nil
+		}
+	}
+}
+
 func (s *gameService) canManageGames(role model.UserRole) bool {
-	return role == model.RoleAdmin || role == model.RoleSuperAdmin
+	return role.Permissions().CanManageGames
 }
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/repository/storage"
+	"gorm.io/gorm"
+)
+
+// searchGameRepository serves one page of matches out of a larger total
+type searchGameRepository struct {
+	repository.GameRepository
+	page  []*model.Game
+	total int64
+}
+
+func (r *searchGameRepository) Search(query string, limit, offset int) ([]*model.Game, error) {
+	return r.page, nil
+}
+
+func (r *searchGameRepository) CountSearch(query string) (int64, error) {
+	return r.total, nil
+}
+
+// ============= TEST SEARCH TOTAL =============
+func TestSearch_ReturnsTotalAcrossPages(t *testing.T) {
+	page := make([]*model.Game, 10)
+	for i := range page {
+		page[i] = &model.Game{ID: uint(i + 1)}
+	}
+	svc := NewGameService(&searchGameRepository{page: page, total: 25}, nil)
+
+	games, total, err := svc.Search("zelda", 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, games, 10)
+	assert.Equal(t, int64(25), total)
+}
+
+// imageGameRepository holds a single game and records updates
+type imageGameRepository struct {
+	repository.GameRepository
+	game    *model.Game
+	updated bool
+}
+
+func (r *imageGameRepository) GetByID(id uint) (*model.Game, error) {
+	return r.game, nil
+}
+
+func (r *imageGameRepository) Update(game *model.Game) error {
+	r.updated = true
+	return nil
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// ============= TEST GAME IMAGES =============
+func TestAddImage_Success(t *testing.T) {
+	repo := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 1}}
+	store := &storage.MockStorageRepository{}
+	svc := NewGameService(repo, store)
+
+	game, err := svc.AddImage(context.Background(), 1, model.RoleAdmin, 7, pngHeader)
+
+	assert.NoError(t, err)
+	assert.True(t, repo.updated)
+	if assert.Len(t, store.UploadedFiles, 1) {
+		assert.Regexp(t, `^games/7/[0-9a-f]{32}\.png$`, store.UploadedFiles[0].Path)
+		assert.Equal(t, "image/png", store.UploadedFiles[0].ContentType)
+		assert.Equal(t, model.StringList{"https://mock-storage.com/" + store.UploadedFiles[0].Path}, game.Images)
+	}
+}
+
+func TestAddImage_InvalidContentType(t *testing.T) {
+	repo := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 1}}
+	store := &storage.MockStorageRepository{}
+	svc := NewGameService(repo, store)
+
+	_, err := svc.AddImage(context.Background(), 1, model.RoleAdmin, 7, []byte("GIF89a not allowed"))
+
+	assert.Equal(t, ErrGameImageType, err)
+	assert.Empty(t, store.UploadedFiles)
+	assert.False(t, repo.updated)
+}
+
+func TestAddImage_TooLarge(t *testing.T) {
+	repo := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 1}}
+	store := &storage.MockStorageRepository{}
+	svc := NewGameService(repo, store)
+
+	data := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, storage.MaxFileSize)...)
+	_, err := svc.AddImage(context.Background(), 1, model.RoleAdmin, 7, data)
+
+	assert.Equal(t, ErrGameImageTooLarge, err)
+	assert.Empty(t, store.UploadedFiles)
+}
+
+func TestAddImage_NotOwned(t *testing.T) {
+	repo := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 2}}
+	svc := NewGameService(repo, &storage.MockStorageRepository{})
+
+	_, err := svc.AddImage(context.Background(), 1, model.RoleAdmin, 7, pngHeader)
+
+	assert.Equal(t, ErrGameNotOwned, err)
+}
+
+func TestRemoveImage_StripsURL(t *testing.T) {
+	repo := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 1, Images: model.StringList{
+		"https://mock-storage.com/games/7/a.png",
+		"https://example.com/b.png",
+	}}}
+	store := &storage.MockStorageRepository{}
+	svc := NewGameService(repo, store)
+
+	game, err := svc.RemoveImage(context.Background(), 1, model.RoleAdmin, 7, "https://mock-storage.com/games/7/a.png")
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.StringList{"https://example.com/b.png"}, game.Images)
+	assert.True(t, repo.updated)
+	assert.Equal(t, []string{"games/7/a.png"}, store.DeletedFiles)
+}
+
+func TestRemoveImage_KeepsOtherGamesFiles(t *testing.T) {
+	for _, url := range []string{
+		"https://mock-storage.com/games/8/a.png",
+		"https://mock-storage.com/games/7/../8/a.png",
+		"https://mock-storage.com/avatars/a.png",
+	} {
+		repo := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 1, Images: model.StringList{url}}}
+		store := &storage.MockStorageRepository{}
+		svc := NewGameService(repo, store)
+
+		game, err := svc.RemoveImage(context.Background(), 1, model.RoleAdmin, 7, url)
+
+		assert.NoError(t, err)
+		assert.Empty(t, game.Images)
+		assert.Empty(t, store.DeletedFiles, url)
+	}
+}
+
+func TestRemoveImage_UnknownURL(t *testing.T) {
+	repo := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 1, Images: model.StringList{"https://example.com/b.png"}}}
+	svc := NewGameService(repo, &storage.MockStorageRepository{})
+
+	_, err := svc.RemoveImage(context.Background(), 1, model.RoleAdmin, 7, "https://example.com/other.png")
+
+	assert.Equal(t, ErrGameImageNotFound, err)
+	assert.False(t, repo.updated)
+}
+
+// slugGameRepository tracks live slugs by game id and old slugs in redirects
+type slugGameRepository struct {
+	repository.GameRepository
+	games     map[uint]*model.Game
+	redirects map[string]uint
+	created   *model.Game
+}
+
+func (r *slugGameRepository) GetByID(id uint) (*model.Game, error) {
+	if game, ok := r.games[id]; ok {
+		return game, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *slugGameRepository) GetBySlug(slug string) (*model.Game, error) {
+	for _, game := range r.games {
+		if game.Slug == slug {
+			return game, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *slugGameRepository) GetGameIDBySlugRedirect(slug string) (uint, error) {
+	if id, ok := r.redirects[slug]; ok {
+		return id, nil
+	}
+	return 0, gorm.ErrRecordNotFound
+}
+
+func (r *slugGameRepository) SlugInUse(slug string, excludeGameID uint) (bool, error) {
+	for id, game := range r.games {
+		if id != excludeGameID && game.Slug == slug {
+			return true, nil
+		}
+	}
+	id, ok := r.redirects[slug]
+	return ok && id != excludeGameID, nil
+}
+
+func (r *slugGameRepository) Create(game *model.Game) error {
+	r.created = game
+	return nil
+}
+
+func (r *slugGameRepository) Update(game *model.Game) error {
+	return nil
+}
+
+func (r *slugGameRepository) UpdateWithSlugRedirect(game *model.Game, oldSlug string) error {
+	delete(r.redirects, game.Slug)
+	r.redirects[oldSlug] = game.ID
+	return nil
+}
+
+// ============= TEST GAME SLUGS =============
+func TestCreate_SlugSkipsTakenSlugs(t *testing.T) {
+	repo := &slugGameRepository{
+		games:     map[uint]*model.Game{1: {ID: 1, Slug: "halo-infinite"}},
+		redirects: map[string]uint{"halo-infinite-2": 2},
+	}
+	svc := NewGameService(repo, nil)
+
+	err := svc.Create(1, model.RoleAdmin, &model.Game{Name: "Halo: Infinite"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "halo-infinite-3", repo.created.Slug)
+}
+
+func TestUpdate_RenameKeepsOldSlugAsRedirect(t *testing.T) {
+	repo := &slugGameRepository{
+		games:     map[uint]*model.Game{7: {ID: 7, AdminID: 1, Name: "Zelda", Slug: "zelda"}},
+		redirects: map[string]uint{},
+	}
+	svc := NewGameService(repo, nil)
+
+	err := svc.Update(1, model.RoleAdmin, 7, &model.Game{Name: "Zelda: Tears of the Kingdom"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "zelda-tears-of-the-kingdom", repo.games[7].Slug)
+	assert.Equal(t, map[string]uint{"zelda": 7}, repo.redirects)
+
+	game, err := svc.GetBySlug("zelda")
+	assert.NoError(t, err)
+	assert.Equal(t, "zelda-tears-of-the-kingdom", game.Slug)
+}
+
+func TestUpdate_SameNameKeepsSlug(t *testing.T) {
+	repo := &slugGameRepository{
+		games:     map[uint]*model.Game{7: {ID: 7, AdminID: 1, Name: "Zelda", Slug: "zelda"}},
+		redirects: map[string]uint{},
+	}
+	svc := NewGameService(repo, nil)
+
+	assert.NoError(t, svc.Update(1, model.RoleAdmin, 7, &model.Game{Name: "Zelda"}))
+	assert.Equal(t, "zelda", repo.games[7].Slug)
+	assert.Empty(t, repo.redirects)
+}
+
+func TestGetBySlug_Unknown(t *testing.T) {
+	svc := NewGameService(&slugGameRepository{games: map[uint]*model.Game{}, redirects: map[string]uint{}}, nil)
+
+	_, err := svc.GetBySlug("missing")
+
+	assert.Equal(t, ErrGameNotFound, err)
+}
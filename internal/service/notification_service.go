@@ -0,0 +1,70 @@
+package service
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+)
+
+var (
+	ErrNotificationNotFound = utils.NewNotFoundError("notification_not_found", "notification not found")
+)
+
+type NotificationService interface {
+	// System (called next to email dispatch)
+	Notify(userID uint, notificationType model.NotificationType, title, message string, bookingID *uint)
+
+	// Customer
+	GetMyNotifications(userID uint, limit, offset int) ([]*model.Notification, int64, int64, error)
+	MarkAsRead(userID, notificationID uint) error
+}
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationService(notificationRepo repository.NotificationRepository) NotificationService {
+	return &notificationService{notificationRepo: notificationRepo}
+}
+
+// Notify records an in-app notification. Failures are logged, never returned, so a
+// notification problem can't fail the business operation that triggered it.
+func (s *notificationService) Notify(userID uint, notificationType model.NotificationType, title, message string, bookingID *uint) {
+	notification := &model.Notification{
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		BookingID: bookingID,
+	}
+	if err := s.notificationRepo.Create(notification); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"user_id": userID,
+			"type":    notificationType,
+		}).Error("Failed to record notification")
+	}
+}
+
+// GetMyNotifications returns a page of notifications plus the total and unread counts
+func (s *notificationService) GetMyNotifications(userID uint, limit, offset int) ([]*model.Notification, int64, int64, error) {
+	notifications, err := s.notificationRepo.GetUserNotifications(userID, limit, offset)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	total, err := s.notificationRepo.CountUserNotifications(userID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(userID)
+	return notifications, total, unread, err
+}
+
+func (s *notificationService) MarkAsRead(userID, notificationID uint) error {
+	if err := s.notificationRepo.MarkAsRead(notificationID, userID); err != nil {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"net/http"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -11,28 +14,53 @@ import (
 	"github.com/yoockh/go-game-rental-api/internal/repository"
 	"github.com/yoockh/go-game-rental-api/internal/repository/email"
 	"github.com/yoockh/go-game-rental-api/internal/repository/transaction"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
 
 var (
-	ErrPaymentNotFound               = errors.New("payment not found")
-	ErrPaymentAlreadyExists          = errors.New("payment already exists for this booking")
-	ErrPaymentBookingNotFound        = errors.New("booking not found")
-	ErrPaymentInvalidStatus          = errors.New("invalid payment status transition")
-	ErrPaymentInsufficientPermission = errors.New("insufficient permission")
+	ErrPaymentNotFound               = utils.NewNotFoundError("payment_not_found", "payment not found")
+	ErrPaymentAlreadyExists          = utils.NewConflictError("payment_already_exists", "payment already exists for this booking")
+	ErrPaymentBookingNotFound        = utils.NewNotFoundError("booking_not_found", "booking not found")
+	ErrPaymentBookingNotOwned        = utils.NewForbiddenError("booking_not_owned", "unauthorized")
+	ErrPaymentInvalidStatus          = utils.NewConflictError("payment_invalid_status", "invalid payment status transition")
+	ErrPaymentInsufficientPermission = utils.NewForbiddenError("insufficient_permission", "insufficient permission")
+	ErrPaymentAlreadyPaid            = utils.NewConflictError("payment_already_paid", "payment already paid")
+	ErrPaymentProviderUnsupported    = utils.NewBadRequestError("payment_provider_unsupported", "unsupported payment provider")
+	ErrRefundAmountInvalid           = utils.NewBadRequestError("refund_amount_invalid", "refund amount must be positive and not exceed the payment amount")
+	ErrWebhookInvalidSignature       = utils.NewServiceError("webhook_invalid_signature", http.StatusUnauthorized, "invalid webhook signature")
+	ErrWebhookInvalidPayload         = utils.NewBadRequestError("webhook_invalid_payload", "webhook payload must be a JSON object")
+	ErrWebhookProviderMismatch       = utils.NewBadRequestError("webhook_provider_mismatch", "payment belongs to a different provider")
+	ErrDepositHoldPaymentType        = utils.NewBadRequestError("deposit_hold_payment_type", "this game holds the deposit on a card; use payment_type credit_card")
+	ErrDepositHoldCardToken          = utils.NewBadRequestError("deposit_hold_card_token", "this game holds the deposit on a card; send the card_token from Midtrans.js")
+	ErrDepositHoldNotSettleable      = utils.NewConflictError("deposit_hold_not_settleable", "capture the hold once the game is returned (or overdue) and void it only for cancelled bookings")
+	ErrRevenueRangeInvalid           = utils.NewBadRequestError("revenue_range_invalid", "from must not be after to")
+	ErrRevenueRangeTooLong           = utils.NewBadRequestError("revenue_range_too_long", "revenue report range is limited to one year")
+	ErrRevenueGroupByInvalid         = utils.NewBadRequestError("revenue_group_by_invalid", "group_by must be day, week or month")
+	ErrPaymentStatusInvalid          = utils.NewBadRequestError("payment_status_invalid", "unknown payment status")
+	ErrExportRangeInvalid            = utils.NewBadRequestError("export_range_invalid", "from must not be after to")
 )
 
 type PaymentService interface {
 	// Customer methods
-	CreatePayment(userID uint, bookingID uint, provider model.PaymentProvider, paymentType string) (*model.Payment, error)
+	CreatePayment(ctx context.Context, userID uint, bookingID uint, provider model.PaymentProvider, paymentType, cardToken string) (*model.Payment, error)
 	GetPaymentByBooking(userID uint, bookingID uint) (*model.Payment, error)
+	GetBookingByPayment(userID uint, paymentID uint) (*model.Booking, error)
 
 	// Admin methods
 	GetAllPayments(requestorRole model.UserRole, limit, offset int) ([]*model.Payment, int64, error)
 	GetPaymentsByStatus(requestorRole model.UserRole, status model.PaymentStatus, limit, offset int) ([]*model.Payment, int64, error)
 	GetPaymentDetail(requestorRole model.UserRole, paymentID uint) (*model.Payment, error)
+	GetPaymentByProviderID(requestorRole model.UserRole, providerPaymentID string) (*model.Payment, error)
+	GetRevenueReport(requestorRole model.UserRole, from, to time.Time, groupBy string) ([]repository.RevenueBucket, error)
+	ExportPayments(requestorRole model.UserRole, filter repository.PaymentExportFilter, fn func([]*model.Payment) error) error
+	MarkPaidManually(ctx context.Context, requestorID uint, requestorRole model.UserRole, paymentID uint, method, note string) (*model.Payment, error)
+	MarkFailedManually(requestorID uint, requestorRole model.UserRole, paymentID uint, reason string) (*model.Payment, error)
+	RefundPayment(requestorID uint, requestorRole model.UserRole, paymentID uint, amount float64, reason string) (*model.Payment, error)
+	CaptureDepositHold(requestorID uint, requestorRole model.UserRole, paymentID uint, keepDeposit bool, note string) (*model.Payment, error)
+	VoidDepositHold(requestorID uint, requestorRole model.UserRole, paymentID uint, reason string) (*model.Payment, error)
 
 	// Webhook/System methods
-	ProcessWebhook(data interface{}) error
+	ProcessWebhook(ctx context.Context, provider model.PaymentProvider, payload []byte, stripeSignature string) error
 }
 
 type paymentService struct {
@@ -42,7 +70,13 @@ type paymentService struct {
 	gameRepo        repository.GameRepository
 	bookingService  BookingService
 	transactionRepo transaction.TransactionRepository
+	stripeRepo      transaction.TransactionRepository // nil when Stripe isn't configured
 	emailRepo       email.EmailRepository
+	notifier        NotificationService
+	auditRepo       repository.BookingAuditRepository
+	paymentExpiry   time.Duration // zero keeps the provider's default expiry
+	// depositMode applies to games that don't set their own
+	depositMode model.DepositMode
 }
 
 func NewPaymentService(
@@ -52,8 +86,16 @@ func NewPaymentService(
 	gameRepo repository.GameRepository,
 	bookingService BookingService,
 	transactionRepo transaction.TransactionRepository,
+	stripeRepo transaction.TransactionRepository,
 	emailRepo email.EmailRepository,
+	notifier NotificationService,
+	auditRepo repository.BookingAuditRepository,
+	paymentExpiry time.Duration,
+	depositMode model.DepositMode,
 ) PaymentService {
+	if !depositMode.IsValid() {
+		depositMode = model.DepositCharge
+	}
 	return &paymentService{
 		paymentRepo:     paymentRepo,
 		bookingRepo:     bookingRepo,
@@ -61,11 +103,18 @@ func NewPaymentService(
 		gameRepo:        gameRepo,
 		bookingService:  bookingService,
 		transactionRepo: transactionRepo,
+		stripeRepo:      stripeRepo,
 		emailRepo:       emailRepo,
+		notifier:        notifier,
+		auditRepo:       auditRepo,
+		paymentExpiry:   paymentExpiry,
+		depositMode:     depositMode,
 	}
 }
 
-func (s *paymentService) CreatePayment(userID uint, bookingID uint, provider model.PaymentProvider, paymentType string) (*model.Payment, error) {
+// CreatePayment charges, or for deposit holds authorizes, the booking total. cardToken is the
+// Midtrans.js token of the customer's card; Midtrans needs it for card payments, Stripe ignores it.
+func (s *paymentService) CreatePayment(ctx context.Context, userID uint, bookingID uint, provider model.PaymentProvider, paymentType, cardToken string) (*model.Payment, error) {
 	// Get booking and validate ownership
 	booking, err := s.bookingRepo.GetByID(bookingID)
 	if err != nil {
@@ -73,11 +122,11 @@ func (s *paymentService) CreatePayment(userID uint, bookingID uint, provider mod
 	}
 
 	if booking.UserID != userID {
-		return nil, errors.New("unauthorized")
+		return nil, ErrPaymentBookingNotOwned
 	}
 
 	if booking.Status != model.BookingPending {
-		return nil, errors.New("booking must be in pending status")
+		return nil, ErrBookingNotPending
 	}
 
 	// Check if payment already exists
@@ -86,12 +135,37 @@ func (s *paymentService) CreatePayment(userID uint, bookingID uint, provider mod
 		return nil, ErrPaymentAlreadyExists
 	}
 
-	// Create payment record
+	depositMode := s.depositModeFor(booking)
+	if depositMode == model.DepositHold && provider == model.ProviderMidtrans {
+		if paymentType == "" {
+			paymentType = "credit_card"
+		} else if paymentType != "credit_card" {
+			return nil, ErrDepositHoldPaymentType
+		}
+		if cardToken == "" {
+			return nil, ErrDepositHoldCardToken
+		}
+	}
+
+	// Create payment record; the stored amount is exactly what the gateway will charge
 	payment := &model.Payment{
-		BookingID: bookingID,
-		Provider:  provider,
-		Amount:    booking.TotalAmount,
-		Status:    model.PaymentPending,
+		BookingID:   bookingID,
+		Provider:    provider,
+		Amount:      float64(gatewayAmount(booking.TotalAmount)),
+		Status:      model.PaymentPending,
+		DepositMode: depositMode,
+	}
+	chargeParams := map[string]interface{}{}
+	if s.paymentExpiry > 0 {
+		expiresAt := time.Now().Add(s.paymentExpiry)
+		payment.ExpiresAt = &expiresAt
+		chargeParams["expiry_minutes"] = int(s.paymentExpiry / time.Minute)
+	}
+	if depositMode == model.DepositHold {
+		chargeParams["authorize_only"] = true
+	}
+	if cardToken != "" {
+		chargeParams["token_id"] = cardToken
 	}
 
 	err = s.paymentRepo.Create(payment)
@@ -112,9 +186,9 @@ func (s *paymentService) CreatePayment(userID uint, bookingID uint, provider mod
 		txID, _, err := s.transactionRepo.CreateCharge(
 			context.Background(),
 			orderID,
-			int64(payment.Amount),
+			gatewayAmount(payment.Amount),
 			paymentType,
-			nil,
+			chargeParams,
 		)
 		if err != nil {
 			return payment, fmt.Errorf("midtrans payment gateway error: %w", err)
@@ -125,16 +199,37 @@ func (s *paymentService) CreatePayment(userID uint, bookingID uint, provider mod
 		s.paymentRepo.Update(payment)
 
 	case model.ProviderStripe:
-		return payment, errors.New("stripe payment provider not implemented yet")
+		if s.stripeRepo == nil {
+			return payment, ErrPaymentProviderUnsupported
+		}
+
+		intentID, clientSecret, err := s.stripeRepo.CreateCharge(
+			context.Background(),
+			orderID,
+			gatewayAmount(payment.Amount),
+			paymentType,
+			chargeParams,
+		)
+		if err != nil {
+			return payment, fmt.Errorf("stripe payment gateway error: %w", err)
+		}
+
+		payment.ProviderPaymentID = &intentID
+		s.paymentRepo.Update(payment)
+		payment.ClientSecret = clientSecret
 
 	default:
-		return payment, errors.New("unsupported payment provider")
+		return payment, ErrPaymentProviderUnsupported
 	}
 
+	s.notifier.Notify(userID, model.NotificationPaymentPending, "Complete your payment",
+		fmt.Sprintf("Pay Rp %.0f within %s to confirm your booking for %s.", payment.Amount, s.paymentWindow(), booking.Game.Name), &booking.ID)
+
 	// SEND EMAIL: Payment instruction
 	user, _ := s.userRepo.GetByID(userID)
 	game, _ := s.gameRepo.GetByID(booking.GameID)
 	if user != nil && game != nil {
+		mailCtx := context.WithoutCancel(ctx)
 		go func() {
 			subject := "Payment Instruction - Game Rental"
 			orderIDStr := "N/A"
@@ -151,12 +246,12 @@ func (s *paymentService) CreatePayment(userID uint, bookingID uint, provider mod
 					<li><strong>Amount:</strong> Rp %.0f</li>
 					<li><strong>Game:</strong> %s</li>
 				</ul>
-				<p>Complete within 24 hours.</p>
-			`, user.FullName, orderIDStr, payment.Amount, game.Name)
+				<p>Complete within %s.</p>
+			`, user.FullName, orderIDStr, payment.Amount, game.Name, s.paymentWindow())
 
 			plainText := fmt.Sprintf("Payment instruction. Order ID: %s, Amount: Rp %.0f", orderIDStr, payment.Amount)
 
-			if err := s.emailRepo.SendEmail(context.Background(), user.Email, subject, plainText, htmlContent); err != nil {
+			if err := s.emailRepo.SendEmail(mailCtx, user.Email, subject, plainText, htmlContent); err != nil {
 				logrus.WithError(err).Error("Failed to send payment instruction email")
 			}
 		}()
@@ -165,6 +260,44 @@ func (s *paymentService) CreatePayment(userID uint, bookingID uint, provider mod
 	return payment, nil
 }
 
+// depositHoldWindow is how long a card authorization stays capturable. Stripe and Midtrans
+// both release uncaptured authorizations after about 7 days.
+const depositHoldWindow = 7 * 24 * time.Hour
+
+// gatewayAmount rounds half away from zero to whole units, the smallest unit the gateways
+// accept (IDR has none below the rupiah). Plain int64 conversion would charge 149999 for 149999.99.
+func gatewayAmount(amount float64) int64 {
+	return int64(math.Round(amount))
+}
+
+// depositModeFor picks the game's deposit mode, falling back to the configured default.
+// Bookings without a deposit are always charged, since there is nothing to hold, and so are
+// bookings ending after depositHoldWindow: the hold would expire before it could be captured.
+func (s *paymentService) depositModeFor(booking *model.Booking) model.DepositMode {
+	if booking.SecurityDeposit <= 0 {
+		return model.DepositCharge
+	}
+	mode := s.depositMode
+	if booking.Game.DepositMode.IsValid() {
+		mode = booking.Game.DepositMode
+	}
+	if mode == model.DepositHold && booking.EndDate.AddDate(0, 0, 1).After(time.Now().Add(depositHoldWindow)) {
+		return model.DepositCharge
+	}
+	return mode
+}
+
+// paymentWindow describes how long the customer has to pay, for notifications and emails
+func (s *paymentService) paymentWindow() string {
+	if s.paymentExpiry <= 0 {
+		return "24 hours"
+	}
+	if s.paymentExpiry%time.Hour == 0 {
+		return fmt.Sprintf("%d hours", int(s.paymentExpiry/time.Hour))
+	}
+	return fmt.Sprintf("%d minutes", int(s.paymentExpiry/time.Minute))
+}
+
 func (s *paymentService) GetPaymentByBooking(userID uint, bookingID uint) (*model.Payment, error) {
 	// Validate booking ownership
 	booking, err := s.bookingRepo.GetByID(bookingID)
@@ -173,12 +306,31 @@ func (s *paymentService) GetPaymentByBooking(userID uint, bookingID uint) (*mode
 	}
 
 	if booking.UserID != userID {
-		return nil, errors.New("unauthorized")
+		return nil, ErrPaymentBookingNotOwned
 	}
 
 	return s.paymentRepo.GetByBookingID(bookingID)
 }
 
+// GetBookingByPayment is the inverse of GetPaymentByBooking, for customers starting from a receipt
+func (s *paymentService) GetBookingByPayment(userID uint, paymentID uint) (*model.Booking, error) {
+	payment, err := s.paymentRepo.GetByID(paymentID)
+	if err != nil {
+		return nil, ErrPaymentNotFound
+	}
+
+	booking, err := s.bookingRepo.GetByID(payment.BookingID)
+	if err != nil {
+		return nil, ErrPaymentBookingNotFound
+	}
+
+	if booking.UserID != userID {
+		return nil, ErrPaymentBookingNotOwned
+	}
+
+	return booking, nil
+}
+
 func (s *paymentService) GetAllPayments(requestorRole model.UserRole, limit, offset int) ([]*model.Payment, int64, error) {
 	if !s.canManagePayments(requestorRole) {
 		return nil, 0, ErrPaymentInsufficientPermission
@@ -193,6 +345,53 @@ func (s *paymentService) GetAllPayments(requestorRole model.UserRole, limit, off
 	return payments, count, err
 }
 
+// GetRevenueReport sums paid payments by paid_at between the from and to days, both
+// inclusive, bucketed by day, week or month
+func (s *paymentService) GetRevenueReport(requestorRole model.UserRole, from, to time.Time, groupBy string) ([]repository.RevenueBucket, error) {
+	if !s.canManagePayments(requestorRole) {
+		return nil, ErrPaymentInsufficientPermission
+	}
+
+	switch groupBy {
+	case repository.RevenueByDay, repository.RevenueByWeek, repository.RevenueByMonth:
+	default:
+		return nil, ErrRevenueGroupByInvalid
+	}
+
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if from.After(to) {
+		return nil, ErrRevenueRangeInvalid
+	}
+	if to.After(from.AddDate(1, 0, 0)) {
+		return nil, ErrRevenueRangeTooLong
+	}
+
+	return s.paymentRepo.GetRevenueBuckets(from, to.AddDate(0, 0, 1), groupBy)
+}
+
+// exportBatchSize is how many payments ExportPayments loads per query
+const exportBatchSize = 500
+
+// ExportPayments hands the payments matching filter to fn in batches, oldest first
+func (s *paymentService) ExportPayments(requestorRole model.UserRole, filter repository.PaymentExportFilter, fn func([]*model.Payment) error) error {
+	if !s.canManagePayments(requestorRole) {
+		return ErrPaymentInsufficientPermission
+	}
+
+	switch filter.Status {
+	case "", model.PaymentPending, model.PaymentAuthorized, model.PaymentPaid, model.PaymentFailed,
+		model.PaymentRefunded, model.PaymentExpired, model.PaymentVoided:
+	default:
+		return ErrPaymentStatusInvalid
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return ErrExportRangeInvalid
+	}
+
+	return s.paymentRepo.ExportInBatches(filter, exportBatchSize, fn)
+}
+
 func (s *paymentService) GetPaymentsByStatus(requestorRole model.UserRole, status model.PaymentStatus, limit, offset int) ([]*model.Payment, int64, error) {
 	if !s.canManagePayments(requestorRole) {
 		return nil, 0, ErrPaymentInsufficientPermission
@@ -215,56 +414,445 @@ func (s *paymentService) GetPaymentDetail(requestorRole model.UserRole, paymentI
 	return s.paymentRepo.GetByIDWithRelations(paymentID)
 }
 
-func (s *paymentService) ProcessWebhook(data interface{}) error {
-	webhookData, ok := data.(map[string]interface{})
-	if !ok {
-		return errors.New("invalid webhook data")
+// GetPaymentByProviderID finds a payment from a gateway reference (Midtrans transaction ID or Stripe intent ID)
+func (s *paymentService) GetPaymentByProviderID(requestorRole model.UserRole, providerPaymentID string) (*model.Payment, error) {
+	if !s.canManagePayments(requestorRole) {
+		return nil, ErrPaymentInsufficientPermission
 	}
 
-	providerPaymentID, ok := webhookData["order_id"].(string)
-	if !ok {
-		return errors.New("missing order_id in webhook")
+	payment, err := s.paymentRepo.GetByProviderPaymentID(providerPaymentID)
+	if err != nil {
+		return nil, ErrPaymentNotFound
 	}
+	return s.paymentRepo.GetByIDWithRelations(payment.ID)
+}
 
-	transactionStatus, ok := webhookData["transaction_status"].(string)
-	if !ok {
-		return errors.New("missing transaction_status in webhook")
+func (s *paymentService) MarkPaidManually(ctx context.Context, requestorID uint, requestorRole model.UserRole, paymentID uint, method, note string) (*model.Payment, error) {
+	if !s.canManagePayments(requestorRole) {
+		return nil, ErrPaymentInsufficientPermission
 	}
 
-	payment, err := s.paymentRepo.GetByProviderPaymentID(providerPaymentID)
+	payment, err := s.paymentRepo.GetByIDWithRelations(paymentID)
 	if err != nil {
-		return ErrPaymentNotFound
+		return nil, ErrPaymentNotFound
+	}
+
+	if payment.Status == model.PaymentPaid {
+		return nil, ErrPaymentAlreadyPaid
+	}
+	if payment.Status != model.PaymentPending {
+		return nil, ErrPaymentInvalidStatus
+	}
+	isLateFee := payment.Purpose == model.PaymentPurposeLateFee
+	if !isLateFee && payment.Booking.Status != model.BookingPending {
+		return nil, ErrBookingNotPending
+	}
+
+	if method == "" {
+		method = "manual"
+	}
+
+	marked, err := s.paymentRepo.MarkAsPaidManually(paymentID, requestorID, method, note)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		// Another request settled the payment between the read and the update
+		return nil, ErrPaymentAlreadyPaid
+	}
+
+	// ConfirmPayment also sends the payment confirmation email to the customer.
+	// Late fees are charged after the return, so the booking is already settled.
+	if !isLateFee {
+		if err := s.bookingService.ConfirmPayment(ctx, payment.BookingID); err != nil {
+			return nil, err
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"payment_id": paymentID,
+		"booking_id": payment.BookingID,
+		"admin_id":   requestorID,
+		"method":     method,
+	}).Info("Payment manually marked as paid")
+	s.recordAudit(payment, requestorID, model.AuditPaymentMarkedPaid, model.FieldChanges{
+		"payment_status": {Old: payment.Status, New: model.PaymentPaid},
+		"payment_method": {Old: payment.PaymentMethod, New: method},
+	})
+
+	return s.paymentRepo.GetByIDWithRelations(paymentID)
+}
+
+func (s *paymentService) MarkFailedManually(requestorID uint, requestorRole model.UserRole, paymentID uint, reason string) (*model.Payment, error) {
+	if !s.canManagePayments(requestorRole) {
+		return nil, ErrPaymentInsufficientPermission
+	}
+
+	payment, err := s.paymentRepo.GetByID(paymentID)
+	if err != nil {
+		return nil, ErrPaymentNotFound
+	}
+
+	// Only stuck pending payments can be voided; paid ones need a refund instead
+	if payment.Status != model.PaymentPending {
+		return nil, ErrPaymentInvalidStatus
 	}
 
+	marked, err := s.paymentRepo.MarkAsFailedManually(paymentID, requestorID, reason)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, ErrPaymentInvalidStatus
+	}
+
+	// Cancels the booking and releases the reserved stock
+	if err := s.bookingService.FailPayment(payment.BookingID); err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"payment_id": paymentID,
+		"booking_id": payment.BookingID,
+		"admin_id":   requestorID,
+	}).Info("Payment manually marked as failed")
+	s.recordAudit(payment, requestorID, model.AuditPaymentMarkedFailed, model.FieldChanges{
+		"payment_status": {Old: payment.Status, New: model.PaymentFailed},
+		"reason":         {Old: nil, New: reason},
+	})
+
+	return s.paymentRepo.GetByIDWithRelations(paymentID)
+}
+
+// RefundPayment refunds all or part of a paid payment through its gateway, then records it
+func (s *paymentService) RefundPayment(requestorID uint, requestorRole model.UserRole, paymentID uint, amount float64, reason string) (*model.Payment, error) {
+	if !s.canManagePayments(requestorRole) {
+		return nil, ErrPaymentInsufficientPermission
+	}
+
+	payment, err := s.paymentRepo.GetByID(paymentID)
+	if err != nil {
+		return nil, ErrPaymentNotFound
+	}
+
+	if payment.Status != model.PaymentPaid {
+		return nil, ErrPaymentInvalidStatus
+	}
+	if amount <= 0 || amount > payment.Amount {
+		return nil, ErrRefundAmountInvalid
+	}
+
+	// Manually confirmed payments never went through a gateway, so there is nothing to call
+	var gateway transaction.TransactionRepository
+	if payment.ProviderPaymentID != nil {
+		if gateway, err = s.gatewayFor(payment); err != nil {
+			return nil, err
+		}
+	}
+
+	// Claim the refund before calling the gateway so two concurrent refunds can't both pay out
+	claimed, err := s.paymentRepo.MarkAsRefunded(paymentID, requestorID, amount, reason)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrPaymentInvalidStatus
+	}
+	if gateway != nil {
+		if err := gateway.Refund(context.Background(), *payment.ProviderPaymentID, gatewayAmount(amount)); err != nil {
+			if reopenErr := s.paymentRepo.ReopenRefund(paymentID); reopenErr != nil {
+				logrus.WithError(reopenErr).WithField("payment_id", paymentID).Error("Failed to reopen payment after refund error")
+			}
+			return nil, fmt.Errorf("refund failed: %w", err)
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"payment_id": paymentID,
+		"booking_id": payment.BookingID,
+		"admin_id":   requestorID,
+		"amount":     amount,
+	}).Info("Payment refunded")
+	s.recordAudit(payment, requestorID, model.AuditPaymentRefunded, model.FieldChanges{
+		"payment_status": {Old: payment.Status, New: model.PaymentRefunded},
+		"refund_amount":  {Old: nil, New: amount},
+		"reason":         {Old: nil, New: reason},
+	})
+
+	return s.paymentRepo.GetByIDWithRelations(paymentID)
+}
+
+// CaptureDepositHold settles an authorized hold once the game is back: it captures the rental
+// amount, plus the security deposit when keepDeposit is set, and the gateway releases the rest
+func (s *paymentService) CaptureDepositHold(requestorID uint, requestorRole model.UserRole, paymentID uint, keepDeposit bool, note string) (*model.Payment, error) {
+	if !s.canManagePayments(requestorRole) {
+		return nil, ErrPaymentInsufficientPermission
+	}
+
+	payment, err := s.paymentRepo.GetByIDWithRelations(paymentID)
+	if err != nil {
+		return nil, ErrPaymentNotFound
+	}
+
+	if payment.Status != model.PaymentAuthorized {
+		return nil, ErrPaymentInvalidStatus
+	}
+	if payment.Booking.Status != model.BookingCompleted && payment.Booking.Status != model.BookingOverdue {
+		return nil, ErrDepositHoldNotSettleable
+	}
+
+	amount := payment.Amount
+	if !keepDeposit {
+		amount -= payment.Booking.SecurityDeposit
+	}
+
+	gateway, err := s.gatewayFor(payment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the hold before touching the gateway so a concurrent capture or void can't settle it too
+	claimed, err := s.paymentRepo.MarkHoldCaptured(paymentID, requestorID, amount, !keepDeposit, note)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrPaymentInvalidStatus
+	}
+	if err := gateway.Capture(context.Background(), *payment.ProviderPaymentID, gatewayAmount(amount)); err != nil {
+		s.reopenHold(payment)
+		return nil, fmt.Errorf("capture failed: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"payment_id":   paymentID,
+		"booking_id":   payment.BookingID,
+		"admin_id":     requestorID,
+		"amount":       amount,
+		"keep_deposit": keepDeposit,
+	}).Info("Deposit hold captured")
+	s.recordAudit(payment, requestorID, model.AuditDepositCaptured, model.FieldChanges{
+		"payment_status":   {Old: payment.Status, New: model.PaymentPaid},
+		"amount":           {Old: payment.Amount, New: amount},
+		"deposit_released": {Old: false, New: !keepDeposit},
+	})
+
+	return s.paymentRepo.GetByIDWithRelations(paymentID)
+}
+
+// VoidDepositHold releases the whole hold for a booking that was cancelled before handover
+func (s *paymentService) VoidDepositHold(requestorID uint, requestorRole model.UserRole, paymentID uint, reason string) (*model.Payment, error) {
+	if !s.canManagePayments(requestorRole) {
+		return nil, ErrPaymentInsufficientPermission
+	}
+
+	payment, err := s.paymentRepo.GetByIDWithRelations(paymentID)
+	if err != nil {
+		return nil, ErrPaymentNotFound
+	}
+
+	if payment.Status != model.PaymentAuthorized {
+		return nil, ErrPaymentInvalidStatus
+	}
+	if payment.Booking.Status != model.BookingCancelled {
+		return nil, ErrDepositHoldNotSettleable
+	}
+
+	gateway, err := s.gatewayFor(payment)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.paymentRepo.MarkHoldVoided(paymentID, requestorID, reason)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrPaymentInvalidStatus
+	}
+	if err := gateway.Void(context.Background(), *payment.ProviderPaymentID); err != nil {
+		s.reopenHold(payment)
+		return nil, fmt.Errorf("void failed: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"payment_id": paymentID,
+		"booking_id": payment.BookingID,
+		"admin_id":   requestorID,
+	}).Info("Deposit hold voided")
+	s.recordAudit(payment, requestorID, model.AuditDepositVoided, model.FieldChanges{
+		"payment_status": {Old: payment.Status, New: model.PaymentVoided},
+		"reason":         {Old: nil, New: reason},
+	})
+
+	return s.paymentRepo.GetByIDWithRelations(paymentID)
+}
+
+// recordAudit adds an admin's payment action to the booking's audit log
+func (s *paymentService) recordAudit(payment *model.Payment, adminID uint, action model.BookingAuditAction, changes model.FieldChanges) {
+	changes["payment_id"] = model.FieldChange{Old: nil, New: payment.ID}
+	writeAudit(s.auditRepo, newAuditEntry(payment.BookingID, adminID, action, changes))
+}
+
+// reopenHold undoes a capture or void claim after the gateway refused it, so the admin can retry
+func (s *paymentService) reopenHold(payment *model.Payment) {
+	if err := s.paymentRepo.ReopenHold(payment.ID, payment.Amount); err != nil {
+		logrus.WithError(err).WithField("payment_id", payment.ID).Error("Failed to reopen deposit hold after gateway error")
+	}
+}
+
+// gatewayFor returns the gateway that holds payment's transaction
+func (s *paymentService) gatewayFor(payment *model.Payment) (transaction.TransactionRepository, error) {
+	gateway := s.transactionRepo
+	if payment.Provider == model.ProviderStripe {
+		gateway = s.stripeRepo
+	}
+	if gateway == nil || payment.ProviderPaymentID == nil {
+		return nil, ErrPaymentProviderUnsupported
+	}
+	return gateway, nil
+}
+
+// ProcessWebhook applies a gateway notification. provider comes from the route the gateway
+// posts to, never from the payload. payload is the raw request body and stripeSignature its
+// Stripe-Signature header; Midtrans signs fields inside the payload instead.
+func (s *paymentService) ProcessWebhook(ctx context.Context, provider model.PaymentProvider, payload []byte, stripeSignature string) error {
+	var webhookData map[string]interface{}
+	if err := json.Unmarshal(payload, &webhookData); err != nil {
+		return ErrWebhookInvalidPayload
+	}
+
+	var providerPaymentID string
 	var newStatus model.PaymentStatus
-	switch transactionStatus {
-	case "capture", "settlement":
-		newStatus = model.PaymentPaid
-	case "pending":
-		newStatus = model.PaymentPending
-	case "deny", "expire", "cancel":
-		newStatus = model.PaymentFailed
+	switch provider {
+	case model.ProviderStripe:
+		// Reject forged events before any payment or booking is touched
+		if s.stripeRepo == nil || !s.stripeRepo.VerifyWebhookSignature(payload, stripeSignature) {
+			logrus.Warn("Rejected Stripe webhook with invalid signature")
+			return ErrWebhookInvalidSignature
+		}
+
+		eventType, _ := webhookData["type"].(string)
+		intentID, err := stripeIntentID(webhookData)
+		if err != nil {
+			return err
+		}
+		providerPaymentID = intentID
+
+		switch transaction.MapStripeEventToInternal(eventType) {
+		case "paid":
+			newStatus = model.PaymentPaid
+		case "authorized":
+			newStatus = model.PaymentAuthorized
+		case "pending":
+			newStatus = model.PaymentPending
+		case "failed":
+			newStatus = model.PaymentFailed
+		default:
+			// Stripe sends many event types; acknowledge the ones we don't track
+			return nil
+		}
+	case model.ProviderMidtrans:
+		orderID, ok := webhookData["order_id"].(string)
+		if !ok {
+			return errors.New("missing order_id in webhook")
+		}
+		providerPaymentID = orderID
+
+		// Reject forged notifications before any payment or booking is touched
+		statusCode, _ := webhookData["status_code"].(string)
+		grossAmount, _ := webhookData["gross_amount"].(string)
+		signatureKey, _ := webhookData["signature_key"].(string)
+		if signatureKey == "" || !s.transactionRepo.VerifyNotification(orderID, statusCode, grossAmount, signatureKey) {
+			logrus.WithField("order_id", orderID).Warn("Rejected payment webhook with invalid signature")
+			return ErrWebhookInvalidSignature
+		}
+
+		transactionStatus, ok := webhookData["transaction_status"].(string)
+		if !ok {
+			return errors.New("missing transaction_status in webhook")
+		}
+
+		switch transactionStatus {
+		case "capture", "settlement":
+			newStatus = model.PaymentPaid
+		case "authorize":
+			newStatus = model.PaymentAuthorized
+		case "pending":
+			newStatus = model.PaymentPending
+		case "expire":
+			newStatus = model.PaymentExpired
+		case "deny", "cancel":
+			newStatus = model.PaymentFailed
+		default:
+			return errors.New("unknown transaction status")
+		}
 	default:
-		return errors.New("unknown transaction status")
+		return ErrPaymentProviderUnsupported
+	}
+
+	payment, err := s.paymentRepo.GetByProviderPaymentID(providerPaymentID)
+	if err != nil {
+		return ErrPaymentNotFound
+	}
+	// A provider may only move payments it created
+	if payment.Provider != provider {
+		logrus.WithFields(logrus.Fields{
+			"payment_id":       payment.ID,
+			"payment_provider": payment.Provider,
+			"webhook_provider": provider,
+		}).Warn("Rejected payment webhook from a different provider")
+		return ErrWebhookProviderMismatch
+	}
+
+	// Gateways retry notifications; a repeat of the current state is a no-op
+	if payment.Status == newStatus {
+		return nil
+	}
+	// Settled holds are recorded by CaptureDepositHold/VoidDepositHold; the gateway's
+	// capture and cancel notifications for them carry nothing new
+	if (payment.Status == model.PaymentAuthorized && newStatus == model.PaymentPaid) || payment.Status == model.PaymentVoided {
+		return nil
 	}
 
 	now := time.Now()
 	switch newStatus {
 	case model.PaymentPaid:
 		payment.PaidAt = &now
-		if err := s.bookingService.ConfirmPayment(payment.BookingID); err != nil {
+		if err := s.bookingService.ConfirmPayment(ctx, payment.BookingID); err != nil {
+			return err
+		}
+	case model.PaymentAuthorized:
+		// The hold secures the booking just like a payment would
+		if err := s.bookingService.ConfirmPayment(ctx, payment.BookingID); err != nil {
 			return err
 		}
 	case model.PaymentFailed:
 		if err := s.bookingService.FailPayment(payment.BookingID); err != nil {
 			return err
 		}
+	case model.PaymentExpired:
+		payment.FailedAt = &now
+		if err := s.bookingService.ExpirePayment(payment.BookingID); err != nil {
+			return err
+		}
 	}
 
 	payment.Status = newStatus
 	return s.paymentRepo.Update(payment)
 }
 
+// stripeIntentID pulls the PaymentIntent ID out of a Stripe event's data.object
+func stripeIntentID(event map[string]interface{}) (string, error) {
+	data, _ := event["data"].(map[string]interface{})
+	object, _ := data["object"].(map[string]interface{})
+	id, ok := object["id"].(string)
+	if !ok || id == "" {
+		return "", errors.New("missing data.object.id in stripe webhook")
+	}
+	return id, nil
+}
+
 func (s *paymentService) canManagePayments(role model.UserRole) bool {
-	return role == model.RoleAdmin || role == model.RoleSuperAdmin
+	return role.Permissions().CanManagePayments
 }
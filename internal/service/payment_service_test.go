@@ -0,0 +1,809 @@
+package service
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/repository/transaction"
+)
+
+const testServerKey = "SB-Mid-server-test"
+
+func midtransSignature(orderID, statusCode, grossAmount string) string {
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + testServerKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// signingTransactionRepository checks signatures the way Midtrans computes them
+type signingTransactionRepository struct {
+	transaction.MockTransactionRepository
+}
+
+func (signingTransactionRepository) VerifyNotification(orderID, statusCode, grossAmount, signatureKey string) bool {
+	return signatureKey == midtransSignature(orderID, statusCode, grossAmount)
+}
+
+// stubPaymentRepository reports every lookup as missing; other methods panic via the nil embedded interface
+type stubPaymentRepository struct {
+	repository.PaymentRepository
+	lookups int
+}
+
+func (s *stubPaymentRepository) GetByProviderPaymentID(providerPaymentID string) (*model.Payment, error) {
+	s.lookups++
+	return nil, errors.New("record not found")
+}
+
+func midtransNotification(signature string) map[string]interface{} {
+	return map[string]interface{}{
+		"order_id":           "booking-5",
+		"status_code":        "200",
+		"gross_amount":       "150000.00",
+		"transaction_status": "settlement",
+		"signature_key":      signature,
+	}
+}
+
+func webhookPayload(t *testing.T, notification map[string]interface{}) []byte {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+// rejectingStripeRepository fails every webhook signature check
+type rejectingStripeRepository struct {
+	transaction.MockTransactionRepository
+}
+
+func (rejectingStripeRepository) VerifyWebhookSignature(payload []byte, signatureHeader string) bool {
+	return false
+}
+
+// ============= TEST WEBHOOK SIGNATURE VERIFICATION =============
+func TestProcessWebhook_ValidSignatureIsProcessed(t *testing.T) {
+	paymentRepo := &stubPaymentRepository{}
+	svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, &signingTransactionRepository{}, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+	err := svc.ProcessWebhook(context.Background(), model.ProviderMidtrans, webhookPayload(t, midtransNotification(midtransSignature("booking-5", "200", "150000.00"))), "")
+
+	// Got past verification to the payment lookup
+	assert.ErrorIs(t, err, ErrPaymentNotFound)
+	assert.Equal(t, 1, paymentRepo.lookups)
+}
+
+func TestProcessWebhook_TamperedSignatureIsRejected(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"missing signature": midtransNotification(""),
+		"wrong signature":   midtransNotification("deadbeef"),
+		"tampered amount": func() map[string]interface{} {
+			n := midtransNotification(midtransSignature("booking-5", "200", "150000.00"))
+			n["gross_amount"] = "1.00"
+			return n
+		}(),
+		// A Stripe-looking key must not route around the Midtrans check
+		"stripe type key": func() map[string]interface{} {
+			n := midtransNotification("deadbeef")
+			n["type"] = "payment_intent.succeeded"
+			return n
+		}(),
+	}
+
+	for name, notification := range cases {
+		t.Run(name, func(t *testing.T) {
+			paymentRepo := &stubPaymentRepository{}
+			svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, &signingTransactionRepository{}, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+			err := svc.ProcessWebhook(context.Background(), model.ProviderMidtrans, webhookPayload(t, notification), "")
+
+			assert.ErrorIs(t, err, ErrWebhookInvalidSignature)
+			assert.Zero(t, paymentRepo.lookups)
+		})
+	}
+}
+
+func TestProcessWebhook_UnsignedStripeEventIsRejected(t *testing.T) {
+	event := map[string]interface{}{
+		"type": "payment_intent.succeeded",
+		"data": map[string]interface{}{"object": map[string]interface{}{"id": "pi_123"}},
+	}
+	cases := map[string]transaction.TransactionRepository{
+		"stripe not configured": nil,
+		"bad signature":         &rejectingStripeRepository{},
+	}
+
+	for name, stripeRepo := range cases {
+		t.Run(name, func(t *testing.T) {
+			paymentRepo := &stubPaymentRepository{}
+			svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, &signingTransactionRepository{}, stripeRepo, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+			err := svc.ProcessWebhook(context.Background(), model.ProviderStripe, webhookPayload(t, event), "t=1,v1=forged")
+
+			assert.ErrorIs(t, err, ErrWebhookInvalidSignature)
+			assert.Zero(t, paymentRepo.lookups)
+		})
+	}
+}
+
+// providerPaymentRepository finds one payment by provider ID and records updates
+type providerPaymentRepository struct {
+	repository.PaymentRepository
+	payment *model.Payment
+	updated bool
+}
+
+func (r *providerPaymentRepository) GetByProviderPaymentID(providerPaymentID string) (*model.Payment, error) {
+	return r.payment, nil
+}
+
+func (r *providerPaymentRepository) Update(payment *model.Payment) error {
+	r.updated = true
+	return nil
+}
+
+func TestProcessWebhook_RejectsOtherProvidersPayment(t *testing.T) {
+	paymentRepo := &providerPaymentRepository{payment: &model.Payment{ID: 5, BookingID: 5, Provider: model.ProviderStripe, Status: model.PaymentPending}}
+	svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, &signingTransactionRepository{}, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+	err := svc.ProcessWebhook(context.Background(), model.ProviderMidtrans, webhookPayload(t, midtransNotification(midtransSignature("booking-5", "200", "150000.00"))), "")
+
+	assert.ErrorIs(t, err, ErrWebhookProviderMismatch)
+	assert.False(t, paymentRepo.updated)
+	assert.Equal(t, model.PaymentPending, paymentRepo.payment.Status)
+}
+
+// refundPaymentRepository serves one payment and records the refund; other methods panic via the nil embedded interface.
+// The refund claim only succeeds while the payment is paid, like the conditional update;
+// staleStatus, when set, is what GetByID reports instead, as a concurrent request would have read.
+type refundPaymentRepository struct {
+	repository.PaymentRepository
+	payment     *model.Payment
+	staleStatus model.PaymentStatus
+	claimErr    error
+	refunded    bool
+	reopened    bool
+}
+
+func (r *refundPaymentRepository) GetByID(id uint) (*model.Payment, error) {
+	copied := *r.payment
+	if r.staleStatus != "" {
+		copied.Status = r.staleStatus
+	}
+	return &copied, nil
+}
+
+func (r *refundPaymentRepository) MarkAsRefunded(paymentID uint, adminID uint, amount float64, reason string) (bool, error) {
+	if r.claimErr != nil {
+		return false, r.claimErr
+	}
+	if r.payment.Status != model.PaymentPaid {
+		return false, nil
+	}
+	r.payment.Status = model.PaymentRefunded
+	r.refunded = true
+	return true, nil
+}
+
+func (r *refundPaymentRepository) ReopenRefund(paymentID uint) error {
+	r.payment.Status = model.PaymentPaid
+	r.reopened = true
+	return nil
+}
+
+func (r *refundPaymentRepository) GetByIDWithRelations(id uint) (*model.Payment, error) {
+	return r.payment, nil
+}
+
+// ============= TEST REFUND PAYMENT =============
+func TestRefundPayment(t *testing.T) {
+	txID := "midtrans-tx-1"
+	cases := []struct {
+		name       string
+		status     model.PaymentStatus
+		amount     float64
+		wantRefund int64
+		wantErr    error
+	}{
+		{"partial refund", model.PaymentPaid, 50000, 50000, nil},
+		{"full refund", model.PaymentPaid, 150000, 150000, nil},
+		{"fractional amount rounds", model.PaymentPaid, 49999.6, 50000, nil},
+		{"more than paid", model.PaymentPaid, 150001, 0, ErrRefundAmountInvalid},
+		{"not paid yet", model.PaymentPending, 50000, 0, ErrPaymentInvalidStatus},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			paymentRepo := &refundPaymentRepository{payment: &model.Payment{
+				ID: 1, Provider: model.ProviderMidtrans, ProviderPaymentID: &txID, Amount: 150000, Status: tc.status,
+			}}
+			gateway := &transaction.MockTransactionRepository{}
+			auditRepo := &memoryBookingAuditRepository{}
+			svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, gateway, nil, nil, nil, auditRepo, 0, "")
+
+			_, err := svc.RefundPayment(9, model.RoleAdmin, 1, tc.amount, "customer cancelled")
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, gateway.Refunds)
+				assert.False(t, paymentRepo.refunded)
+				assert.Empty(t, auditRepo.entries)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []transaction.MockRefund{{ProviderPaymentID: txID, Amount: tc.wantRefund}}, gateway.Refunds)
+			assert.True(t, paymentRepo.refunded)
+			if assert.Len(t, auditRepo.entries, 1) {
+				entry := auditRepo.entries[0]
+				assert.Equal(t, model.AuditPaymentRefunded, entry.Action)
+				assert.Equal(t, uint(9), *entry.ActorID)
+				assert.Equal(t, tc.amount, entry.Changes["refund_amount"].New)
+				assert.Equal(t, uint(1), entry.Changes["payment_id"].New)
+			}
+		})
+	}
+}
+
+func TestRefundPayment_ClaimsBeforeGateway(t *testing.T) {
+	txID := "midtrans-tx-1"
+	newRepo := func() *refundPaymentRepository {
+		return &refundPaymentRepository{payment: &model.Payment{
+			ID: 1, Provider: model.ProviderMidtrans, ProviderPaymentID: &txID, Amount: 150000, Status: model.PaymentPaid,
+		}}
+	}
+
+	t.Run("second refund", func(t *testing.T) {
+		paymentRepo := newRepo()
+		gateway := &transaction.MockTransactionRepository{}
+		svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, gateway, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+		_, err := svc.RefundPayment(9, model.RoleAdmin, 1, 50000, "customer cancelled")
+		assert.NoError(t, err)
+
+		paymentRepo.staleStatus = model.PaymentPaid
+		_, err = svc.RefundPayment(9, model.RoleAdmin, 1, 50000, "customer cancelled")
+		assert.ErrorIs(t, err, ErrPaymentInvalidStatus)
+		assert.Len(t, gateway.Refunds, 1)
+	})
+
+	t.Run("gateway refuses", func(t *testing.T) {
+		paymentRepo := newRepo()
+		svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, &failingGateway{}, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+		_, err := svc.RefundPayment(9, model.RoleAdmin, 1, 50000, "customer cancelled")
+
+		assert.ErrorContains(t, err, "refund failed")
+		assert.True(t, paymentRepo.reopened)
+		assert.Equal(t, model.PaymentPaid, paymentRepo.payment.Status)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		paymentRepo := newRepo()
+		paymentRepo.claimErr = errors.New("connection refused")
+		gateway := &transaction.MockTransactionRepository{}
+		svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, gateway, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+		_, err := svc.RefundPayment(9, model.RoleAdmin, 1, 50000, "customer cancelled")
+
+		assert.EqualError(t, err, "connection refused")
+		assert.Empty(t, gateway.Refunds)
+	})
+}
+
+// ============= TEST PAYMENT SEARCH BY PROVIDER ID =============
+func TestGetPaymentByProviderID_NotFound(t *testing.T) {
+	paymentRepo := &stubPaymentRepository{}
+	svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+	payment, err := svc.GetPaymentByProviderID(model.RoleAdmin, "unknown-tx")
+
+	assert.Nil(t, payment)
+	assert.ErrorIs(t, err, ErrPaymentNotFound)
+}
+
+func TestGetPaymentByProviderID_RequiresAdmin(t *testing.T) {
+	paymentRepo := &stubPaymentRepository{}
+	svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+	_, err := svc.GetPaymentByProviderID(model.RoleCustomer, "midtrans-tx-1")
+
+	assert.ErrorIs(t, err, ErrPaymentInsufficientPermission)
+	assert.Zero(t, paymentRepo.lookups)
+}
+
+// holdPaymentRepository serves one payment and records how its hold was settled. claimLost
+// makes the conditional settlement find the hold already taken.
+type holdPaymentRepository struct {
+	repository.PaymentRepository
+	payment   *model.Payment
+	claimLost bool
+	captured  *float64
+	released  bool
+	voided    bool
+	reopened  bool
+}
+
+func (r *holdPaymentRepository) GetByIDWithRelations(id uint) (*model.Payment, error) {
+	return r.payment, nil
+}
+
+func (r *holdPaymentRepository) GetByBookingID(bookingID uint) (*model.Payment, error) {
+	return nil, errors.New("record not found")
+}
+
+func (r *holdPaymentRepository) MarkHoldCaptured(paymentID uint, adminID uint, amount float64, depositReleased bool, note string) (bool, error) {
+	if r.claimLost {
+		return false, nil
+	}
+	r.captured = &amount
+	r.released = depositReleased
+	r.payment.Status = model.PaymentPaid
+	return true, nil
+}
+
+func (r *holdPaymentRepository) MarkHoldVoided(paymentID uint, adminID uint, reason string) (bool, error) {
+	if r.claimLost {
+		return false, nil
+	}
+	r.voided = true
+	r.payment.Status = model.PaymentVoided
+	return true, nil
+}
+
+func (r *holdPaymentRepository) ReopenHold(paymentID uint, amount float64) error {
+	r.reopened = true
+	r.payment.Status = model.PaymentAuthorized
+	return nil
+}
+
+// failingGateway refuses every refund, capture and void
+type failingGateway struct {
+	transaction.MockTransactionRepository
+}
+
+func (g *failingGateway) Refund(ctx context.Context, providerPaymentID string, amount int64) error {
+	return errors.New("gateway unavailable")
+}
+
+func (g *failingGateway) Capture(ctx context.Context, providerPaymentID string, amount int64) error {
+	return errors.New("authorization expired")
+}
+
+func (g *failingGateway) Void(ctx context.Context, providerPaymentID string) error {
+	return errors.New("authorization expired")
+}
+
+// ============= TEST DEPOSIT MODE =============
+func TestDepositModeFor(t *testing.T) {
+	cases := []struct {
+		name     string
+		fallback model.DepositMode
+		game     model.DepositMode
+		deposit  float64
+		want     model.DepositMode
+	}{
+		{"defaults to charge", "", "", 50000, model.DepositCharge},
+		{"configured hold", model.DepositHold, "", 50000, model.DepositHold},
+		{"game overrides config", model.DepositHold, model.DepositCharge, 50000, model.DepositCharge},
+		{"game opts into hold", model.DepositCharge, model.DepositHold, 50000, model.DepositHold},
+		{"nothing to hold", model.DepositHold, model.DepositHold, 0, model.DepositCharge},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewPaymentService(nil, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, tc.fallback).(*paymentService)
+			booking := &model.Booking{SecurityDeposit: tc.deposit, EndDate: time.Now().AddDate(0, 0, 3), Game: model.Game{DepositMode: tc.game}}
+
+			assert.Equal(t, tc.want, svc.depositModeFor(booking))
+		})
+	}
+}
+
+func TestDepositModeFor_ChargesBookingsEndingAfterHoldWindow(t *testing.T) {
+	svc := NewPaymentService(nil, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, model.DepositHold).(*paymentService)
+
+	soon := &model.Booking{SecurityDeposit: 50000, EndDate: time.Now().AddDate(0, 0, 5)}
+	late := &model.Booking{SecurityDeposit: 50000, EndDate: time.Now().AddDate(0, 0, 30)}
+
+	assert.Equal(t, model.DepositHold, svc.depositModeFor(soon))
+	assert.Equal(t, model.DepositCharge, svc.depositModeFor(late))
+}
+
+func TestCreatePayment_HoldRequiresCardOnMidtrans(t *testing.T) {
+	bookingRepo := &stubBookingRepository{booking: &model.Booking{
+		ID: 3, UserID: 1, Status: model.BookingPending, SecurityDeposit: 50000, TotalAmount: 150000,
+	}}
+	svc := NewPaymentService(&holdPaymentRepository{}, bookingRepo, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, model.DepositHold)
+
+	_, err := svc.CreatePayment(context.Background(), 1, 3, model.ProviderMidtrans, "bank_transfer", "card-token")
+	assert.ErrorIs(t, err, ErrDepositHoldPaymentType)
+
+	_, err = svc.CreatePayment(context.Background(), 1, 3, model.ProviderMidtrans, "credit_card", "")
+	assert.ErrorIs(t, err, ErrDepositHoldCardToken)
+}
+
+func TestCreatePayment_HoldSendsCardToken(t *testing.T) {
+	bookingRepo := &stubBookingRepository{booking: &model.Booking{
+		ID: 3, UserID: 1, GameID: 2, Status: model.BookingPending, SecurityDeposit: 50000, TotalAmount: 150000,
+		EndDate: time.Now().AddDate(0, 0, 3),
+	}}
+	gateway := &transaction.MockTransactionRepository{}
+	userRepo := new(MockUserRepository)
+	userRepo.On("GetByID", uint(1)).Return(nil, errors.New("record not found"))
+	svc := NewPaymentService(&chargePaymentRepository{}, bookingRepo, userRepo, &stubGameRepository{}, nil, gateway, nil, nil, silentNotifier{}, &memoryBookingAuditRepository{}, 0, model.DepositHold)
+
+	payment, err := svc.CreatePayment(context.Background(), 1, 3, model.ProviderMidtrans, "", "card-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.DepositHold, payment.DepositMode)
+	if assert.Len(t, gateway.Charges, 1) {
+		assert.Equal(t, "credit_card", gateway.Charges[0].PaymentType)
+		assert.Equal(t, true, gateway.Charges[0].Params["authorize_only"])
+		assert.Equal(t, "card-token", gateway.Charges[0].Params["token_id"])
+	}
+}
+
+// chargePaymentRepository has no existing payment and keeps the one CreatePayment stores
+type chargePaymentRepository struct {
+	repository.PaymentRepository
+	created *model.Payment
+}
+
+func (r *chargePaymentRepository) GetByBookingID(bookingID uint) (*model.Payment, error) {
+	return nil, errors.New("record not found")
+}
+
+func (r *chargePaymentRepository) Create(payment *model.Payment) error {
+	r.created = payment
+	return nil
+}
+
+func (r *chargePaymentRepository) Update(payment *model.Payment) error {
+	return nil
+}
+
+// ============= TEST CREATE PAYMENT ROUNDING =============
+func TestCreatePayment_RoundsToWholeRupiah(t *testing.T) {
+	cases := []struct {
+		total float64
+		want  int64
+	}{
+		{149999.99, 150000},
+		{149999.49, 149999},
+		{150000.50, 150001},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%.2f", tc.total), func(t *testing.T) {
+			bookingRepo := &stubBookingRepository{booking: &model.Booking{
+				ID: 3, UserID: 1, GameID: 2, Status: model.BookingPending, TotalAmount: tc.total,
+			}}
+			paymentRepo := &chargePaymentRepository{}
+			gateway := &transaction.MockTransactionRepository{}
+			userRepo := new(MockUserRepository)
+			userRepo.On("GetByID", uint(1)).Return(nil, errors.New("record not found"))
+			svc := NewPaymentService(paymentRepo, bookingRepo, userRepo, &stubGameRepository{}, nil, gateway, nil, nil, silentNotifier{}, &memoryBookingAuditRepository{}, 0, "")
+
+			payment, err := svc.CreatePayment(context.Background(), 1, 3, model.ProviderMidtrans, "", "")
+
+			assert.NoError(t, err)
+			if assert.Len(t, gateway.Charges, 1) {
+				assert.Equal(t, tc.want, gateway.Charges[0].Amount)
+			}
+			assert.Equal(t, float64(tc.want), payment.Amount)
+			assert.Equal(t, float64(tc.want), paymentRepo.created.Amount)
+		})
+	}
+}
+
+// ============= TEST SETTLE DEPOSIT HOLD =============
+func TestCaptureDepositHold(t *testing.T) {
+	txID := "midtrans-tx-1"
+	cases := []struct {
+		name          string
+		paymentStatus model.PaymentStatus
+		bookingStatus model.BookingStatus
+		keepDeposit   bool
+		wantCaptured  int64
+		wantErr       error
+	}{
+		{"returned, deposit released", model.PaymentAuthorized, model.BookingCompleted, false, 100000, nil},
+		{"returned damaged, deposit kept", model.PaymentAuthorized, model.BookingCompleted, true, 150000, nil},
+		{"never returned", model.PaymentAuthorized, model.BookingOverdue, true, 150000, nil},
+		{"still rented", model.PaymentAuthorized, model.BookingActive, false, 0, ErrDepositHoldNotSettleable},
+		{"charged, not held", model.PaymentPaid, model.BookingCompleted, false, 0, ErrPaymentInvalidStatus},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			paymentRepo := &holdPaymentRepository{payment: &model.Payment{
+				ID: 1, BookingID: 3, Provider: model.ProviderMidtrans, ProviderPaymentID: &txID,
+				Amount: 150000, Status: tc.paymentStatus, DepositMode: model.DepositHold,
+				Booking: model.Booking{ID: 3, Status: tc.bookingStatus, SecurityDeposit: 50000},
+			}}
+			gateway := &transaction.MockTransactionRepository{}
+			svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, gateway, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+			_, err := svc.CaptureDepositHold(9, model.RoleAdmin, 1, tc.keepDeposit, "checked on return")
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, gateway.Captures)
+				assert.Nil(t, paymentRepo.captured)
+				return
+			}
+			assert.NoError(t, err)
+			if assert.Len(t, gateway.Captures, 1) {
+				assert.Equal(t, tc.wantCaptured, gateway.Captures[0].Amount)
+			}
+			if assert.NotNil(t, paymentRepo.captured) {
+				assert.Equal(t, float64(tc.wantCaptured), *paymentRepo.captured)
+			}
+			assert.Equal(t, !tc.keepDeposit, paymentRepo.released)
+		})
+	}
+}
+
+func TestCaptureDepositHold_RoundsFractionalAmount(t *testing.T) {
+	txID := "midtrans-tx-1"
+	paymentRepo := &holdPaymentRepository{payment: &model.Payment{
+		ID: 1, BookingID: 3, Provider: model.ProviderMidtrans, ProviderPaymentID: &txID,
+		Amount: 150000, Status: model.PaymentAuthorized, DepositMode: model.DepositHold,
+		Booking: model.Booking{ID: 3, Status: model.BookingCompleted, SecurityDeposit: 50000.4},
+	}}
+	gateway := &transaction.MockTransactionRepository{}
+	svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, gateway, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+	_, err := svc.CaptureDepositHold(9, model.RoleAdmin, 1, false, "checked on return")
+
+	assert.NoError(t, err)
+	if assert.Len(t, gateway.Captures, 1) {
+		assert.Equal(t, int64(100000), gateway.Captures[0].Amount)
+	}
+}
+
+func TestCaptureDepositHold_ClaimsBeforeGateway(t *testing.T) {
+	txID := "midtrans-tx-1"
+	newRepo := func() *holdPaymentRepository {
+		return &holdPaymentRepository{payment: &model.Payment{
+			ID: 1, BookingID: 3, Provider: model.ProviderMidtrans, ProviderPaymentID: &txID,
+			Amount: 150000, Status: model.PaymentAuthorized, DepositMode: model.DepositHold,
+			Booking: model.Booking{ID: 3, Status: model.BookingCompleted, SecurityDeposit: 50000},
+		}}
+	}
+
+	t.Run("claim lost", func(t *testing.T) {
+		paymentRepo := newRepo()
+		paymentRepo.claimLost = true
+		gateway := &transaction.MockTransactionRepository{}
+		svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, gateway, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+		_, err := svc.CaptureDepositHold(9, model.RoleAdmin, 1, false, "checked on return")
+
+		assert.ErrorIs(t, err, ErrPaymentInvalidStatus)
+		assert.Empty(t, gateway.Captures)
+	})
+
+	t.Run("gateway refuses", func(t *testing.T) {
+		paymentRepo := newRepo()
+		svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, &failingGateway{}, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+		_, err := svc.CaptureDepositHold(9, model.RoleAdmin, 1, false, "checked on return")
+
+		assert.ErrorContains(t, err, "capture failed")
+		assert.True(t, paymentRepo.reopened)
+		assert.Equal(t, model.PaymentAuthorized, paymentRepo.payment.Status)
+	})
+}
+
+func TestVoidDepositHold_ReopensHoldWhenGatewayFails(t *testing.T) {
+	txID := "pi_123"
+	paymentRepo := &holdPaymentRepository{payment: &model.Payment{
+		ID: 1, Provider: model.ProviderStripe, ProviderPaymentID: &txID, Amount: 150000,
+		Status: model.PaymentAuthorized, Booking: model.Booking{Status: model.BookingCancelled},
+	}}
+	svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, nil, &failingGateway{}, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+	_, err := svc.VoidDepositHold(9, model.RoleAdmin, 1, "customer cancelled")
+
+	assert.ErrorContains(t, err, "void failed")
+	assert.True(t, paymentRepo.reopened)
+	assert.Equal(t, model.PaymentAuthorized, paymentRepo.payment.Status)
+}
+
+func TestVoidDepositHold_OnlyForCancelledBookings(t *testing.T) {
+	txID := "pi_123"
+	for _, status := range []model.BookingStatus{model.BookingCancelled, model.BookingConfirmed} {
+		t.Run(string(status), func(t *testing.T) {
+			paymentRepo := &holdPaymentRepository{payment: &model.Payment{
+				ID: 1, Provider: model.ProviderStripe, ProviderPaymentID: &txID, Amount: 150000,
+				Status: model.PaymentAuthorized, Booking: model.Booking{Status: status},
+			}}
+			gateway := &transaction.MockTransactionRepository{}
+			svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, nil, gateway, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+			_, err := svc.VoidDepositHold(9, model.RoleAdmin, 1, "customer cancelled")
+
+			if status == model.BookingCancelled {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"pi_123"}, gateway.Voids)
+				assert.True(t, paymentRepo.voided)
+			} else {
+				assert.ErrorIs(t, err, ErrDepositHoldNotSettleable)
+				assert.Empty(t, gateway.Voids)
+			}
+		})
+	}
+}
+
+// revenuePaymentRangeRepository records the range GetRevenueBuckets was asked for
+type revenuePaymentRangeRepository struct {
+	repository.PaymentRepository
+	from, to time.Time
+	called   bool
+}
+
+func (r *revenuePaymentRangeRepository) GetRevenueBuckets(from, to time.Time, groupBy string) ([]repository.RevenueBucket, error) {
+	r.called = true
+	r.from, r.to = from, to
+	return nil, nil
+}
+
+// ============= TEST REVENUE REPORT =============
+func TestGetRevenueReport_Validation(t *testing.T) {
+	jan1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		from, to time.Time
+		groupBy  string
+		wantErr  error
+	}{
+		{"from after to", jan1.AddDate(0, 0, 1), jan1, repository.RevenueByDay, ErrRevenueRangeInvalid},
+		{"over a year", jan1, jan1.AddDate(1, 0, 1), repository.RevenueByMonth, ErrRevenueRangeTooLong},
+		{"unknown grouping", jan1, jan1, "quarter", ErrRevenueGroupByInvalid},
+		{"exactly a year", jan1, jan1.AddDate(1, 0, 0), repository.RevenueByMonth, nil},
+		{"single day", jan1, jan1, repository.RevenueByWeek, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			paymentRepo := &revenuePaymentRangeRepository{}
+			svc := NewPaymentService(paymentRepo, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+			_, err := svc.GetRevenueReport(model.RoleAdmin, tc.from, tc.to, tc.groupBy)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.False(t, paymentRepo.called)
+				return
+			}
+			assert.NoError(t, err)
+			// to is inclusive, so the repository gets the start of the following day
+			assert.Equal(t, tc.from, paymentRepo.from)
+			assert.Equal(t, tc.to.AddDate(0, 0, 1), paymentRepo.to)
+		})
+	}
+}
+
+func TestGetRevenueReport_RequiresManagePayments(t *testing.T) {
+	svc := NewPaymentService(&revenuePaymentRangeRepository{}, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+	_, err := svc.GetRevenueReport(model.RoleCustomer, time.Now(), time.Now(), repository.RevenueByDay)
+
+	assert.ErrorIs(t, err, ErrPaymentInsufficientPermission)
+}
+
+// manualPaymentRepository serves one pending payment; markErr fails the conditional update and
+// settled makes it find the payment already settled
+type manualPaymentRepository struct {
+	repository.PaymentRepository
+	payment *model.Payment
+	markErr error
+	settled bool
+}
+
+func (r *manualPaymentRepository) GetByID(id uint) (*model.Payment, error) {
+	return r.payment, nil
+}
+
+func (r *manualPaymentRepository) GetByIDWithRelations(id uint) (*model.Payment, error) {
+	return r.payment, nil
+}
+
+func (r *manualPaymentRepository) MarkAsPaidManually(paymentID uint, adminID uint, paymentMethod string, note string) (bool, error) {
+	return !r.settled && r.markErr == nil, r.markErr
+}
+
+func (r *manualPaymentRepository) MarkAsFailedManually(paymentID uint, adminID uint, failureReason string) (bool, error) {
+	return !r.settled && r.markErr == nil, r.markErr
+}
+
+// ============= TEST MARK PAID MANUALLY =============
+func TestMarkPaidManually_SeparatesLostRaceFromDatabaseErrors(t *testing.T) {
+	newRepo := func() *manualPaymentRepository {
+		return &manualPaymentRepository{payment: &model.Payment{
+			ID: 1, BookingID: 3, Status: model.PaymentPending, Purpose: model.PaymentPurposeLateFee,
+		}}
+	}
+
+	settled := newRepo()
+	settled.settled = true
+	_, err := NewPaymentService(settled, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "").MarkPaidManually(context.Background(), 9, model.RoleAdmin, 1, "cash", "")
+	assert.ErrorIs(t, err, ErrPaymentAlreadyPaid)
+
+	broken := newRepo()
+	broken.markErr = errors.New("connection refused")
+	_, err = NewPaymentService(broken, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "").MarkPaidManually(context.Background(), 9, model.RoleAdmin, 1, "cash", "")
+	assert.EqualError(t, err, "connection refused")
+}
+
+// ============= TEST MARK FAILED MANUALLY =============
+func TestMarkFailedManually_SeparatesLostRaceFromDatabaseErrors(t *testing.T) {
+	newRepo := func() *manualPaymentRepository {
+		return &manualPaymentRepository{payment: &model.Payment{ID: 1, BookingID: 3, Status: model.PaymentPending}}
+	}
+
+	settled := newRepo()
+	settled.settled = true
+	_, err := NewPaymentService(settled, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "").MarkFailedManually(9, model.RoleAdmin, 1, "stuck")
+	assert.ErrorIs(t, err, ErrPaymentInvalidStatus)
+
+	broken := newRepo()
+	broken.markErr = errors.New("connection refused")
+	_, err = NewPaymentService(broken, nil, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "").MarkFailedManually(9, model.RoleAdmin, 1, "stuck")
+	assert.EqualError(t, err, "connection refused")
+}
+
+// lookupPaymentRepository serves a single payment by ID; other IDs are not found
+type lookupPaymentRepository struct {
+	repository.PaymentRepository
+	payment *model.Payment
+}
+
+func (r *lookupPaymentRepository) GetByID(id uint) (*model.Payment, error) {
+	if r.payment == nil || r.payment.ID != id {
+		return nil, errors.New("record not found")
+	}
+	return r.payment, nil
+}
+
+// ============= TEST GET BOOKING BY PAYMENT =============
+func TestGetBookingByPayment(t *testing.T) {
+	booking := &model.Booking{ID: 3, UserID: 1, Status: model.BookingConfirmed}
+	paymentRepo := &lookupPaymentRepository{payment: &model.Payment{ID: 7, BookingID: 3}}
+	svc := NewPaymentService(paymentRepo, &stubBookingRepository{booking: booking}, nil, nil, nil, nil, nil, nil, nil, &memoryBookingAuditRepository{}, 0, "")
+
+	cases := []struct {
+		name      string
+		userID    uint
+		paymentID uint
+		wantErr   error
+	}{
+		{"owned", 1, 7, nil},
+		{"someone else's booking", 2, 7, ErrPaymentBookingNotOwned},
+		{"missing payment", 1, 99, ErrPaymentNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := svc.GetBookingByPayment(tc.userID, tc.paymentID)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, booking, got)
+		})
+	}
+}
@@ -1,67 +1,175 @@
 package service
 
 import (
-	"errors"
+	"time"
 
 	"github.com/yoockh/go-game-rental-api/internal/model"
 	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
 )
 
 var (
-	ErrReviewAlreadyExists       = errors.New("review already exists for this booking")
-	ErrReviewBookingNotCompleted = errors.New("can only review completed bookings")
+	ErrReviewAlreadyExists       = utils.NewConflictError("review_already_exists", "review already exists for this booking")
+	ErrReviewBookingNotCompleted = utils.NewBadRequestError("review_booking_not_completed", "can only review completed bookings")
+	ErrReviewNotFound            = utils.NewNotFoundError("review_not_found", "review not found")
+	ErrReviewNotOwned            = utils.NewForbiddenError("review_not_owned", "you don't own this review")
+	ErrReviewEditWindowClosed    = utils.NewForbiddenError("review_edit_window_closed", "reviews can only be edited within 30 days")
+	ErrReviewReplyForbidden      = utils.NewForbiddenError("review_reply_forbidden", "only the game's owner can reply to its reviews")
 )
 
+// reviewEditWindow is how long after posting a review its author may still edit it
+const reviewEditWindow = 30 * 24 * time.Hour
+
 type ReviewService interface {
 	// Customer methods
 	CreateReview(userID uint, bookingID uint, reviewData *model.Review) error
+	CanReview(userID uint, bookingID uint) error
+	UpdateReview(userID, reviewID uint, data *model.Review) error
+	DeleteReview(userID, reviewID uint) error
+	GetUserReviews(userID uint, limit, offset int) ([]*model.Review, int64, error)
+
+	// Admin methods
+	ReplyToReview(userID uint, role model.UserRole, reviewID uint, reply string) error
 
 	// Public methods
 	GetGameReviews(gameID uint, limit, offset int) ([]*model.Review, error)
+	GetGameRatingStats(gameID uint) (avg float64, count int64, err error)
 }
 
 type reviewService struct {
 	reviewRepo  repository.ReviewRepository
 	bookingRepo repository.BookingRepository
+	gameRepo    repository.GameRepository
 }
 
-func NewReviewService(reviewRepo repository.ReviewRepository, bookingRepo repository.BookingRepository) ReviewService {
+func NewReviewService(reviewRepo repository.ReviewRepository, bookingRepo repository.BookingRepository, gameRepo repository.GameRepository) ReviewService {
 	return &reviewService{
 		reviewRepo:  reviewRepo,
 		bookingRepo: bookingRepo,
+		gameRepo:    gameRepo,
 	}
 }
 
 func (s *reviewService) CreateReview(userID uint, bookingID uint, reviewData *model.Review) error {
+	booking, err := s.checkReviewable(userID, bookingID)
+	if err != nil {
+		return err
+	}
+
+	// Set review details
+	reviewData.BookingID = bookingID
+	reviewData.UserID = userID
+	reviewData.GameID = booking.GameID
+
+	return s.reviewRepo.Create(reviewData)
+}
+
+// CanReview runs the same checks as CreateReview without writing anything; nil means allowed
+func (s *reviewService) CanReview(userID uint, bookingID uint) error {
+	_, err := s.checkReviewable(userID, bookingID)
+	return err
+}
+
+func (s *reviewService) checkReviewable(userID uint, bookingID uint) (*model.Booking, error) {
 	// Validate booking exists and belongs to user
 	booking, err := s.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return ErrBookingNotFound
+		return nil, ErrBookingNotFound
 	}
 
 	if booking.UserID != userID {
-		return ErrBookingNotOwned
+		return nil, ErrBookingNotOwned
 	}
 
 	// Can only review completed bookings
 	if booking.Status != model.BookingCompleted {
-		return ErrReviewBookingNotCompleted
+		return nil, ErrReviewBookingNotCompleted
 	}
 
 	// Check if review already exists
 	existingReview, _ := s.reviewRepo.GetByBookingID(bookingID)
 	if existingReview != nil {
-		return ErrReviewAlreadyExists
+		return nil, ErrReviewAlreadyExists
 	}
 
-	// Set review details
-	reviewData.BookingID = bookingID
-	reviewData.UserID = userID
-	reviewData.GameID = booking.GameID
-
-	return s.reviewRepo.Create(reviewData)
+	return booking, nil
 }
 
 func (s *reviewService) GetGameReviews(gameID uint, limit, offset int) ([]*model.Review, error) {
 	return s.reviewRepo.GetGameReviews(gameID, limit, offset)
 }
+
+func (s *reviewService) GetUserReviews(userID uint, limit, offset int) ([]*model.Review, int64, error) {
+	reviews, err := s.reviewRepo.GetUserReviews(userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.reviewRepo.CountUserReviews(userID)
+	return reviews, total, err
+}
+
+func (s *reviewService) GetGameRatingStats(gameID uint) (float64, int64, error) {
+	return s.reviewRepo.GetGameRatingStats(gameID)
+}
+
+// UpdateReview changes rating and comment on the caller's own review while it is within reviewEditWindow
+func (s *reviewService) UpdateReview(userID, reviewID uint, data *model.Review) error {
+	review, err := s.getOwnedReview(userID, reviewID)
+	if err != nil {
+		return err
+	}
+	if time.Since(review.CreatedAt) > reviewEditWindow {
+		return ErrReviewEditWindowClosed
+	}
+
+	review.Rating = data.Rating
+	review.Comment = data.Comment
+	return s.reviewRepo.Update(review)
+}
+
+// DeleteReview removes the caller's own review; unlike edits there is no age limit
+func (s *reviewService) DeleteReview(userID, reviewID uint) error {
+	if _, err := s.getOwnedReview(userID, reviewID); err != nil {
+		return err
+	}
+	return s.reviewRepo.Delete(reviewID)
+}
+
+// ReplyToReview sets the public reply on a review of one of the replier's games.
+// Super admins may reply on any game; a second reply replaces the first.
+func (s *reviewService) ReplyToReview(userID uint, role model.UserRole, reviewID uint, reply string) error {
+	perms := role.Permissions()
+	if !perms.CanManageGames {
+		return ErrReviewReplyForbidden
+	}
+
+	review, err := s.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return ErrReviewNotFound
+	}
+
+	game, err := s.gameRepo.GetByID(review.GameID)
+	if err != nil {
+		return ErrGameNotFound
+	}
+	if !perms.CanManageAnyGame && game.AdminID != userID {
+		return ErrReviewReplyForbidden
+	}
+
+	now := time.Now()
+	review.Reply = &reply
+	review.RepliedAt = &now
+	return s.reviewRepo.UpdateReply(review)
+}
+
+func (s *reviewService) getOwnedReview(userID, reviewID uint) (*model.Review, error) {
+	review, err := s.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return nil, ErrReviewNotFound
+	}
+	if review.UserID != userID {
+		return nil, ErrReviewNotOwned
+	}
+	return review, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+)
+
+// ownReviewRepository holds one review and records writes
+type ownReviewRepository struct {
+	repository.ReviewRepository
+	review  *model.Review
+	updated *model.Review
+	deleted uint
+}
+
+func (r *ownReviewRepository) GetByID(id uint) (*model.Review, error) {
+	return r.review, nil
+}
+
+func (r *ownReviewRepository) Update(review *model.Review) error {
+	r.updated = review
+	return nil
+}
+
+func (r *ownReviewRepository) UpdateReply(review *model.Review) error {
+	r.updated = review
+	return nil
+}
+
+func (r *ownReviewRepository) Delete(id uint) error {
+	r.deleted = id
+	return nil
+}
+
+// ============= TEST UPDATE REVIEW =============
+func TestUpdateReview_Success(t *testing.T) {
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 1, BookingID: 9, Rating: 2, CreatedAt: time.Now().Add(-24 * time.Hour)}}
+	svc := NewReviewService(repo, nil, nil)
+	comment := "Great game"
+
+	err := svc.UpdateReview(1, 4, &model.Review{Rating: 5, Comment: &comment, BookingID: 99})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, repo.updated) {
+		assert.Equal(t, 5, repo.updated.Rating)
+		assert.Equal(t, &comment, repo.updated.Comment)
+		assert.Equal(t, uint(9), repo.updated.BookingID)
+	}
+}
+
+func TestUpdateReview_NotOwned(t *testing.T) {
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 2, CreatedAt: time.Now()}}
+	svc := NewReviewService(repo, nil, nil)
+
+	err := svc.UpdateReview(1, 4, &model.Review{Rating: 5})
+
+	assert.Equal(t, ErrReviewNotOwned, err)
+	assert.Nil(t, repo.updated)
+}
+
+func TestUpdateReview_OlderThan30Days(t *testing.T) {
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 1, CreatedAt: time.Now().Add(-31 * 24 * time.Hour)}}
+	svc := NewReviewService(repo, nil, nil)
+
+	err := svc.UpdateReview(1, 4, &model.Review{Rating: 5})
+
+	assert.Equal(t, ErrReviewEditWindowClosed, err)
+	assert.Nil(t, repo.updated)
+}
+
+// ============= TEST DELETE REVIEW =============
+func TestDeleteReview_Success(t *testing.T) {
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 1, CreatedAt: time.Now().Add(-90 * 24 * time.Hour)}}
+	svc := NewReviewService(repo, nil, nil)
+
+	assert.NoError(t, svc.DeleteReview(1, 4))
+	assert.Equal(t, uint(4), repo.deleted)
+}
+
+func TestDeleteReview_NotOwned(t *testing.T) {
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 2}}
+	svc := NewReviewService(repo, nil, nil)
+
+	assert.Equal(t, ErrReviewNotOwned, svc.DeleteReview(1, 4))
+	assert.Zero(t, repo.deleted)
+}
+
+// ============= TEST REPLY TO REVIEW =============
+func TestReplyToReview_GameOwner(t *testing.T) {
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 1, GameID: 7}}
+	games := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 5}}
+	svc := NewReviewService(repo, nil, games)
+
+	err := svc.ReplyToReview(5, model.RoleAdmin, 4, "Thanks for renting!")
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, repo.updated) {
+		assert.Equal(t, "Thanks for renting!", *repo.updated.Reply)
+		assert.NotNil(t, repo.updated.RepliedAt)
+	}
+}
+
+func TestReplyToReview_SecondReplyReplacesFirst(t *testing.T) {
+	first := "Thanks!"
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 1, GameID: 7, Reply: &first}}
+	games := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 5}}
+	svc := NewReviewService(repo, nil, games)
+
+	assert.NoError(t, svc.ReplyToReview(5, model.RoleAdmin, 4, "Thanks, see you again!"))
+	assert.Equal(t, "Thanks, see you again!", *repo.updated.Reply)
+}
+
+func TestReplyToReview_NonOwningAdminRejected(t *testing.T) {
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 1, GameID: 7}}
+	games := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 5}}
+	svc := NewReviewService(repo, nil, games)
+
+	err := svc.ReplyToReview(6, model.RoleAdmin, 4, "Not my game")
+
+	assert.Equal(t, ErrReviewReplyForbidden, err)
+	assert.Nil(t, repo.updated)
+}
+
+func TestReplyToReview_SuperAdminAnyGame(t *testing.T) {
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 1, GameID: 7}}
+	games := &imageGameRepository{game: &model.Game{ID: 7, AdminID: 5}}
+	svc := NewReviewService(repo, nil, games)
+
+	assert.NoError(t, svc.ReplyToReview(9, model.RoleSuperAdmin, 4, "On behalf of the shop"))
+}
+
+func TestReplyToReview_CustomerRejected(t *testing.T) {
+	repo := &ownReviewRepository{review: &model.Review{ID: 4, UserID: 1, GameID: 7}}
+	svc := NewReviewService(repo, nil, nil)
+
+	assert.Equal(t, ErrReviewReplyForbidden, svc.ReplyToReview(1, model.RoleCustomer, 4, "Me again"))
+}
@@ -1,10 +1,14 @@
 package service
 
 import (
-	"errors"
-	"log"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/yoockh/go-api-utils/pkg-echo/auth"
 	"github.com/yoockh/go-game-rental-api/internal/dto"
 	"github.com/yoockh/go-game-rental-api/internal/model"
@@ -13,35 +17,87 @@ import (
 )
 
 var (
-	ErrUserNotFound           = errors.New("user not found")
-	ErrInsufficientPermission = errors.New("insufficient permission")
-	ErrCannotDeleteSuperAdmin = errors.New("cannot delete super admin")
-	ErrCannotDeleteSelf       = errors.New("cannot delete yourself")
+	ErrUserNotFound           = utils.NewNotFoundError("user_not_found", "user not found")
+	ErrInsufficientPermission = utils.NewForbiddenError("insufficient_permission", "insufficient permission")
+	ErrCannotDeleteSuperAdmin = utils.NewForbiddenError("cannot_delete_super_admin", "cannot delete super admin")
+	ErrCannotDeleteSelf       = utils.NewBadRequestError("cannot_delete_self", "cannot delete yourself")
+	ErrEmailAlreadyExists     = utils.NewConflictError("email_already_exists", "email already exists")
+	ErrInvalidCredentials     = utils.NewServiceError("invalid_credentials", http.StatusUnauthorized, "invalid credentials")
+	ErrAccountInactive        = utils.NewForbiddenError("account_inactive", "account is inactive")
+	ErrInvalidRole            = utils.NewBadRequestError("invalid_role", "invalid role")
+	ErrSuperAdminProtected    = utils.NewForbiddenError("super_admin_protected", "admin cannot modify super admin role")
+	ErrSuperAdminPromotion    = utils.NewForbiddenError("super_admin_promotion", "admin cannot promote user to super admin")
+	ErrSuperAdminAssignment   = utils.NewForbiddenError("super_admin_assignment", "only super admin can assign super admin role")
+	ErrSuperAdminStatusLocked = utils.NewForbiddenError("super_admin_status_locked", "admin cannot modify super admin status")
+	ErrSuperAdminDeleteLocked = utils.NewForbiddenError("super_admin_delete_locked", "admin cannot delete super admin")
+	ErrResetTokenInvalid      = utils.NewBadRequestError("reset_token_invalid", "invalid password reset token")
+	ErrResetTokenExpired      = utils.NewBadRequestError("reset_token_expired", "password reset token has expired")
+	ErrResetTokenUsed         = utils.NewBadRequestError("reset_token_used", "password reset token has already been used")
+	ErrEmailNotVerified       = utils.NewForbiddenError("email_not_verified", "please verify your email before logging in")
+	ErrVerifyTokenInvalid     = utils.NewBadRequestError("verify_token_invalid", "invalid email verification token")
+	ErrVerifyTokenExpired     = utils.NewBadRequestError("verify_token_expired", "email verification token has expired")
+	ErrVerifyTokenUsed        = utils.NewBadRequestError("verify_token_used", "email has already been verified")
+	ErrCurrentPasswordWrong   = utils.NewBadRequestError("current_password_incorrect", "current password is incorrect")
+	ErrPasswordTooWeak        = utils.NewServiceError("password_too_weak", http.StatusUnprocessableEntity, "password must be at least 8 characters and contain a letter and a digit")
+	ErrRefreshTokenInvalid    = utils.NewServiceError("refresh_token_invalid", http.StatusUnauthorized, "invalid refresh token")
+	ErrRefreshTokenExpired    = utils.NewServiceError("refresh_token_expired", http.StatusUnauthorized, "refresh token has expired")
+	ErrRefreshTokenRevoked    = utils.NewServiceError("refresh_token_revoked", http.StatusUnauthorized, "refresh token has been revoked")
+	ErrRegistrationDisabled   = utils.NewForbiddenError("registration_disabled", "self-registration is disabled; ask an administrator for an account")
+	ErrAdminCreationLocked    = utils.NewForbiddenError("admin_creation_locked", "only super admin can create admin accounts")
+)
+
+const (
+	// passwordResetTokenTTL is how long an emailed reset link stays valid
+	passwordResetTokenTTL = time.Hour
+	// emailVerificationTokenTTL is how long a verify link from registration stays valid
+	emailVerificationTokenTTL = 24 * time.Hour
+	accessTokenTTL            = 24 * time.Hour
+	refreshTokenTTL           = 30 * 24 * time.Hour
+	// setPasswordTokenTTL is how long the set-password link for an admin-created account stays valid
+	setPasswordTokenTTL = 72 * time.Hour
 )
 
 type UserService interface {
 	// Public methods
 	GetProfile(userID uint) (*model.User, error)
 	UpdateProfile(userID uint, updateData interface{}) error
+	ChangePassword(userID uint, oldPassword, newPassword string) error
 
 	// Auth methods
 	Register(registerData interface{}) (*model.User, error)
 	Login(loginData interface{}, jwtSecret string) (interface{}, error)
+	RefreshAccessToken(refreshToken, jwtSecret string) (*dto.RefreshTokenResponse, error)
+	Logout(refreshToken string) error
+	RequestPasswordReset(email string) (*model.User, string, error)
+	ResetPassword(token, newPassword string) error
+	IssueEmailVerification(userID uint) (string, error)
+	VerifyEmail(token string) error
 
 	// Admin methods
-	GetAllUsers(requestorRole model.UserRole, limit, offset int) ([]*model.User, int64, error)
+	CreateUser(requestorRole model.UserRole, createData interface{}) (*model.User, string, error)
+	GetAllUsers(requestorRole model.UserRole, filter repository.UserFilter, limit, offset int) ([]*model.User, int64, error)
 	GetUserDetail(requestorRole model.UserRole, userID uint) (*model.User, error)
 	UpdateUserRole(requestorRole model.UserRole, userID uint, newRole model.UserRole) error
-	ToggleUserStatus(requestorRole model.UserRole, userID uint) error
+	SetUserStatus(requestorRole model.UserRole, userID uint, isActive bool) error
 	DeleteUser(requestorID uint, requestorRole model.UserRole, targetUserID uint) error
 }
 
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo                 repository.UserRepository
+	tokenRepo                repository.EmailVerificationRepository
+	refreshRepo              repository.RefreshTokenRepository
+	requireEmailVerification bool // new accounts stay inactive until the emailed link is opened
+	registrationEnabled      bool // when false only admins create accounts, via CreateUser
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
-	return &userService{userRepo: userRepo}
+func NewUserService(userRepo repository.UserRepository, tokenRepo repository.EmailVerificationRepository, refreshRepo repository.RefreshTokenRepository, requireEmailVerification, registrationEnabled bool) UserService {
+	return &userService{
+		userRepo:                 userRepo,
+		tokenRepo:                tokenRepo,
+		refreshRepo:              refreshRepo,
+		requireEmailVerification: requireEmailVerification,
+		registrationEnabled:      registrationEnabled,
+	}
 }
 
 func (s *userService) GetProfile(userID uint) (*model.User, error) {
@@ -63,12 +119,42 @@ func (s *userService) UpdateProfile(userID uint, updateData interface{}) error {
 	return s.userRepo.Update(user)
 }
 
+func (s *userService) ChangePassword(userID uint, oldPassword, newPassword string) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if !utils.CheckPassword(user.Password, oldPassword) {
+		return ErrCurrentPasswordWrong
+	}
+	if !utils.IsStrongPassword(newPassword) {
+		return ErrPasswordTooWeak
+	}
+
+	hashed, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	user.Password = hashed
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	// A stolen session shouldn't outlive the password change; the caller logs in again
+	return s.refreshRepo.RevokeAllForUser(userID)
+}
+
 func (s *userService) Register(registerData interface{}) (*model.User, error) {
+	if !s.registrationEnabled {
+		return nil, ErrRegistrationDisabled
+	}
+
 	req := registerData.(*dto.RegisterRequest)
+	req.Email = normalizeEmail(req.Email)
 
 	// Check if user exists
 	if _, err := s.userRepo.GetByEmail(req.Email); err == nil {
-		return nil, errors.New("email already exists")
+		return nil, ErrEmailAlreadyExists
 	}
 
 	// Use our own HashPassword
@@ -84,7 +170,7 @@ func (s *userService) Register(registerData interface{}) (*model.User, error) {
 		Phone:    &req.Phone,
 		Address:  &req.Address,
 		Role:     model.RoleCustomer,
-		IsActive: true, // Auto-active (no email verification)
+		IsActive: !s.requireEmailVerification, // Activated by VerifyEmail when verification is required
 	}
 
 	return user, s.userRepo.Create(user)
@@ -92,25 +178,37 @@ func (s *userService) Register(registerData interface{}) (*model.User, error) {
 
 func (s *userService) Login(loginData interface{}, jwtSecret string) (interface{}, error) {
 	req := loginData.(*dto.LoginRequest)
-	log.Printf("DEBUG: Login attempt for email: %s", req.Email)
+	req.Email = normalizeEmail(req.Email)
+	logger := logrus.WithField("email", utils.RedactEmail(req.Email))
+	logger.Debug("Login attempt")
 
 	user, err := s.userRepo.GetByEmail(req.Email)
 	if err != nil {
-		log.Printf("ERROR: GetByEmail failed: %v", err)
-		return nil, errors.New("invalid credentials")
+		logger.WithError(err).Warn("Login failed: user lookup")
+		return nil, ErrInvalidCredentials
 	}
-	log.Printf("DEBUG: User found, hash: %s..., active: %v", user.Password[:30], user.IsActive)
 
 	// Use our own CheckPassword
 	if !utils.CheckPassword(user.Password, req.Password) {
-		log.Printf("ERROR: Password mismatch for email %s", req.Email)
-		return nil, errors.New("invalid credentials")
+		logger.Warn("Login failed: password mismatch")
+		return nil, ErrInvalidCredentials
 	}
-	log.Printf("DEBUG: Password OK for %s", req.Email)
 
 	if !user.IsActive {
-		log.Printf("ERROR: User not active: %s", req.Email)
-		return nil, errors.New("account is inactive")
+		logger.Warn("Login failed: user not active")
+		if s.isAwaitingVerification(user.ID) {
+			return nil, ErrEmailNotVerified
+		}
+		return nil, ErrAccountInactive
+	}
+
+	s.upgradePasswordHash(user, req.Password)
+	// Only feeds the admin user list, so a failed write doesn't block the login
+	now := time.Now()
+	if err := s.userRepo.UpdateLastLogin(user.ID, now); err != nil {
+		logger.WithError(err).Warn("Failed to record last login")
+	} else {
+		user.LastLoginAt = &now
 	}
 
 	// Still use go-api-utils for JWT generation
@@ -119,32 +217,146 @@ func (s *userService) Login(loginData interface{}, jwtSecret string) (interface{
 		user.Email,
 		string(user.Role),
 		jwtSecret,
-		24*time.Hour,
+		accessTokenTTL,
 	)
 	if err != nil {
-		log.Printf("ERROR: GenerateToken failed: %v", err)
+		logger.WithError(err).Error("Failed to generate access token")
+		return nil, err
+	}
+	logger.Debug("Access token generated")
+
+	refreshToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	refreshExpiresAt := time.Now().Add(refreshTokenTTL)
+	if err := s.refreshRepo.Create(&model.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: refreshExpiresAt,
+	}); err != nil {
+		logger.WithError(err).Error("Failed to store refresh token")
 		return nil, err
 	}
-	log.Printf("DEBUG: Token generated successfully for %s", req.Email)
 
 	return &dto.LoginResponse{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		User:                  user,
+		ExpiresAt:             time.Now().Add(accessTokenTTL),
+		RefreshTokenExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+// RefreshAccessToken issues a new access token for an unexpired, unrevoked refresh token.
+// The refresh token itself is not rotated; it stays valid until it expires or Logout revokes it.
+func (s *userService) RefreshAccessToken(refreshToken, jwtSecret string) (*dto.RefreshTokenResponse, error) {
+	stored, err := s.getRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, ErrRefreshTokenExpired
+	}
+
+	user, err := s.userRepo.GetByID(stored.UserID)
+	if err != nil {
+		return nil, ErrRefreshTokenInvalid
+	}
+	if !user.IsActive {
+		return nil, ErrAccountInactive
+	}
+
+	accessToken, err := auth.GenerateToken(int(user.ID), user.Email, string(user.Role), jwtSecret, accessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RefreshTokenResponse{
 		AccessToken: accessToken,
-		User:        user,
-		ExpiresAt:   time.Now().Add(24 * time.Hour),
+		ExpiresAt:   time.Now().Add(accessTokenTTL),
 	}, nil
 }
 
-func (s *userService) GetAllUsers(requestorRole model.UserRole, limit, offset int) ([]*model.User, int64, error) {
+// Logout revokes the refresh token; revoking an already revoked token is an error
+func (s *userService) Logout(refreshToken string) error {
+	stored, err := s.getRefreshToken(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := s.refreshRepo.Revoke(stored.ID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrRefreshTokenRevoked
+	}
+	return nil
+}
+
+// CreateUser adds an account with the requested role and active state. Its password is a
+// random one nobody sees; the returned token is a password reset token for the caller to
+// email as a set-password link. It works whether or not self-registration is enabled.
+func (s *userService) CreateUser(requestorRole model.UserRole, createData interface{}) (*model.User, string, error) {
+	if !s.canManageUsers(requestorRole) {
+		return nil, "", ErrInsufficientPermission
+	}
+
+	req := createData.(*dto.CreateUserRequest)
+	if err := checkRoleAssignment(requestorRole, req.Role); err != nil {
+		return nil, "", err
+	}
+	// Stricter than role changes: admins can only create customers
+	if req.Role == model.RoleAdmin && requestorRole != model.RoleSuperAdmin {
+		return nil, "", ErrAdminCreationLocked
+	}
+
+	req.Email = normalizeEmail(req.Email)
+	if _, err := s.userRepo.GetByEmail(req.Email); err == nil {
+		return nil, "", ErrEmailAlreadyExists
+	}
+
+	// Placeholder until the user picks their own through the set-password link
+	password, err := utils.GenerateTemporaryPassword()
+	if err != nil {
+		return nil, "", err
+	}
+	hashed, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	user := &model.User{
+		Email:    req.Email,
+		Password: hashed,
+		FullName: req.FullName,
+		Phone:    utils.PtrOrNil(req.Phone),
+		Address:  utils.PtrOrNil(req.Address),
+		Role:     req.Role,
+		IsActive: *req.IsActive,
+	}
+	token, record, err := newToken(model.TokenPasswordReset, setPasswordTokenTTL)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.userRepo.CreateWithToken(user, record); err != nil {
+		return nil, "", err
+	}
+	return user, token, nil
+}
+
+func (s *userService) GetAllUsers(requestorRole model.UserRole, filter repository.UserFilter, limit, offset int) ([]*model.User, int64, error) {
 	if !s.canManageUsers(requestorRole) {
 		return nil, 0, ErrInsufficientPermission
 	}
 
-	users, err := s.userRepo.GetAll(limit, offset)
+	users, err := s.userRepo.GetAll(filter, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	count, err := s.userRepo.Count()
+	count, err := s.userRepo.Count(filter)
 	return users, count, err
 }
 
@@ -166,38 +378,21 @@ func (s *userService) UpdateUserRole(requestorRole model.UserRole, userID uint,
 		return ErrUserNotFound
 	}
 
-	// FIX 1: Validate target role
-	validRoles := []model.UserRole{model.RoleCustomer, model.RoleAdmin, model.RoleSuperAdmin}
-	isValidRole := false
-	for _, validRole := range validRoles {
-		if newRole == validRole {
-			isValidRole = true
-			break
-		}
-	}
-	if !isValidRole {
-		return errors.New("invalid role")
-	}
-
 	// FIX 2: Admin cannot modify super_admin
 	if requestorRole == model.RoleAdmin && targetUser.Role == model.RoleSuperAdmin {
-		return errors.New("admin cannot modify super admin role")
+		return ErrSuperAdminProtected
 	}
 
-	// FIX 3: Admin cannot promote to super_admin
-	if requestorRole == model.RoleAdmin && newRole == model.RoleSuperAdmin {
-		return errors.New("admin cannot promote user to super admin")
-	}
-
-	// FIX 4: Only super_admin can create/modify super_admin
-	if newRole == model.RoleSuperAdmin && requestorRole != model.RoleSuperAdmin {
-		return errors.New("only super admin can assign super admin role")
+	if err := checkRoleAssignment(requestorRole, newRole); err != nil {
+		return err
 	}
 
 	return s.userRepo.UpdateRole(userID, newRole)
 }
 
-func (s *userService) ToggleUserStatus(requestorRole model.UserRole, userID uint) error {
+// SetUserStatus writes the requested state instead of flipping the current one,
+// so repeated or concurrent calls always converge on the same value.
+func (s *userService) SetUserStatus(requestorRole model.UserRole, userID uint, isActive bool) error {
 	if !s.canManageUsers(requestorRole) {
 		return ErrInsufficientPermission
 	}
@@ -209,7 +404,7 @@ func (s *userService) ToggleUserStatus(requestorRole model.UserRole, userID uint
 
 	// FIX 1: Admin cannot disable super_admin
 	if requestorRole == model.RoleAdmin && targetUser.Role == model.RoleSuperAdmin {
-		return errors.New("admin cannot modify super admin status")
+		return ErrSuperAdminStatusLocked
 	}
 
 	// FIX 2: Super admin cannot be disabled (extra protection)
@@ -217,12 +412,12 @@ func (s *userService) ToggleUserStatus(requestorRole model.UserRole, userID uint
 		return ErrCannotDeleteSuperAdmin
 	}
 
-	return s.userRepo.UpdateActiveStatus(userID, !targetUser.IsActive)
+	return s.userRepo.UpdateActiveStatus(userID, isActive)
 }
 
 func (s *userService) DeleteUser(requestorID uint, requestorRole model.UserRole, targetUserID uint) error {
 	// FIX: Allow both admin and super_admin
-	if requestorRole != model.RoleAdmin && requestorRole != model.RoleSuperAdmin {
+	if !s.canManageUsers(requestorRole) {
 		return ErrInsufficientPermission
 	}
 
@@ -238,7 +433,7 @@ func (s *userService) DeleteUser(requestorID uint, requestorRole model.UserRole,
 
 	// Admin cannot delete super_admin
 	if requestorRole == model.RoleAdmin && targetUser.Role == model.RoleSuperAdmin {
-		return errors.New("admin cannot delete super admin")
+		return ErrSuperAdminDeleteLocked
 	}
 
 	// Super admin cannot be deleted (extra safety)
@@ -249,7 +444,204 @@ func (s *userService) DeleteUser(requestorID uint, requestorRole model.UserRole,
 	return s.userRepo.Delete(targetUserID)
 }
 
+// RequestPasswordReset issues a reset token for the account and returns the user with
+// the raw token to email. Unknown or inactive accounts return a nil user and no error,
+// so callers can't reveal which emails are registered.
+func (s *userService) RequestPasswordReset(email string) (*model.User, string, error) {
+	user, err := s.userRepo.GetByEmail(normalizeEmail(email))
+	if err != nil || !user.IsActive {
+		return nil, "", nil
+	}
+
+	token, err := s.issueToken(user.ID, model.TokenPasswordReset, passwordResetTokenTTL)
+	if err != nil {
+		return nil, "", err
+	}
+	return user, token, nil
+}
+
+func (s *userService) ResetPassword(token, newPassword string) error {
+	resetToken, err := s.tokenRepo.GetByHash(hashToken(token), model.TokenPasswordReset)
+	if err != nil {
+		return ErrResetTokenInvalid
+	}
+	if resetToken.IsUsed {
+		return ErrResetTokenUsed
+	}
+	if time.Now().After(resetToken.ExpiresAt) {
+		return ErrResetTokenExpired
+	}
+
+	user, err := s.userRepo.GetByID(resetToken.UserID)
+	if err != nil {
+		return ErrResetTokenInvalid
+	}
+	if !utils.IsStrongPassword(newPassword) {
+		return ErrPasswordTooWeak
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	// Consume the token first so a concurrent request with the same link loses
+	consumed, err := s.tokenRepo.MarkUsed(resetToken.ID)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrResetTokenUsed
+	}
+
+	user.Password = hashedPassword
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+	// Whoever prompted the reset may hold a session; sign every device out
+	return s.refreshRepo.RevokeAllForUser(user.ID)
+}
+
+// IssueEmailVerification creates a verification token for the user and returns the raw token to email
+func (s *userService) IssueEmailVerification(userID uint) (string, error) {
+	return s.issueToken(userID, model.TokenEmailVerification, emailVerificationTokenTTL)
+}
+
+// VerifyEmail redeems a verification token and activates its account
+func (s *userService) VerifyEmail(token string) error {
+	verifyToken, err := s.tokenRepo.GetByHash(hashToken(token), model.TokenEmailVerification)
+	if err != nil {
+		return ErrVerifyTokenInvalid
+	}
+	if verifyToken.IsUsed {
+		return ErrVerifyTokenUsed
+	}
+	if time.Now().After(verifyToken.ExpiresAt) {
+		return ErrVerifyTokenExpired
+	}
+
+	consumed, err := s.tokenRepo.MarkUsed(verifyToken.ID)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrVerifyTokenUsed
+	}
+
+	return s.userRepo.UpdateActiveStatus(verifyToken.UserID, true)
+}
+
 // Helper methods
+
+// upgradePasswordHash rehashes with the current BCRYPT_COST after a successful login. Failures
+// are only logged; the old hash keeps working and the upgrade is retried on the next login.
+func (s *userService) upgradePasswordHash(user *model.User, plainPassword string) {
+	if !utils.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashed, err := utils.HashPassword(plainPassword)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", user.ID).Warn("Failed to rehash password")
+		return
+	}
+	user.Password = hashed
+	if err := s.userRepo.Update(user); err != nil {
+		logrus.WithError(err).WithField("user_id", user.ID).Warn("Failed to save rehashed password")
+		return
+	}
+	logrus.WithField("user_id", user.ID).Info("Password hash upgraded to current bcrypt cost")
+}
+
+func (s *userService) getRefreshToken(refreshToken string) (*model.RefreshToken, error) {
+	stored, err := s.refreshRepo.GetByHash(hashToken(refreshToken))
+	if err != nil {
+		return nil, ErrRefreshTokenInvalid
+	}
+	if stored.RevokedAt != nil {
+		return nil, ErrRefreshTokenRevoked
+	}
+	return stored, nil
+}
+
+func (s *userService) issueToken(userID uint, purpose model.TokenPurpose, ttl time.Duration) (string, error) {
+	token, record, err := newToken(purpose, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	record.UserID = userID
+	return token, s.tokenRepo.Create(record)
+}
+
+// newToken returns a random token and its unsaved, hashed record; the caller sets UserID
+func newToken(purpose model.TokenPurpose, ttl time.Duration) (string, *model.EmailVerificationToken, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", nil, err
+	}
+	return token, &model.EmailVerificationToken{
+		TokenHash: hashToken(token),
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(ttl),
+	}, nil
+}
+
+// isAwaitingVerification tells an unverified signup apart from an account an admin deactivated
+func (s *userService) isAwaitingVerification(userID uint) bool {
+	if s.tokenRepo == nil {
+		return false
+	}
+	pending, err := s.tokenRepo.HasUnused(userID, model.TokenEmailVerification)
+	return err == nil && pending
+}
+
+// randomToken returns 32 random bytes, hex-encoded
+func randomToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// checkRoleAssignment validates newRole and that requestorRole may hand it out,
+// for both role changes and admin-created accounts
+func checkRoleAssignment(requestorRole, newRole model.UserRole) error {
+	// FIX 1: Validate target role
+	validRoles := []model.UserRole{model.RoleCustomer, model.RoleAdmin, model.RoleSuperAdmin}
+	isValidRole := false
+	for _, validRole := range validRoles {
+		if newRole == validRole {
+			isValidRole = true
+			break
+		}
+	}
+	if !isValidRole {
+		return ErrInvalidRole
+	}
+
+	// FIX 3: Admin cannot promote to super_admin
+	if requestorRole == model.RoleAdmin && newRole == model.RoleSuperAdmin {
+		return ErrSuperAdminPromotion
+	}
+
+	// FIX 4: Only super_admin can create/modify super_admin
+	if newRole == model.RoleSuperAdmin && !requestorRole.Permissions().CanAssignSuperAdmin {
+		return ErrSuperAdminAssignment
+	}
+	return nil
+}
+
 func (s *userService) canManageUsers(role model.UserRole) bool {
-	return role == model.RoleAdmin || role == model.RoleSuperAdmin
+	return role.Permissions().CanManageUsers
 }
@@ -0,0 +1,682 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yoockh/go-game-rental-api/internal/dto"
+	"github.com/yoockh/go-game-rental-api/internal/model"
+	"github.com/yoockh/go-game-rental-api/internal/repository"
+	"github.com/yoockh/go-game-rental-api/internal/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ============= MOCK USER REPOSITORY =============
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(user *model.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateWithToken(user *model.User, token *model.EmailVerificationToken) error {
+	args := m.Called(user, token)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetByID(id uint) (*model.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmail(email string) (*model.User, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(user *model.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetAll(filter repository.UserFilter, limit, offset int) ([]*model.User, error) {
+	args := m.Called(filter, limit, offset)
+	return args.Get(0).([]*model.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateRole(userID uint, newRole model.UserRole) error {
+	args := m.Called(userID, newRole)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateActiveStatus(userID uint, isActive bool) error {
+	args := m.Called(userID, isActive)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateLastLogin(userID uint, at time.Time) error {
+	args := m.Called(userID, at)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Count(filter repository.UserFilter) (int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// ============= TEST LOGIN MIXED-CASE EMAIL =============
+func TestLogin_MixedCaseEmail(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	svc := NewUserService(mockUserRepo, nil, mockRefreshRepo, false, true)
+	mockRefreshRepo.On("Create", mock.Anything).Return(nil)
+
+	hashed, err := utils.HashPassword("password123")
+	assert.NoError(t, err)
+
+	storedUser := &model.User{
+		ID:       1,
+		Email:    "test@example.com",
+		Password: hashed,
+		Role:     model.RoleCustomer,
+		IsActive: true,
+	}
+
+	// Lookup must receive the normalized address
+	mockUserRepo.On("GetByEmail", "test@example.com").Return(storedUser, nil)
+
+	mockUserRepo.On("UpdateLastLogin", uint(1), mock.Anything).Return(nil)
+	resp, err := svc.Login(&dto.LoginRequest{Email: "  Test@Example.COM ", Password: "password123"}, "test-secret")
+	if assert.NoError(t, err) {
+		loginResp := resp.(*dto.LoginResponse)
+		assert.NotEmpty(t, loginResp.AccessToken)
+		assert.Equal(t, storedUser.ID, loginResp.User.ID)
+	}
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+// ============= TEST REGISTER NORMALIZES EMAIL =============
+func TestRegister_NormalizesEmail(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	svc := NewUserService(mockUserRepo, nil, nil, false, true)
+
+	mockUserRepo.On("GetByEmail", "new@example.com").Return(nil, errors.New("record not found"))
+	mockUserRepo.On("Create", mock.MatchedBy(func(u *model.User) bool {
+		return u.Email == "new@example.com"
+	})).Return(nil)
+
+	user, err := svc.Register(&dto.RegisterRequest{
+		Email:    "New@Example.com",
+		Password: "password123",
+		FullName: "New User",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "new@example.com", user.Email)
+	}
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+// ============= TEST REGISTER DUPLICATE MIXED-CASE EMAIL =============
+func TestRegister_DuplicateMixedCaseEmail(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	svc := NewUserService(mockUserRepo, nil, nil, false, true)
+
+	mockUserRepo.On("GetByEmail", "test@example.com").Return(&model.User{ID: 1, Email: "test@example.com"}, nil)
+
+	_, err := svc.Register(&dto.RegisterRequest{
+		Email:    "TEST@example.com",
+		Password: "password123",
+		FullName: "Duplicate User",
+	})
+	assert.EqualError(t, err, "email already exists")
+
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// ============= TEST REGISTRATION DISABLED =============
+func TestRegister_Disabled(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	svc := NewUserService(mockUserRepo, nil, nil, false, false)
+
+	_, err := svc.Register(&dto.RegisterRequest{
+		Email:    "new@example.com",
+		Password: "password123",
+		FullName: "New User",
+	})
+	assert.ErrorIs(t, err, ErrRegistrationDisabled)
+
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// ============= TEST ADMIN CREATE USER =============
+func TestCreateUser_ExplicitRoleAndStatus(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockEmailVerificationRepository)
+	// Admin creation keeps working with self-registration off
+	svc := NewUserService(mockUserRepo, mockTokenRepo, nil, true, false)
+	isActive := false
+
+	mockUserRepo.On("GetByEmail", "staff@example.com").Return(nil, errors.New("record not found"))
+	// The account and its set-password token are saved together, never through tokenRepo alone
+	var stored *model.EmailVerificationToken
+	mockUserRepo.On("CreateWithToken", mock.Anything, mock.MatchedBy(func(tok *model.EmailVerificationToken) bool {
+		stored = tok
+		return tok.Purpose == model.TokenPasswordReset
+	})).Return(nil)
+
+	user, token, err := svc.CreateUser(model.RoleSuperAdmin, &dto.CreateUserRequest{
+		Email:    " Staff@Example.com",
+		FullName: "Staff Member",
+		Role:     model.RoleAdmin,
+		IsActive: &isActive,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "staff@example.com", user.Email)
+		assert.Equal(t, model.RoleAdmin, user.Role)
+		assert.False(t, user.IsActive)
+		assert.NotEmpty(t, user.Password)
+		// The emailed token is a set-password link, stored hashed
+		assert.Equal(t, hashToken(token), stored.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(setPasswordTokenTTL), stored.ExpiresAt, time.Minute)
+	}
+
+	mockUserRepo.AssertExpectations(t)
+	mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateUser_RoleGuard(t *testing.T) {
+	cases := []struct {
+		name      string
+		requestor model.UserRole
+		role      model.UserRole
+		wantErr   error
+	}{
+		{"admin creates admin", model.RoleAdmin, model.RoleAdmin, ErrAdminCreationLocked},
+		{"admin creates super admin", model.RoleAdmin, model.RoleSuperAdmin, ErrSuperAdminPromotion},
+		{"customer creates customer", model.RoleCustomer, model.RoleCustomer, ErrInsufficientPermission},
+		{"unknown role", model.RoleSuperAdmin, model.UserRole("partner"), ErrInvalidRole},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			svc := NewUserService(mockUserRepo, nil, nil, false, true)
+			isActive := true
+
+			_, _, err := svc.CreateUser(tc.requestor, &dto.CreateUserRequest{
+				Email:    "new@example.com",
+				FullName: "New User",
+				Role:     tc.role,
+				IsActive: &isActive,
+			})
+			assert.ErrorIs(t, err, tc.wantErr)
+
+			mockUserRepo.AssertNotCalled(t, "CreateWithToken", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// ============= MOCK EMAIL VERIFICATION REPOSITORY =============
+type MockEmailVerificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailVerificationRepository) Create(token *model.EmailVerificationToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockEmailVerificationRepository) GetByHash(tokenHash string, purpose model.TokenPurpose) (*model.EmailVerificationToken, error) {
+	args := m.Called(tokenHash, purpose)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.EmailVerificationToken), args.Error(1)
+}
+
+func (m *MockEmailVerificationRepository) MarkUsed(tokenID uint) (bool, error) {
+	args := m.Called(tokenID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockEmailVerificationRepository) HasUnused(userID uint, purpose model.TokenPurpose) (bool, error) {
+	args := m.Called(userID, purpose)
+	return args.Bool(0), args.Error(1)
+}
+
+// ============= TEST PASSWORD RESET =============
+func TestRequestPasswordReset_UnknownEmailIsSilent(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockEmailVerificationRepository)
+	svc := NewUserService(mockUserRepo, mockTokenRepo, nil, false, true)
+
+	mockUserRepo.On("GetByEmail", "nobody@example.com").Return(nil, errors.New("record not found"))
+
+	user, token, err := svc.RequestPasswordReset("Nobody@Example.com")
+	assert.NoError(t, err)
+	assert.Nil(t, user)
+	assert.Empty(t, token)
+	mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRequestPasswordReset_StoresOnlyHash(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockEmailVerificationRepository)
+	svc := NewUserService(mockUserRepo, mockTokenRepo, nil, false, true)
+
+	mockUserRepo.On("GetByEmail", "test@example.com").Return(&model.User{ID: 1, Email: "test@example.com", IsActive: true}, nil)
+	var stored *model.EmailVerificationToken
+	mockTokenRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*model.EmailVerificationToken)
+	}).Return(nil)
+
+	user, token, err := svc.RequestPasswordReset("test@example.com")
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(1), user.ID)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, hashToken(token), stored.TokenHash)
+		assert.NotEqual(t, token, stored.TokenHash)
+		assert.Equal(t, model.TokenPasswordReset, stored.Purpose)
+	}
+}
+
+func TestResetPassword_ExpiredToken(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockEmailVerificationRepository)
+	svc := NewUserService(mockUserRepo, mockTokenRepo, nil, false, true)
+
+	mockTokenRepo.On("GetByHash", hashToken("raw-token"), model.TokenPasswordReset).Return(&model.EmailVerificationToken{
+		ID: 7, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute),
+	}, nil)
+
+	err := svc.ResetPassword("raw-token", "newpassword123")
+	assert.ErrorIs(t, err, ErrResetTokenExpired)
+	mockTokenRepo.AssertNotCalled(t, "MarkUsed", mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestResetPassword_ReusedToken(t *testing.T) {
+	cases := map[string]struct {
+		isUsed   bool
+		consumed bool
+	}{
+		"already used":          {isUsed: true},
+		"consumed concurrently": {isUsed: false, consumed: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockTokenRepo := new(MockEmailVerificationRepository)
+			svc := NewUserService(mockUserRepo, mockTokenRepo, nil, false, true)
+
+			mockTokenRepo.On("GetByHash", hashToken("raw-token"), model.TokenPasswordReset).Return(&model.EmailVerificationToken{
+				ID: 7, UserID: 1, IsUsed: tc.isUsed, ExpiresAt: time.Now().Add(time.Hour),
+			}, nil)
+			mockUserRepo.On("GetByID", uint(1)).Return(&model.User{ID: 1}, nil)
+			mockTokenRepo.On("MarkUsed", uint(7)).Return(tc.consumed, nil)
+
+			err := svc.ResetPassword("raw-token", "newpassword123")
+			assert.ErrorIs(t, err, ErrResetTokenUsed)
+			mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+		})
+	}
+}
+
+func TestResetPassword_WeakPassword(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockEmailVerificationRepository)
+	svc := NewUserService(mockUserRepo, mockTokenRepo, nil, false, true)
+
+	mockTokenRepo.On("GetByHash", hashToken("raw-token"), model.TokenPasswordReset).Return(&model.EmailVerificationToken{
+		ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	mockUserRepo.On("GetByID", uint(1)).Return(&model.User{ID: 1, Password: "old-hash"}, nil)
+
+	err := svc.ResetPassword("raw-token", "password")
+	assert.ErrorIs(t, err, ErrPasswordTooWeak)
+	mockTokenRepo.AssertNotCalled(t, "MarkUsed", mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestResetPassword_UpdatesPassword(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockEmailVerificationRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	svc := NewUserService(mockUserRepo, mockTokenRepo, mockRefreshRepo, false, true)
+
+	mockTokenRepo.On("GetByHash", hashToken("raw-token"), model.TokenPasswordReset).Return(&model.EmailVerificationToken{
+		ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	mockUserRepo.On("GetByID", uint(1)).Return(&model.User{ID: 1, Password: "old-hash"}, nil)
+	mockTokenRepo.On("MarkUsed", uint(7)).Return(true, nil)
+	mockUserRepo.On("Update", mock.MatchedBy(func(u *model.User) bool {
+		return utils.CheckPassword(u.Password, "newpassword123")
+	})).Return(nil)
+	mockRefreshRepo.On("RevokeAllForUser", uint(1)).Return(nil)
+
+	assert.NoError(t, svc.ResetPassword("raw-token", "newpassword123"))
+	mockUserRepo.AssertExpectations(t)
+	mockRefreshRepo.AssertExpectations(t)
+}
+
+// ============= TEST EMAIL VERIFICATION =============
+func TestEmailVerification_HappyPath(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockEmailVerificationRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	svc := NewUserService(mockUserRepo, mockTokenRepo, mockRefreshRepo, true, true)
+	mockRefreshRepo.On("Create", mock.Anything).Return(nil)
+
+	// Register leaves the account inactive
+	var created *model.User
+	mockUserRepo.On("GetByEmail", "new@example.com").Return(nil, errors.New("record not found")).Once()
+	mockUserRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*model.User)
+		created.ID = 1
+	}).Return(nil)
+
+	user, err := svc.Register(&dto.RegisterRequest{Email: "new@example.com", Password: "password123", FullName: "New User"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, user.IsActive)
+
+	var stored *model.EmailVerificationToken
+	mockTokenRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*model.EmailVerificationToken)
+		stored.ID = 7
+	}).Return(nil)
+
+	token, err := svc.IssueEmailVerification(user.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, model.TokenEmailVerification, stored.Purpose)
+
+	// Login before verifying gets the dedicated error
+	mockUserRepo.On("GetByEmail", "new@example.com").Return(created, nil)
+	mockTokenRepo.On("HasUnused", uint(1), model.TokenEmailVerification).Return(true, nil).Once()
+
+	_, err = svc.Login(&dto.LoginRequest{Email: "new@example.com", Password: "password123"}, "test-secret")
+	assert.ErrorIs(t, err, ErrEmailNotVerified)
+
+	// Verifying consumes the token and activates the account
+	mockTokenRepo.On("GetByHash", hashToken(token), model.TokenEmailVerification).Return(stored, nil)
+	mockTokenRepo.On("MarkUsed", uint(7)).Return(true, nil)
+	mockUserRepo.On("UpdateActiveStatus", uint(1), true).Run(func(args mock.Arguments) {
+		created.IsActive = true
+	}).Return(nil)
+
+	assert.NoError(t, svc.VerifyEmail(token))
+
+	mockUserRepo.On("UpdateLastLogin", uint(1), mock.Anything).Return(nil)
+	_, err = svc.Login(&dto.LoginRequest{Email: "new@example.com", Password: "password123"}, "test-secret")
+	assert.NoError(t, err)
+
+	mockUserRepo.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestVerifyEmail_ExpiredToken(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockEmailVerificationRepository)
+	svc := NewUserService(mockUserRepo, mockTokenRepo, nil, true, true)
+
+	mockTokenRepo.On("GetByHash", hashToken("raw-token"), model.TokenEmailVerification).Return(&model.EmailVerificationToken{
+		ID: 7, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute),
+	}, nil)
+
+	err := svc.VerifyEmail("raw-token")
+	assert.ErrorIs(t, err, ErrVerifyTokenExpired)
+	mockTokenRepo.AssertNotCalled(t, "MarkUsed", mock.Anything)
+	mockUserRepo.AssertNotCalled(t, "UpdateActiveStatus", mock.Anything, mock.Anything)
+}
+
+func TestLogin_DeactivatedAccountIsNotAskedToVerify(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockEmailVerificationRepository)
+	svc := NewUserService(mockUserRepo, mockTokenRepo, nil, true, true)
+
+	hashed, err := utils.HashPassword("password123")
+	assert.NoError(t, err)
+	mockUserRepo.On("GetByEmail", "test@example.com").Return(&model.User{ID: 1, Email: "test@example.com", Password: hashed}, nil)
+	mockTokenRepo.On("HasUnused", uint(1), model.TokenEmailVerification).Return(false, nil)
+
+	_, err = svc.Login(&dto.LoginRequest{Email: "test@example.com", Password: "password123"}, "test-secret")
+	assert.ErrorIs(t, err, ErrAccountInactive)
+}
+
+// ============= MOCK REFRESH TOKEN REPOSITORY =============
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(token *model.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) GetByHash(tokenHash string) (*model.RefreshToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) Revoke(tokenID uint) (bool, error) {
+	args := m.Called(tokenID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllForUser(userID uint) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+// ============= TEST REFRESH TOKENS =============
+func TestLogin_IssuesHashedRefreshToken(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	svc := NewUserService(mockUserRepo, nil, mockRefreshRepo, false, true)
+
+	hashed, err := utils.HashPassword("password123")
+	assert.NoError(t, err)
+	mockUserRepo.On("GetByEmail", "test@example.com").Return(&model.User{ID: 1, Email: "test@example.com", Password: hashed, IsActive: true}, nil)
+	var stored *model.RefreshToken
+	mockRefreshRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*model.RefreshToken)
+	}).Return(nil)
+
+	mockUserRepo.On("UpdateLastLogin", uint(1), mock.Anything).Return(nil)
+	resp, err := svc.Login(&dto.LoginRequest{Email: "test@example.com", Password: "password123"}, "test-secret")
+	if assert.NoError(t, err) {
+		loginResp := resp.(*dto.LoginResponse)
+		assert.NotEmpty(t, loginResp.RefreshToken)
+		assert.Equal(t, hashToken(loginResp.RefreshToken), stored.TokenHash)
+		assert.Equal(t, uint(1), stored.UserID)
+		assert.True(t, stored.ExpiresAt.After(loginResp.ExpiresAt))
+	}
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Hour)
+	cases := []struct {
+		name    string
+		token   *model.RefreshToken
+		wantErr error
+	}{
+		{"valid", &model.RefreshToken{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil},
+		{"expired", &model.RefreshToken{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}, ErrRefreshTokenExpired},
+		{"revoked", &model.RefreshToken{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, ErrRefreshTokenRevoked},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockRefreshRepo := new(MockRefreshTokenRepository)
+			svc := NewUserService(mockUserRepo, nil, mockRefreshRepo, false, true)
+
+			mockRefreshRepo.On("GetByHash", hashToken("raw-refresh")).Return(tc.token, nil)
+			mockUserRepo.On("GetByID", uint(1)).Return(&model.User{ID: 1, Email: "test@example.com", Role: model.RoleCustomer, IsActive: true}, nil)
+
+			resp, err := svc.RefreshAccessToken("raw-refresh", "test-secret")
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, resp)
+				mockUserRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.NotEmpty(t, resp.AccessToken)
+			}
+		})
+	}
+}
+
+func TestRefreshAccessToken_UnknownToken(t *testing.T) {
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	svc := NewUserService(new(MockUserRepository), nil, mockRefreshRepo, false, true)
+
+	mockRefreshRepo.On("GetByHash", hashToken("unknown")).Return(nil, errors.New("record not found"))
+
+	_, err := svc.RefreshAccessToken("unknown", "test-secret")
+	assert.ErrorIs(t, err, ErrRefreshTokenInvalid)
+}
+
+func TestLogout_RevokesRefreshToken(t *testing.T) {
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	svc := NewUserService(new(MockUserRepository), nil, mockRefreshRepo, false, true)
+
+	mockRefreshRepo.On("GetByHash", hashToken("raw-refresh")).Return(&model.RefreshToken{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	mockRefreshRepo.On("Revoke", uint(3)).Return(true, nil).Once()
+
+	assert.NoError(t, svc.Logout("raw-refresh"))
+
+	// A concurrent logout that loses the race reports the token as already revoked
+	mockRefreshRepo.On("Revoke", uint(3)).Return(false, nil)
+	assert.ErrorIs(t, svc.Logout("raw-refresh"), ErrRefreshTokenRevoked)
+}
+
+// ============= TEST CHANGE PASSWORD =============
+func TestChangePassword(t *testing.T) {
+	hashed, err := utils.HashPassword("oldpassword1")
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		oldPassword string
+		newPassword string
+		wantErr     error
+	}{
+		{"success", "oldpassword1", "newpassword1", nil},
+		{"wrong current password", "wrongpassword1", "newpassword1", ErrCurrentPasswordWrong},
+		{"too short", "oldpassword1", "abc123", ErrPasswordTooWeak},
+		{"no digit", "oldpassword1", "onlyletters", ErrPasswordTooWeak},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockRefreshRepo := new(MockRefreshTokenRepository)
+			svc := NewUserService(mockUserRepo, nil, mockRefreshRepo, false, true)
+
+			mockUserRepo.On("GetByID", uint(1)).Return(&model.User{ID: 1, Password: hashed}, nil)
+			mockUserRepo.On("Update", mock.MatchedBy(func(u *model.User) bool {
+				return utils.CheckPassword(u.Password, tc.newPassword)
+			})).Return(nil)
+			mockRefreshRepo.On("RevokeAllForUser", uint(1)).Return(nil)
+
+			err := svc.ChangePassword(1, tc.oldPassword, tc.newPassword)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+				mockRefreshRepo.AssertNotCalled(t, "RevokeAllForUser", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			mockUserRepo.AssertExpectations(t)
+			mockRefreshRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// ============= TEST LOGIN REHASH =============
+func TestLogin_RehashesOutdatedCost(t *testing.T) {
+	t.Setenv("BCRYPT_COST", "5")
+
+	oldHash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	svc := NewUserService(mockUserRepo, nil, mockRefreshRepo, false, true)
+
+	mockUserRepo.On("GetByEmail", "test@example.com").Return(&model.User{ID: 1, Email: "test@example.com", Password: string(oldHash), IsActive: true}, nil)
+	mockRefreshRepo.On("Create", mock.Anything).Return(nil)
+	mockUserRepo.On("Update", mock.MatchedBy(func(u *model.User) bool {
+		cost, err := bcrypt.Cost([]byte(u.Password))
+		return err == nil && cost == 5 && utils.CheckPassword(u.Password, "password123")
+	})).Return(nil)
+
+	mockUserRepo.On("UpdateLastLogin", uint(1), mock.Anything).Return(nil)
+	_, err = svc.Login(&dto.LoginRequest{Email: "test@example.com", Password: "password123"}, "test-secret")
+	assert.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestLogin_KeepsCurrentCost(t *testing.T) {
+	t.Setenv("BCRYPT_COST", "5")
+
+	hashed, err := utils.HashPassword("password123")
+	assert.NoError(t, err)
+
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	svc := NewUserService(mockUserRepo, nil, mockRefreshRepo, false, true)
+
+	mockUserRepo.On("GetByEmail", "test@example.com").Return(&model.User{ID: 1, Email: "test@example.com", Password: hashed, IsActive: true}, nil)
+	mockRefreshRepo.On("Create", mock.Anything).Return(nil)
+
+	mockUserRepo.On("UpdateLastLogin", uint(1), mock.Anything).Return(nil)
+	_, err = svc.Login(&dto.LoginRequest{Email: "test@example.com", Password: "password123"}, "test-secret")
+	assert.NoError(t, err)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestLogin_WrongPasswordIsNotRehashed(t *testing.T) {
+	t.Setenv("BCRYPT_COST", "5")
+
+	oldHash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	mockUserRepo := new(MockUserRepository)
+	svc := NewUserService(mockUserRepo, nil, nil, false, true)
+
+	mockUserRepo.On("GetByEmail", "test@example.com").Return(&model.User{ID: 1, Email: "test@example.com", Password: string(oldHash), IsActive: true}, nil)
+
+	_, err = svc.Login(&dto.LoginRequest{Email: "test@example.com", Password: "wrongpassword"}, "test-secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
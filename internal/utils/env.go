@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// GetEnvString reads a string env var, returning def when unset or blank
+func GetEnvString(key, def string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	return def
+}
+
+// GetEnvBool reads a boolean env var, returning def when unset or unparsable
+func GetEnvBool(key string, def bool) bool {
+	if v, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// GetEnvInt reads a positive integer env var, returning def when unset or invalid
+func GetEnvInt(key string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
+
+// GetEnvFloat reads a positive float env var, returning def when unset or invalid
+func GetEnvFloat(key string, def float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil && v > 0 {
+		return v
+	}
+	return def
+}
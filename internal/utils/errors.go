@@ -1,14 +1,53 @@
 package utils
 
 import (
+	"errors"
+	"net/http"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	myResponse "github.com/yoockh/go-api-utils/pkg-echo/response"
 )
 
-// MapServiceError maps service errors to appropriate HTTP responses
+// ServiceError is a service-layer error that carries the HTTP status it should surface as
+type ServiceError struct {
+	Code       string
+	Message    string
+	HTTPStatus int
+}
+
+func (e *ServiceError) Error() string {
+	return e.Message
+}
+
+func NewServiceError(code string, httpStatus int, message string) *ServiceError {
+	return &ServiceError{Code: code, Message: message, HTTPStatus: httpStatus}
+}
+
+func NewNotFoundError(code, message string) *ServiceError {
+	return NewServiceError(code, http.StatusNotFound, message)
+}
+
+func NewForbiddenError(code, message string) *ServiceError {
+	return NewServiceError(code, http.StatusForbidden, message)
+}
+
+func NewBadRequestError(code, message string) *ServiceError {
+	return NewServiceError(code, http.StatusBadRequest, message)
+}
+
+func NewConflictError(code, message string) *ServiceError {
+	return NewServiceError(code, http.StatusConflict, message)
+}
+
+// MapServiceError maps service errors to appropriate HTTP responses.
+// ServiceErrors carry their own status; anything else falls back to message matching.
 func MapServiceError(c echo.Context, err error) error {
+	var svcErr *ServiceError
+	if errors.As(err, &svcErr) {
+		return myResponse.Error(c, svcErr.HTTPStatus, svcErr.Message)
+	}
+
 	errMsg := err.Error()
 
 	switch {
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ParseFields splits a comma separated `fields` query value into a lookup set.
+// An empty value yields nil, meaning "return everything".
+func ParseFields(raw string) map[string]bool {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	fields := make(map[string]bool)
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields[f] = true
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// SelectFields serializes data and keeps only the requested top-level JSON keys of the
+// object, or of each object in an array. Unknown field names are ignored. With no
+// fields the data is returned untouched.
+func SelectFields(data interface{}, fields map[string]bool) (interface{}, error) {
+	if len(fields) == 0 {
+		return data, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+
+	switch v := decoded.(type) {
+	case map[string]interface{}:
+		return pickKeys(v, fields), nil
+	case []interface{}:
+		for i, item := range v {
+			if obj, ok := item.(map[string]interface{}); ok {
+				v[i] = pickKeys(obj, fields)
+			}
+		}
+		return v, nil
+	default:
+		return decoded, nil
+	}
+}
+
+func pickKeys(obj map[string]interface{}, fields map[string]bool) map[string]interface{} {
+	picked := make(map[string]interface{}, len(fields))
+	for k, val := range obj {
+		if fields[k] {
+			picked[k] = val
+		}
+	}
+	return picked
+}
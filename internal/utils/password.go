@@ -1,12 +1,37 @@
 package utils
 
 import (
+	"crypto/rand"
+	"math/big"
+	"unicode"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+// MinPasswordLength is the shortest password IsStrongPassword accepts
+const MinPasswordLength = 8
+
+const (
+	temporaryPasswordLength = 12
+	// temporaryPasswordChars is alphanumeric without look-alikes (0/O, 1/l/I)
+	temporaryPasswordChars = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// BcryptCost is the work factor for new hashes, from BCRYPT_COST (default 10), clamped to bcrypt's limits
+func BcryptCost() int {
+	cost := GetEnvInt("BCRYPT_COST", bcrypt.DefaultCost)
+	if cost < bcrypt.MinCost {
+		return bcrypt.MinCost
+	}
+	if cost > bcrypt.MaxCost {
+		return bcrypt.MaxCost
+	}
+	return cost
+}
+
 // HashPassword generates a bcrypt hash from plain password
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), BcryptCost())
 	if err != nil {
 		return "", err
 	}
@@ -19,3 +44,45 @@ func CheckPassword(hashedPassword, plainPassword string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
 	return err == nil
 }
+
+// NeedsRehash reports whether hash was made with a lower cost than BcryptCost.
+// Hashes above the current cost are left alone, so lowering BCRYPT_COST never weakens them.
+func NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err == nil && cost < BcryptCost()
+}
+
+// GenerateTemporaryPassword returns a random password that passes IsStrongPassword
+func GenerateTemporaryPassword() (string, error) {
+	max := big.NewInt(int64(len(temporaryPasswordChars)))
+	for {
+		password := make([]byte, temporaryPasswordLength)
+		for i := range password {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			password[i] = temporaryPasswordChars[n.Int64()]
+		}
+		if IsStrongPassword(string(password)) {
+			return string(password), nil
+		}
+	}
+}
+
+// IsStrongPassword requires at least MinPasswordLength characters with a letter and a digit
+func IsStrongPassword(password string) bool {
+	if len([]rune(password)) < MinPasswordLength {
+		return false
+	}
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	return hasLetter && hasDigit
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	myResponse "github.com/yoockh/go-api-utils/pkg-echo/response"
+	"golang.org/x/time/rate"
+)
+
+// PublicRateLimiter throttles unauthenticated catalog traffic per client IP. It is mounted on
+// the catalog route group only, so no other path needs skipping.
+// ratePerSecond is the steady refill rate and burst the bucket size.
+func PublicRateLimiter(ratePerSecond float64, burst int) echo.MiddlewareFunc {
+	retryAfter := strconv.Itoa(int(math.Ceil(1 / ratePerSecond)))
+
+	return middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
+		Store: middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:      rate.Limit(ratePerSecond),
+			Burst:     burst,
+			ExpiresIn: 3 * time.Minute,
+		}),
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			return c.RealIP(), nil
+		},
+		ErrorHandler: func(c echo.Context, err error) error {
+			return myResponse.Forbidden(c, "Unable to identify client")
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			c.Response().Header().Set("Retry-After", retryAfter)
+			return myResponse.Error(c, http.StatusTooManyRequests, "Too many requests, please slow down")
+		},
+	})
+}
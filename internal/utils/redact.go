@@ -0,0 +1,12 @@
+package utils
+
+import "strings"
+
+// RedactEmail keeps the first character of the local part and the domain, e.g. j***@example.com
+func RedactEmail(addr string) string {
+	at := strings.LastIndex(addr, "@")
+	if at <= 0 {
+		return "***"
+	}
+	return addr[:1] + "***" + addr[at:]
+}
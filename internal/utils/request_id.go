@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID stores the request ID on ctx so code below the handler can log it
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored on ctx, or "" when absent
+func RequestIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// RequestIDContext copies the ID set by Echo's RequestID middleware into the request context.
+// It must be registered after middleware.RequestID().
+func RequestIDContext() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+				req := c.Request()
+				c.SetRequest(req.WithContext(WithRequestID(req.Context(), id)))
+			}
+			return next(c)
+		}
+	}
+}
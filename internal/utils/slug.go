@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength leaves room in the column for a collision suffix
+const MaxSlugLength = 200
+
+// Slugify lowercases s, drops accents and joins the remaining letters and digits with hyphens,
+// e.g. "God of War Ragnarök" becomes "god-of-war-ragnarok". Names with nothing usable give "game".
+func Slugify(s string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(folded) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			if b.Len() >= MaxSlugLength {
+				break
+			}
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	slug := strings.TrimRight(b.String(), "-")
+	if slug == "" {
+		return "game"
+	}
+	return slug
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// timeoutErrorBody matches response.Error, which the rest of the API uses for failures
+const timeoutErrorBody = `{"error":"Request timed out"}`
+
+// RequestTimeout answers 503 with a JSON error once a request runs longer than timeout.
+// It has to be registered before every other middleware: it replaces the response writer
+// with a buffered one, and middleware outside it races with the handler goroutine.
+// The buffering would hold a whole stream in memory and cut it off at timeout, so
+// streamingPaths (route paths such as "/admin/payments/export") are left unbounded.
+func RequestTimeout(timeout time.Duration, streamingPaths ...string) echo.MiddlewareFunc {
+	skip := make(map[string]bool, len(streamingPaths))
+	for _, path := range streamingPaths {
+		skip[path] = true
+	}
+
+	timeoutMiddleware := middleware.TimeoutWithConfig(middleware.TimeoutConfig{
+		Timeout:      timeout,
+		ErrorMessage: timeoutErrorBody,
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		handler := timeoutMiddleware(next)
+		return func(c echo.Context) error {
+			if skip[c.Path()] {
+				return next(c)
+			}
+			// The timeout reply goes straight to this writer, so label it JSON up front;
+			// a handler that does answer sets its own Content-Type over this one
+			c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			return handler(c)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+-- Normalize user emails to lowercase and enforce case-insensitive uniqueness.
+-- Run once against databases created before idx_users_email_lower existed.
+BEGIN;
+
+-- Accounts that collide case-insensitively with an older account keep working data
+-- but are deactivated and get a unique placeholder email so the index can be built.
+-- Review these rows manually (SELECT * FROM users WHERE email LIKE '%#dup%').
+WITH ranked AS (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY LOWER(email) ORDER BY created_at, id) AS rn
+    FROM users
+)
+UPDATE users u
+SET email = LOWER(u.email) || '#dup' || u.id,
+    is_active = false
+FROM ranked r
+WHERE u.id = r.id AND r.rn > 1;
+
+UPDATE users SET email = LOWER(email) WHERE email <> LOWER(email);
+
+DROP INDEX IF EXISTS idx_users_email;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users(LOWER(email));
+
+COMMIT;
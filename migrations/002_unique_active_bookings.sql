@@ -0,0 +1,25 @@
+-- Prevent a user from holding two live bookings for the same game and dates.
+-- Run once against databases created before idx_bookings_unique_active existed.
+BEGIN;
+
+-- Retries that produced duplicate still-pending bookings are cancelled, keeping the oldest.
+-- Duplicates that already progressed past pending are left alone; resolve them manually
+-- if the index below fails to build.
+WITH ranked AS (
+    SELECT id, status, ROW_NUMBER() OVER (
+        PARTITION BY user_id, game_id, start_date, end_date
+        ORDER BY created_at, id
+    ) AS rn
+    FROM bookings
+    WHERE status <> 'cancelled'
+)
+UPDATE bookings b
+SET status = 'cancelled'
+FROM ranked r
+WHERE b.id = r.id AND r.rn > 1 AND r.status = 'pending';
+
+CREATE UNIQUE INDEX idx_bookings_unique_active
+    ON bookings(user_id, game_id, start_date, end_date)
+    WHERE status <> 'cancelled';
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- In-app notification feed. Run once against databases created before the notifications table existed.
+BEGIN;
+
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    booking_id BIGINT REFERENCES bookings(id) ON DELETE SET NULL,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC);
+
+COMMIT;
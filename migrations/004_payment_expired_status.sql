@@ -0,0 +1,3 @@
+-- Distinguish gateway expiry from other payment failures.
+-- ALTER TYPE ... ADD VALUE cannot run inside a transaction block on PostgreSQL < 12, so no BEGIN/COMMIT here.
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'expired';
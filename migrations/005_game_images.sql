@@ -0,0 +1,2 @@
+-- Image URLs per game. Run once against databases created before games.images existed.
+ALTER TABLE games ADD COLUMN IF NOT EXISTS images JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,2 @@
+-- Store the charge expiry when PAYMENT_EXPIRY_MINUTES overrides the provider default.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
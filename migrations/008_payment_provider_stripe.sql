@@ -0,0 +1,3 @@
+-- Allow Stripe PaymentIntents alongside Midtrans.
+-- ALTER TYPE ... ADD VALUE cannot run inside a transaction block on PostgreSQL < 12, so no BEGIN/COMMIT here.
+ALTER TYPE payment_provider ADD VALUE IF NOT EXISTS 'stripe';
@@ -0,0 +1,8 @@
+-- Record admin-issued refunds on the payment.
+BEGIN;
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_at TIMESTAMP;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refund_amount DECIMAL(12,2);
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refund_reason TEXT;
+
+COMMIT;
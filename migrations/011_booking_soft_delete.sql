@@ -0,0 +1,7 @@
+-- Let admins hide test/spam bookings without losing them.
+BEGIN;
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_bookings_deleted_at ON bookings(deleted_at);
+
+COMMIT;
@@ -0,0 +1,3 @@
+-- Public reply from the game's owner on a review (POST /reviews/:id/reply).
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS reply TEXT;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS replied_at TIMESTAMP;
@@ -0,0 +1,2 @@
+-- Amount added by PATCH /bookings/:id/extend that still needs a follow-up payment.
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS extension_amount_due DECIMAL(10,2) DEFAULT 0.00;
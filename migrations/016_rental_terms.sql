@@ -0,0 +1,3 @@
+-- Per-game rental terms and the customer's acceptance on each booking.
+ALTER TABLE games ADD COLUMN IF NOT EXISTS rental_terms TEXT;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS terms_accepted_at TIMESTAMP;
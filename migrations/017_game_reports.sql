@@ -0,0 +1,17 @@
+-- Customer reports on game listings (POST /games/:id/report), one per user per game
+BEGIN;
+
+CREATE TABLE game_reports (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_game_reports_game_user ON game_reports(game_id, user_id);
+
+CREATE TRIGGER update_game_reports_updated_at BEFORE UPDATE ON game_reports FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMIT;
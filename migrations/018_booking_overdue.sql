@@ -0,0 +1,2 @@
+-- Active bookings past their end date move here via ProcessOverdueBookings.
+ALTER TYPE booking_status ADD VALUE IF NOT EXISTS 'overdue' AFTER 'active';
@@ -0,0 +1,5 @@
+-- Deposit collection mode per game (NULL uses DEPOSIT_MODE) and the mode each payment was made with.
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'authorized' AFTER 'pending';
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'voided';
+ALTER TABLE games ADD COLUMN IF NOT EXISTS deposit_mode VARCHAR(10) CHECK (deposit_mode IN ('charge', 'hold'));
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS deposit_mode VARCHAR(10) NOT NULL DEFAULT 'charge';
@@ -0,0 +1,6 @@
+-- Late-return fees: per-day rate on games, the charged fee on bookings, and a purpose to tell
+-- the late-fee payment apart from the booking's rental payment.
+ALTER TABLE games ADD COLUMN IF NOT EXISTS late_fee_per_day DECIMAL(10,2) NOT NULL DEFAULT 0.00;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS returned_at TIMESTAMP;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS late_fee DECIMAL(10,2) DEFAULT 0.00;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS purpose VARCHAR(20) NOT NULL DEFAULT 'rental';
@@ -0,0 +1,15 @@
+-- Before/after record of admin and system changes to bookings (GET /admin/bookings/:id/audit)
+BEGIN;
+
+CREATE TABLE booking_audits (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(30) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_booking_audits_booking_id ON booking_audits(booking_id, created_at DESC);
+
+COMMIT;
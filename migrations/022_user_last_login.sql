@@ -0,0 +1,2 @@
+-- Last successful login per user, for sorting GET /admin/users by last_login
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP;
@@ -0,0 +1,51 @@
+-- URL slugs for games (GET /games/slug/:slug) and the old slugs of renamed games.
+-- Existing games are backfilled from their names (without the accent folding the API does);
+-- duplicates get the first free -2, -3, ... in id order.
+BEGIN;
+
+ALTER TABLE games ADD COLUMN IF NOT EXISTS slug VARCHAR(220);
+
+-- The first game with each slug keeps it bare; the rest take the next -N suffix no game
+-- holds yet, so "Foo" twice next to an existing "Foo 2" becomes foo, foo-2 and foo-3.
+CREATE TEMP TABLE game_slug_base ON COMMIT DROP AS
+SELECT id,
+       COALESCE(NULLIF(btrim(left(lower(regexp_replace(name, '[^a-zA-Z0-9]+', '-', 'g')), 200), '-'), ''), 'game') AS slug,
+       row_number() OVER (
+           PARTITION BY COALESCE(NULLIF(btrim(left(lower(regexp_replace(name, '[^a-zA-Z0-9]+', '-', 'g')), 200), '-'), ''), 'game')
+           ORDER BY id
+       ) AS n
+FROM games
+WHERE slug IS NULL;
+
+UPDATE games g
+SET slug = b.slug
+FROM game_slug_base b
+WHERE g.id = b.id AND b.n = 1;
+
+DO $$
+DECLARE
+    dup RECORD;
+    suffix INT;
+BEGIN
+    FOR dup IN SELECT id, slug FROM game_slug_base WHERE n > 1 ORDER BY id LOOP
+        suffix := 2;
+        WHILE EXISTS (SELECT 1 FROM games WHERE slug = dup.slug || '-' || suffix) LOOP
+            suffix := suffix + 1;
+        END LOOP;
+        UPDATE games SET slug = dup.slug || '-' || suffix WHERE id = dup.id;
+    END LOOP;
+END $$;
+
+ALTER TABLE games ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE games ADD CONSTRAINT games_slug_key UNIQUE (slug);
+
+CREATE TABLE game_slug_redirects (
+    id BIGSERIAL PRIMARY KEY,
+    slug VARCHAR(220) NOT NULL UNIQUE,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_game_slug_redirects_game_id ON game_slug_redirects(game_id);
+
+COMMIT;
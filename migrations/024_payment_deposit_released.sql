@@ -0,0 +1,12 @@
+-- Whether capturing a deposit hold released the deposit, so the deposit summary no longer has to
+-- compare the captured amount with a booking total that extensions keep raising.
+-- Existing captures are backfilled against the total before any extension.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS deposit_released BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE payments p
+SET deposit_released = TRUE
+FROM bookings b
+WHERE p.booking_id = b.id
+  AND p.deposit_mode = 'hold'
+  AND p.status IN ('paid', 'refunded')
+  AND p.amount < b.total_amount - b.extension_amount_due;
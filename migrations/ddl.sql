@@ -1,8 +1,8 @@
 -- ENUM types (simplified)
 CREATE TYPE user_role AS ENUM ('customer', 'admin', 'super_admin');
-CREATE TYPE booking_status AS ENUM ('pending', 'confirmed', 'active', 'completed', 'cancelled');
-CREATE TYPE payment_status AS ENUM ('pending', 'paid', 'failed', 'refunded');
-CREATE TYPE payment_provider AS ENUM ('midtrans');
+CREATE TYPE booking_status AS ENUM ('pending', 'confirmed', 'active', 'overdue', 'completed', 'cancelled');
+CREATE TYPE payment_status AS ENUM ('pending', 'authorized', 'paid', 'failed', 'refunded', 'expired', 'voided');
+CREATE TYPE payment_provider AS ENUM ('midtrans', 'stripe');
 
 -- Users table
 CREATE TABLE users (
@@ -14,6 +14,7 @@ CREATE TABLE users (
     address TEXT,
     role user_role DEFAULT 'customer',
     is_active BOOLEAN DEFAULT true,
+    last_login_at TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
@@ -24,7 +25,8 @@ CREATE TABLE categories (
     name VARCHAR(100) UNIQUE NOT NULL,
     description TEXT,
     is_active BOOLEAN DEFAULT true,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
 -- Games table 
@@ -33,6 +35,7 @@ CREATE TABLE games (
     admin_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     category_id BIGINT NOT NULL REFERENCES categories(id),
     name VARCHAR(255) NOT NULL,
+    slug VARCHAR(220) NOT NULL UNIQUE,
     description TEXT,
     platform VARCHAR(100),
     stock INTEGER DEFAULT 1,
@@ -40,6 +43,10 @@ CREATE TABLE games (
     rental_price_per_day DECIMAL(10,2) NOT NULL,
     security_deposit DECIMAL(10,2) DEFAULT 0.00,
     condition VARCHAR(50) DEFAULT 'excellent',
+    images JSONB NOT NULL DEFAULT '[]',
+    rental_terms TEXT,
+    deposit_mode VARCHAR(10) CHECK (deposit_mode IN ('charge', 'hold')),
+    late_fee_per_day DECIMAL(10,2) NOT NULL DEFAULT 0.00,
     is_active BOOLEAN DEFAULT true,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
@@ -57,10 +64,17 @@ CREATE TABLE bookings (
     total_rental_price DECIMAL(10,2) NOT NULL,
     security_deposit DECIMAL(10,2) DEFAULT 0.00,
     total_amount DECIMAL(10,2) NOT NULL,
+    extension_amount_due DECIMAL(10,2) DEFAULT 0.00,
     status booking_status DEFAULT 'pending',
     notes TEXT,
+    terms_accepted_at TIMESTAMP,
+    cancellation_reason TEXT,
+    cancelled_at TIMESTAMP,
+    returned_at TIMESTAMP,
+    late_fee DECIMAL(10,2) DEFAULT 0.00,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
 );
 
 -- Payments table 
@@ -71,9 +85,21 @@ CREATE TABLE payments (
     provider_payment_id VARCHAR(255),
     amount DECIMAL(12,2) NOT NULL,
     status payment_status DEFAULT 'pending',
+    deposit_mode VARCHAR(10) NOT NULL DEFAULT 'charge',
+    deposit_released BOOLEAN NOT NULL DEFAULT FALSE,
+    purpose VARCHAR(20) NOT NULL DEFAULT 'rental',
     payment_method VARCHAR(100),
     paid_at TIMESTAMP,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    failed_at TIMESTAMP,
+    failure_reason TEXT,
+    processed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    admin_note TEXT,
+    refunded_at TIMESTAMP,
+    refund_amount DECIMAL(12,2),
+    refund_reason TEXT,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
 -- Reviews table 
@@ -84,12 +110,75 @@ CREATE TABLE reviews (
     game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
     rating INTEGER NOT NULL CHECK (rating >= 1 AND rating <= 5),
     comment TEXT,
+    reply TEXT,
+    replied_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    booking_id BIGINT REFERENCES bookings(id) ON DELETE SET NULL,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE email_verification_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    purpose VARCHAR(30) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    is_used BOOLEAN DEFAULT false,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE refresh_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE game_reports (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Booking audit log
+CREATE TABLE booking_audits (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(30) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Old slugs of renamed games
+CREATE TABLE game_slug_redirects (
+    id BIGSERIAL PRIMARY KEY,
+    slug VARCHAR(220) NOT NULL UNIQUE,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Indexes
-CREATE INDEX idx_users_email ON users(email);
+CREATE UNIQUE INDEX idx_users_email_lower ON users(LOWER(email));
 CREATE INDEX idx_users_role ON users(role);
 CREATE INDEX idx_games_admin_id ON games(admin_id);
 CREATE INDEX idx_games_category_id ON games(category_id);
@@ -97,8 +186,15 @@ CREATE INDEX idx_games_is_active ON games(is_active);
 CREATE INDEX idx_bookings_user_id ON bookings(user_id);
 CREATE INDEX idx_bookings_game_id ON bookings(game_id);
 CREATE INDEX idx_bookings_status ON bookings(status);
+CREATE UNIQUE INDEX idx_bookings_unique_active ON bookings(user_id, game_id, start_date, end_date) WHERE status <> 'cancelled';
+CREATE INDEX idx_bookings_deleted_at ON bookings(deleted_at);
 CREATE INDEX idx_payments_booking_id ON payments(booking_id);
 CREATE INDEX idx_reviews_game_id ON reviews(game_id);
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE UNIQUE INDEX idx_game_reports_game_user ON game_reports(game_id, user_id);
+CREATE INDEX idx_booking_audits_booking_id ON booking_audits(booking_id, created_at DESC);
+CREATE INDEX idx_game_slug_redirects_game_id ON game_slug_redirects(game_id);
 
 -- Triggers for updated_at
 CREATE OR REPLACE FUNCTION update_updated_at_column()
@@ -113,5 +209,11 @@ CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON users FOR EACH ROW EXECU
 CREATE TRIGGER update_games_updated_at BEFORE UPDATE ON games FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 CREATE TRIGGER update_bookings_updated_at BEFORE UPDATE ON bookings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 CREATE TRIGGER update_reviews_updated_at BEFORE UPDATE ON reviews FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_categories_updated_at BEFORE UPDATE ON categories FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_payments_updated_at BEFORE UPDATE ON payments FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_email_verification_tokens_updated_at BEFORE UPDATE ON email_verification_tokens FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_refresh_tokens_updated_at BEFORE UPDATE ON refresh_tokens FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_notifications_updated_at BEFORE UPDATE ON notifications FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_game_reports_updated_at BEFORE UPDATE ON game_reports FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
 